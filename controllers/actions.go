@@ -7,6 +7,7 @@ import (
 	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	clusterv1 "open-cluster-management.io/api/cluster/v1"
 )
@@ -53,7 +54,8 @@ func (r *ClusterGroupUpgradeReconciler) takeActionsAfterCompletion(
 	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) error {
 
 	clusterState := ranv1alpha1.ClusterState{
-		Name: cluster, State: utils.ClusterRemediationComplete}
+		Name: cluster, State: utils.ClusterRemediationComplete,
+		StartedAt: clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt, CompletedAt: metav1.Now()}
 	clusterGroupUpgrade.Status.Clusters = append(clusterGroupUpgrade.Status.Clusters, clusterState)
 
 	afterCompletion := clusterGroupUpgrade.Spec.Actions.AfterCompletion
@@ -157,6 +159,7 @@ func (r *ClusterGroupUpgradeReconciler) deleteResources(
 			return fmt.Errorf("failed to delete PlacementRules for CGU %s: %v", clusterGroupUpgrade.Name, err)
 		}
 		clusterGroupUpgrade.Status.PlacementRules = nil
+		clusterGroupUpgrade.Status.Placements = nil
 
 		if err := utils.DeletePlacementBindings(ctx, r.Client, ns, labels); err != nil {
 			return fmt.Errorf("failed to delete PlacementBindings for CGU %s: %v", clusterGroupUpgrade.Name, err)
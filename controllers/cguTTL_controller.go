@@ -0,0 +1,135 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+)
+
+// CguTTLReconciler deletes completed ClusterGroupUpgrades once their time to live has expired
+type CguTTLReconciler struct {
+	client.Client
+	Log    logr.Logger
+	Scheme *runtime.Scheme
+	// DefaultTTLSecondsAfterFinished applies to ClusterGroupUpgrades that don't set spec.ttlSecondsAfterFinished
+	DefaultTTLSecondsAfterFinished *int32
+}
+
+//+kubebuilder:rbac:groups=ran.openshift.io,resources=clustergroupupgrades,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=ran.openshift.io,resources=upgradereports,verbs=get
+
+// Reconcile deletes the ClusterGroupUpgrade if it completed more than its TTL ago and its UpgradeReport was written,
+// and requeues until then otherwise. The deletion is handled by the cleanup finalizer of the ClusterGroupUpgrade
+// controller.
+func (r *CguTTLReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	clusterGroupUpgrade := &ranv1alpha1.ClusterGroupUpgrade{}
+	if err := r.Get(ctx, req.NamespacedName, clusterGroupUpgrade); err != nil {
+		return doNotRequeue(), client.IgnoreNotFound(err)
+	}
+
+	if clusterGroupUpgrade.GetDeletionTimestamp() != nil || clusterGroupUpgrade.Status.Status.CompletedAt.IsZero() {
+		return doNotRequeue(), nil
+	}
+
+	ttl := r.getTTLSecondsAfterFinished(clusterGroupUpgrade)
+	if ttl == nil {
+		return doNotRequeue(), nil
+	}
+
+	expiresIn := time.Until(clusterGroupUpgrade.Status.Status.CompletedAt.Add(time.Duration(*ttl) * time.Second))
+	if expiresIn > 0 {
+		return requeueWithCustomInterval(expiresIn), nil
+	}
+
+	// Keep the ClusterGroupUpgrade until its report is written, so that the result of the upgrade isn't lost
+	report := &ranv1alpha1.UpgradeReport{}
+	err := r.Get(ctx, types.NamespacedName{Name: getUpgradeReportName(clusterGroupUpgrade), Namespace: req.Namespace}, report)
+	if errors.IsNotFound(err) {
+		r.Log.Info("[Reconcile] Waiting for the UpgradeReport before deleting the ClusterGroupUpgrade", "name", req.NamespacedName)
+		return requeueWithShortInterval(), nil
+	}
+	if err != nil {
+		return doNotRequeue(), err
+	}
+
+	r.Log.Info("[Reconcile] Deleting ClusterGroupUpgrade after TTL expired", "name", req.NamespacedName, "ttlSecondsAfterFinished", *ttl)
+	uid := clusterGroupUpgrade.GetUID()
+	err = r.Delete(ctx, clusterGroupUpgrade, client.Preconditions{UID: &uid})
+	if err != nil && !errors.IsNotFound(err) {
+		return doNotRequeue(), err
+	}
+	return doNotRequeue(), nil
+}
+
+func (r *CguTTLReconciler) getTTLSecondsAfterFinished(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) *int32 {
+	if clusterGroupUpgrade.Spec.TTLSecondsAfterFinished != nil {
+		return clusterGroupUpgrade.Spec.TTLSecondsAfterFinished
+	}
+	return r.DefaultTTLSecondsAfterFinished
+}
+
+// getDefaultTTLSecondsAfterFinished reads the operator-wide TTL from the environment, nil if unset or invalid
+func (r *CguTTLReconciler) getDefaultTTLSecondsAfterFinished() *int32 {
+	value, isSet := os.LookupEnv(utils.CGUTTLSecondsAfterFinishedEnv)
+	if !isSet {
+		return nil
+	}
+	ttl, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || ttl < 0 {
+		r.Log.Info("Invalid value for the default TTL, completed CGUs are not deleted", "env", utils.CGUTTLSecondsAfterFinishedEnv, "value", value)
+		return nil
+	}
+	result := int32(ttl)
+	return &result
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *CguTTLReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.DefaultTTLSecondsAfterFinished == nil {
+		r.DefaultTTLSecondsAfterFinished = r.getDefaultTTLSecondsAfterFinished()
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("cguTTL").
+		For(&ranv1alpha1.ClusterGroupUpgrade{},
+			// only completed CGUs are of interest
+			builder.WithPredicates(predicate.Funcs{
+				GenericFunc: func(e event.GenericEvent) bool { return false },
+				CreateFunc:  func(e event.CreateEvent) bool { return true },
+				DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return !e.ObjectNew.(*ranv1alpha1.ClusterGroupUpgrade).Status.Status.CompletedAt.IsZero()
+				},
+			})).
+		Complete(r)
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCguTTL_Reconcile(t *testing.T) {
+	ttl := func(seconds int32) *int32 { return &seconds }
+
+	testcases := []struct {
+		name         string
+		completedAt  time.Time
+		ttl          *int32
+		defaultTTL   *int32
+		noReport     bool
+		wantDeleted  bool
+		wantRequeued bool
+	}{
+		{
+			name:        "not completed",
+			ttl:         ttl(0),
+			wantDeleted: false,
+		},
+		{
+			name:        "no ttl",
+			completedAt: time.Now().Add(-time.Hour),
+			wantDeleted: false,
+		},
+		{
+			name:         "ttl not expired",
+			completedAt:  time.Now().Add(-time.Minute),
+			ttl:          ttl(3600),
+			wantRequeued: true,
+		},
+		{
+			name:        "ttl expired",
+			completedAt: time.Now().Add(-time.Hour),
+			ttl:         ttl(60),
+			wantDeleted: true,
+		},
+		{
+			name:         "ttl expired before the report is written",
+			completedAt:  time.Now().Add(-time.Hour),
+			ttl:          ttl(60),
+			noReport:     true,
+			wantRequeued: true,
+		},
+		{
+			name:        "default ttl expired",
+			completedAt: time.Now().Add(-time.Hour),
+			defaultTTL:  ttl(60),
+			wantDeleted: true,
+		},
+		{
+			name:         "spec ttl overrides default ttl",
+			completedAt:  time.Now().Add(-time.Hour),
+			ttl:          ttl(7200),
+			defaultTTL:   ttl(60),
+			wantRequeued: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default", UID: "123"},
+				Spec:       ranv1alpha1.ClusterGroupUpgradeSpec{TTLSecondsAfterFinished: tc.ttl},
+			}
+			if !tc.completedAt.IsZero() {
+				cgu.Status.Status.CompletedAt = metav1.NewTime(tc.completedAt)
+			}
+			objs := []client.Object{cgu}
+			if !tc.noReport {
+				objs = append(objs, newUpgradeReport(cgu, getUpgradeReportName(cgu)))
+			}
+			c, _ := getFakeClientFromObjects(objs...)
+			r := &CguTTLReconciler{
+				Client:                         c,
+				Log:                            logr.Discard(),
+				Scheme:                         testscheme,
+				DefaultTTLSecondsAfterFinished: tc.defaultTTL,
+			}
+
+			key := types.NamespacedName{Name: "cgu", Namespace: "default"}
+			result, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRequeued, result.RequeueAfter > 0)
+
+			err = c.Get(context.TODO(), key, &ranv1alpha1.ClusterGroupUpgrade{})
+			assert.Equal(t, tc.wantDeleted, errors.IsNotFound(err))
+		})
+	}
+}
+
+func TestCguTTL_getDefaultTTLSecondsAfterFinished(t *testing.T) {
+	r := &CguTTLReconciler{Log: logr.Discard()}
+
+	os.Unsetenv(utils.CGUTTLSecondsAfterFinishedEnv)
+	assert.Nil(t, r.getDefaultTTLSecondsAfterFinished())
+
+	os.Setenv(utils.CGUTTLSecondsAfterFinishedEnv, "86400")
+	assert.Equal(t, int32(86400), *r.getDefaultTTLSecondsAfterFinished())
+
+	os.Setenv(utils.CGUTTLSecondsAfterFinishedEnv, "-1")
+	assert.Nil(t, r.getDefaultTTLSecondsAfterFinished())
+
+	os.Setenv(utils.CGUTTLSecondsAfterFinishedEnv, "abc")
+	assert.Nil(t, r.getDefaultTTLSecondsAfterFinished())
+	os.Unsetenv(utils.CGUTTLSecondsAfterFinishedEnv)
+}
@@ -60,14 +60,19 @@ type ClusterGroupUpgradeReconciler struct {
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// UsePlacementAPI places the policies with Placements instead of PlacementRules
+	UsePlacementAPI bool
 }
 
 type policiesInfo struct {
 	invalidPolicies      []string
 	missingPolicies      []string
+	missingPolicySets    []string
 	presentPolicies      []*unstructured.Unstructured
 	compliantPolicies    []*unstructured.Unstructured
 	duplicatedPoliciesNs map[string][]string
+	// duplicatedPolicySetsNs holds the namespaces of the managed policy sets found in more than one namespace
+	duplicatedPolicySetsNs map[string][]string
 }
 
 const statusUpdateWaitInMilliSeconds = 100
@@ -102,9 +107,12 @@ func requeueWithCustomInterval(interval time.Duration) ctrl.Result {
 //+kubebuilder:rbac:groups=ran.openshift.io,resources=precachingconfigs,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=ran.openshift.io,resources=precachingconfigs/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ran.openshift.io,resources=precachingconfigs/finalizers,verbs=update
+//+kubebuilder:rbac:groups=ran.openshift.io,resources=upgradereports,verbs=get;list;watch;create
 //+kubebuilder:rbac:groups=apps.open-cluster-management.io,resources=placementrules,verbs=get;list;watch;create;update;patch;delete;deletecollection
 //+kubebuilder:rbac:groups=policy.open-cluster-management.io,resources=placementbindings,verbs=get;list;watch;create;update;patch;delete;deletecollection
+//+kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=placements,verbs=get;list;watch;create;update;patch;delete;deletecollection
 //+kubebuilder:rbac:groups=policy.open-cluster-management.io,resources=policies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy.open-cluster-management.io,resources=policysets,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list;watch;update;patch
 //+kubebuilder:rbac:groups=action.open-cluster-management.io,resources=managedclusteractions,verbs=create;update;delete;get;list;watch;patch;deletecollection
 //+kubebuilder:rbac:groups=view.open-cluster-management.io,resources=managedclusterviews,verbs=create;update;delete;get;list;watch;patch;deletecollection
@@ -166,6 +174,8 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 	suceededCondition := meta.FindStatusCondition(clusterGroupUpgrade.Status.Conditions, string(utils.ConditionTypes.Succeeded))
 	progressingCondition := meta.FindStatusCondition(clusterGroupUpgrade.Status.Conditions, string(utils.ConditionTypes.Progressing))
 
+	// The report is written only once the completion of the CGU is persisted
+	var writeUpgradeReport bool
 	if suceededCondition != nil {
 		if clusterGroupUpgrade.Status.Status.CompletedAt.IsZero() {
 			if shouldDeleteObjects(clusterGroupUpgrade) {
@@ -182,14 +192,24 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 			}
 			// Set completion time only after post actions are executed with no errors
 			clusterGroupUpgrade.Status.Status.CompletedAt = metav1.Now()
+			writeUpgradeReport = true
 			clusterGroupUpgrade.Status.Status.CurrentBatch = 0
 			clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt = metav1.Time{}
+			clusterGroupUpgrade.Status.Status.CurrentBatchCompletedAt = metav1.Time{}
 			clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress = nil
+		} else if clusterGroupUpgrade.Status.UpgradeReport == "" {
+			// Retry writing the report if it failed after the completion was persisted
+			err = r.createUpgradeReport(ctx, clusterGroupUpgrade)
+			if err != nil {
+				return
+			}
 		}
 	} else if progressingCondition == nil || progressingCondition.Status == metav1.ConditionFalse {
 
-		var allManagedPoliciesExist, allManifestWorkTemplatesExist bool
+		var allManagedPoliciesExist, allManifestWorkTemplatesExist, isImageBasedUpgradeValid bool
+		var invalidImageBasedUpgrade []string
 		var managedPoliciesInfo policiesInfo
+		var templatesInfo manifestWorkTemplatesInfo
 		var clusters []string
 		var reconcile bool
 		clusters, reconcile, err = r.validateCR(ctx, clusterGroupUpgrade)
@@ -217,25 +237,40 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 			return
 		}
 
-		if clusterGroupUpgrade.RolloutType() == ranv1alpha1.RolloutTypes.Policy {
+		switch clusterGroupUpgrade.RolloutType() {
+		case ranv1alpha1.RolloutTypes.Policy:
 			allManagedPoliciesExist, managedPoliciesInfo, err =
 				r.doManagedPoliciesExist(ctx, clusterGroupUpgrade, clusters)
-		} else {
-			allManifestWorkTemplatesExist, _, err = r.validateManifestWorkTemplates(ctx, clusterGroupUpgrade)
+		case ranv1alpha1.RolloutTypes.ImageBasedUpgrade:
+			isImageBasedUpgradeValid, invalidImageBasedUpgrade = r.validateImageBasedUpgrade(clusterGroupUpgrade)
+		default:
+			allManifestWorkTemplatesExist, templatesInfo, err = r.validateManifestWorkTemplates(ctx, clusterGroupUpgrade)
 		}
 		if err != nil {
 			return
 		}
 
-		if allManagedPoliciesExist || allManifestWorkTemplatesExist {
-			// TODO validate CV in manifest work templates
-			err = r.validateOpenshiftUpgradeVersion(clusterGroupUpgrade, managedPoliciesInfo.presentPolicies)
+		if allManagedPoliciesExist || allManifestWorkTemplatesExist || isImageBasedUpgradeValid {
+			if clusterGroupUpgrade.RolloutType() == ranv1alpha1.RolloutTypes.ManifestWork {
+				err = r.validateManifestWorkOpenshiftUpgradeVersion(clusterGroupUpgrade, templatesInfo.manifests)
+			} else {
+				err = r.validateOpenshiftUpgradeVersion(clusterGroupUpgrade, managedPoliciesInfo.presentPolicies)
+			}
 			if err != nil {
 				nextReconcile = requeueWithLongInterval()
 				err = r.updateStatus(ctx, clusterGroupUpgrade)
 				return
 			}
 
+			if clusterGroupUpgrade.Spec.PolicyOrdering == ranv1alpha1.PolicyOrdering.Dependencies {
+				managedPoliciesInfo.presentPolicies, err = r.sortPoliciesByDependencies(clusterGroupUpgrade, managedPoliciesInfo.presentPolicies)
+				if err != nil {
+					nextReconcile = requeueWithLongInterval()
+					err = r.updateStatus(ctx, clusterGroupUpgrade)
+					return
+				}
+			}
+
 			err = r.validatePoliciesDependenciesOrder(clusterGroupUpgrade, managedPoliciesInfo.presentPolicies)
 			if err != nil {
 				nextReconcile = requeueWithLongInterval()
@@ -277,6 +312,10 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 				statusMessage = fmt.Sprintf("Missing managed policies: %s ", managedPoliciesInfo.missingPolicies)
 			}
 
+			if len(managedPoliciesInfo.missingPolicySets) != 0 {
+				statusMessage = fmt.Sprintf("Missing managed policy sets: %s ", managedPoliciesInfo.missingPolicySets)
+			}
+
 			if len(managedPoliciesInfo.invalidPolicies) != 0 {
 				statusMessage = fmt.Sprintf("Invalid managed policies: %s ", managedPoliciesInfo.invalidPolicies)
 			}
@@ -287,6 +326,28 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 					"Managed policy name should be unique, but was found in multiple namespaces: %s ", jsonData)
 				conditionReason = utils.ConditionReasons.AmbiguousManagedPoliciesNames
 			}
+
+			if len(managedPoliciesInfo.duplicatedPolicySetsNs) != 0 {
+				jsonData, _ := json.Marshal(managedPoliciesInfo.duplicatedPolicySetsNs)
+				statusMessage = fmt.Sprintf(
+					"Managed policy set name should be unique, but was found in multiple namespaces: %s ", jsonData)
+				conditionReason = utils.ConditionReasons.AmbiguousManagedPoliciesNames
+			}
+
+			if len(templatesInfo.missingTemplates) != 0 {
+				statusMessage = fmt.Sprintf("Missing manifestwork templates: %s ", templatesInfo.missingTemplates)
+				conditionReason = utils.ConditionReasons.NotAllManifestWorkTemplatesExist
+			}
+
+			if len(templatesInfo.invalidTemplates) != 0 {
+				statusMessage = fmt.Sprintf("Invalid manifestwork templates: %s ", strings.Join(templatesInfo.invalidTemplates, ", "))
+				conditionReason = utils.ConditionReasons.InvalidManifestWorkTemplates
+			}
+
+			if len(invalidImageBasedUpgrade) != 0 {
+				statusMessage = fmt.Sprintf("Invalid image based upgrade: %s ", strings.Join(invalidImageBasedUpgrade, ", "))
+				conditionReason = utils.ConditionReasons.InvalidImageBasedUpgrade
+			}
 			// If there are errors regarding the managedPolicies, update the Status accordingly.
 			utils.SetStatusCondition(
 				&clusterGroupUpgrade.Status.Conditions,
@@ -300,9 +361,9 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 			err = r.updateStatus(ctx, clusterGroupUpgrade)
 			return
 		}
-		// TODO precaching support for CRs in manifest work template?
 		// Pass in already compliant policies as the catalog source info is needed by precaching
-		err = r.reconcilePrecaching(ctx, clusterGroupUpgrade, clusters, append(managedPoliciesInfo.presentPolicies, managedPoliciesInfo.compliantPolicies...))
+		err = r.reconcilePrecaching(ctx, clusterGroupUpgrade, clusters,
+			append(managedPoliciesInfo.presentPolicies, managedPoliciesInfo.compliantPolicies...), templatesInfo.manifests)
 		if err != nil {
 			r.Log.Error(err, "reconcilePrecaching error")
 			return
@@ -504,8 +565,9 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 		nextReconcile = requeueWithCustomInterval(requeueAfter)
 
 		// At first, assume all clusters in the batch start applying policies starting with the first one.
-		// Also set the start time of the current batch to the current timestamp.
-		if clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.IsZero() {
+		// Also set the start time of the current batch to the current timestamp. A paused upgrade doesn't
+		// start its next batch.
+		if clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.IsZero() && *clusterGroupUpgrade.Spec.Enable {
 			r.initializeBatchProgress(clusterGroupUpgrade)
 			if shouldDeleteObjects(clusterGroupUpgrade) {
 				err = r.cleanupManifestWorkForPreviousBatch(ctx, clusterGroupUpgrade)
@@ -517,6 +579,17 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 			clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt = metav1.Now()
 		}
 
+		// The upgrade was resumed after being paused
+		if *clusterGroupUpgrade.Spec.Enable && progressingCondition.Reason == string(utils.ConditionReasons.Paused) {
+			utils.SetStatusCondition(
+				&clusterGroupUpgrade.Status.Conditions,
+				utils.ConditionTypes.Progressing,
+				utils.ConditionReasons.InProgress,
+				metav1.ConditionTrue,
+				utils.InProgressMessages[clusterGroupUpgrade.RolloutType()],
+			)
+		}
+
 		// Check whether we have time left on the cgu timeout
 		if time.Since(clusterGroupUpgrade.Status.Status.StartedAt.Time) > time.Duration(clusterGroupUpgrade.Spec.RemediationStrategy.Timeout)*time.Minute {
 			// We are completely out of time
@@ -536,6 +609,17 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 			)
 			err = r.handleBatchTimeout(ctx, clusterGroupUpgrade)
 			nextReconcile = requeueImmediately()
+		} else if !*clusterGroupUpgrade.Spec.Enable {
+			// The upgrade was disabled while in progress. Hold the remediation where it is until it is enabled again,
+			// the placements are left as they are and the overall timeout keeps running.
+			utils.SetStatusCondition(
+				&clusterGroupUpgrade.Status.Conditions,
+				utils.ConditionTypes.Progressing,
+				utils.ConditionReasons.Paused,
+				metav1.ConditionTrue,
+				"Paused, set enable to true to resume",
+			)
+			nextReconcile = requeueWithLongInterval()
 		} else if clusterGroupUpgrade.Status.Status.CurrentBatch < len(clusterGroupUpgrade.Status.RemediationPlan) {
 			// Check if current policies have become compliant and if new policies have to be applied.
 			var isBatchComplete, isSoaking, isProgressing bool
@@ -544,13 +628,48 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 				return
 			}
 
+			var soakLeft time.Duration
+			var regressedClusters map[string][]string
 			if isBatchComplete {
+				soakLeft, regressedClusters, err = r.soakCompletedBatch(ctx, clusterGroupUpgrade)
+				if err != nil {
+					return
+				}
+			}
+
+			if len(regressedClusters) != 0 {
+				jsonData, _ := json.Marshal(regressedClusters)
+				statusMessage := fmt.Sprintf("Clusters are no longer compliant with managed policies during the batch soak: %s", jsonData)
+				utils.SetStatusCondition(
+					&clusterGroupUpgrade.Status.Conditions,
+					utils.ConditionTypes.Progressing,
+					utils.ConditionReasons.Failed,
+					metav1.ConditionFalse,
+					statusMessage,
+				)
+				utils.SetStatusCondition(
+					&clusterGroupUpgrade.Status.Conditions,
+					utils.ConditionTypes.Succeeded,
+					utils.ConditionReasons.Failed,
+					metav1.ConditionFalse,
+					statusMessage,
+				)
+				nextReconcile = requeueImmediately()
+			} else if isBatchComplete && soakLeft > 0 {
+				r.Log.Info("[Reconcile] Soaking completed batch", "batchIndex", clusterGroupUpgrade.Status.Status.CurrentBatch, "soakLeft", soakLeft)
+				if soakLeft > time.Minute {
+					nextReconcile = requeueWithMediumInterval()
+				} else {
+					nextReconcile = requeueWithCustomInterval(soakLeft)
+				}
+			} else if isBatchComplete {
 				// If the upgrade is completed for the current batch, cleanup and move to the next.
 				r.Log.Info("[Reconcile] Upgrade completed for batch", "batchIndex", clusterGroupUpgrade.Status.Status.CurrentBatch)
 				if clusterGroupUpgrade.RolloutType() == ranv1alpha1.RolloutTypes.Policy {
 					r.cleanupPlacementRules(ctx, clusterGroupUpgrade)
 				}
 				clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt = metav1.Time{}
+				clusterGroupUpgrade.Status.Status.CurrentBatchCompletedAt = metav1.Time{}
 				clusterGroupUpgrade.Status.Status.CurrentBatch++
 				nextReconcile = requeueImmediately()
 			} else {
@@ -643,7 +762,39 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 			if err != nil {
 				return
 			}
-			if isUpgradeComplete {
+			if isUpgradeComplete && hasFailedClusters(clusterGroupUpgrade) {
+				utils.SetStatusCondition(
+					&clusterGroupUpgrade.Status.Conditions,
+					utils.ConditionTypes.Progressing,
+					utils.ConditionReasons.Failed,
+					metav1.ConditionFalse,
+					"Upgrade failed on some clusters",
+				)
+				utils.SetStatusCondition(
+					&clusterGroupUpgrade.Status.Conditions,
+					utils.ConditionTypes.Succeeded,
+					utils.ConditionReasons.Failed,
+					metav1.ConditionFalse,
+					"Upgrade failed on some clusters",
+				)
+				nextReconcile = requeueImmediately()
+			} else if isUpgradeComplete && hasPolicyTimedOutClusters(clusterGroupUpgrade) {
+				utils.SetStatusCondition(
+					&clusterGroupUpgrade.Status.Conditions,
+					utils.ConditionTypes.Progressing,
+					utils.ConditionReasons.TimedOut,
+					metav1.ConditionFalse,
+					utils.TimeoutMessages[clusterGroupUpgrade.RolloutType()]+" on some clusters",
+				)
+				utils.SetStatusCondition(
+					&clusterGroupUpgrade.Status.Conditions,
+					utils.ConditionTypes.Succeeded,
+					utils.ConditionReasons.TimedOut,
+					metav1.ConditionFalse,
+					utils.TimeoutMessages[clusterGroupUpgrade.RolloutType()]+" on some clusters",
+				)
+				nextReconcile = requeueImmediately()
+			} else if isUpgradeComplete {
 				utils.SetStatusCondition(
 					&clusterGroupUpgrade.Status.Conditions,
 					utils.ConditionTypes.Progressing,
@@ -665,6 +816,13 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 
 	// Update status
 	err = r.updateStatus(ctx, clusterGroupUpgrade)
+	if err == nil && writeUpgradeReport {
+		// The report is written once the completion is persisted, then recorded in the status so it isn't retried
+		err = r.createUpgradeReport(ctx, clusterGroupUpgrade)
+		if err == nil {
+			err = r.updateStatus(ctx, clusterGroupUpgrade)
+		}
+	}
 	return
 }
 
@@ -700,7 +858,8 @@ func (r *ClusterGroupUpgradeReconciler) handleBatchTimeout(ctx context.Context,
 
 	for _, batchClusterName := range clusterGroupUpgrade.Status.RemediationPlan[batchIndex] {
 		clusterFinalState := ranv1alpha1.ClusterState{
-			Name: batchClusterName, State: utils.ClusterRemediationComplete}
+			Name: batchClusterName, State: utils.ClusterRemediationComplete,
+			StartedAt: clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt, CompletedAt: metav1.Now()}
 		// In certain edge cases we need to be careful to avoid a nil pointer on this access
 		clusterStatus := clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[batchClusterName]
 		if clusterStatus == nil {
@@ -714,6 +873,8 @@ func (r *ClusterGroupUpgradeReconciler) handleBatchTimeout(ctx context.Context,
 			switch clusterGroupUpgrade.RolloutType() {
 			case ranv1alpha1.RolloutTypes.Policy:
 				r.handlePolicyTimeoutForCluster(clusterGroupUpgrade, batchClusterName, &clusterFinalState)
+			case ranv1alpha1.RolloutTypes.ImageBasedUpgrade:
+				r.handleImageBasedUpgradeTimeoutForCluster(clusterGroupUpgrade, batchClusterName, &clusterFinalState)
 			default:
 				err := r.handleManifestWorkTimeoutForCluster(ctx, clusterGroupUpgrade, batchClusterName, &clusterFinalState)
 				if err != nil {
@@ -790,16 +951,20 @@ func (r *ClusterGroupUpgradeReconciler) updateClusterProgress(
 	case ranv1alpha1.RolloutTypes.Policy:
 		index = &clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName].PolicyIndex
 		size = len(clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade)
+	case ranv1alpha1.RolloutTypes.ImageBasedUpgrade:
+		index = &clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName].ImageBasedUpgradeIndex
+		size = len(clusterGroupUpgrade.GetImageBasedUpgradeStages())
 	default:
 		index = &clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName].ManifestWorkIndex
-		size = len(clusterGroupUpgrade.Spec.ManifestWorkTemplates)
+		size = len(clusterGroupUpgrade.GetManifestWorkStages())
 	}
 
 	if *clusterProgressState == ranv1alpha1.NotStarted {
 		*index = new(int)
 		**index = 0
 		*clusterProgressState = ranv1alpha1.InProgress
-	} else if *clusterProgressState == ranv1alpha1.Completed {
+	} else if *clusterProgressState == ranv1alpha1.Completed || *clusterProgressState == ranv1alpha1.TimedOut ||
+		*clusterProgressState == ranv1alpha1.Failed {
 		return true, false, false, nil
 	}
 
@@ -809,9 +974,25 @@ func (r *ClusterGroupUpgradeReconciler) updateClusterProgress(
 	}
 
 	isProgressing := currentIndex > **index
+	if *clusterProgressState == ranv1alpha1.TimedOut {
+		// The cluster exceeded the remediation timeout of its current policy, stop remediating it
+		**index = currentIndex
+		clusterFinalState := ranv1alpha1.ClusterState{
+			Name: clusterName, State: utils.ClusterRemediationPolicyTimedout,
+			StartedAt: clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt, CompletedAt: metav1.Now()}
+		r.handlePolicyTimeoutForCluster(clusterGroupUpgrade, clusterName, &clusterFinalState)
+		if err := r.removeClusterFromPolicyPlacement(ctx, clusterGroupUpgrade, clusterName); err != nil {
+			return false, false, false, err
+		}
+		utils.DeleteMultiCloudObjects(ctx, r.Client, clusterGroupUpgrade, clusterName)
+		clusterGroupUpgrade.Status.Clusters = append(clusterGroupUpgrade.Status.Clusters, clusterFinalState)
+		return true, isSoaking, isProgressing, nil
+	}
 	if currentIndex >= size {
 		clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName].PolicyIndex = nil
 		clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName].ManifestWorkIndex = nil
+		clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName].ImageBasedUpgradeIndex = nil
+		clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName].ManifestWorks = nil
 		*clusterProgressState = ranv1alpha1.Completed
 		err := r.takeActionsAfterCompletion(ctx, clusterGroupUpgrade, clusterName)
 		if err != nil {
@@ -828,11 +1009,82 @@ func (r *ClusterGroupUpgradeReconciler) updateClusterProgress(
 	return false, isSoaking, isProgressing, nil
 }
 
+// hasFailedClusters checks if the upgrade of a cluster of any batch reported a failure
+func hasFailedClusters(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) bool {
+	return hasClustersInFinalState(clusterGroupUpgrade, utils.ClusterRemediationFailed)
+}
+
+// hasPolicyTimedOutClusters checks if a cluster of any batch exceeded the remediation timeout of a policy
+func hasPolicyTimedOutClusters(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) bool {
+	return hasClustersInFinalState(clusterGroupUpgrade, utils.ClusterRemediationPolicyTimedout)
+}
+
+// hasClustersInFinalState checks if a cluster was recorded with the given state in the final cluster states
+func hasClustersInFinalState(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, state string) bool {
+	for _, clusterState := range clusterGroupUpgrade.Status.Clusters {
+		if clusterState.State == state {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+soakCompletedBatch holds the completed current batch for the batch soak period. For policy rollouts, it checks
+that the clusters which completed the batch are still compliant with all the managed policies.
+
+	returns: the time left in the soak period, 0 once it is over or if there is none
+	         the regressed clusters with the managed policies they are no longer compliant with
+	         error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) soakCompletedBatch(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) (time.Duration, map[string][]string, error) {
+
+	batchSoak := time.Duration(clusterGroupUpgrade.Spec.RemediationStrategy.BatchSoakSeconds) * time.Second
+	if batchSoak <= 0 {
+		return 0, nil, nil
+	}
+	if clusterGroupUpgrade.Status.Status.CurrentBatchCompletedAt.IsZero() {
+		clusterGroupUpgrade.Status.Status.CurrentBatchCompletedAt = metav1.Now()
+	}
+
+	regressedClusters := make(map[string][]string)
+	if clusterGroupUpgrade.RolloutType() == ranv1alpha1.RolloutTypes.Policy {
+		for _, managedPolicyInfo := range clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade {
+			managedPolicy, err := r.getPolicyByName(ctx, managedPolicyInfo.Name, managedPolicyInfo.Namespace)
+			if err != nil {
+				return 0, nil, err
+			}
+			for clusterName, clusterProgress := range clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress {
+				if clusterProgress.State != ranv1alpha1.Completed {
+					continue
+				}
+				// The cluster was compliant when it completed the batch, only an explicit NonCompliant is a
+				// regression, a missing or Pending status is waited on
+				if r.isClusterReportedNonCompliant(clusterName, managedPolicy) {
+					regressedClusters[clusterName] = append(regressedClusters[clusterName], managedPolicyInfo.Name)
+				}
+			}
+		}
+	}
+	if len(regressedClusters) != 0 {
+		return 0, regressedClusters, nil
+	}
+
+	soakLeft := time.Until(clusterGroupUpgrade.Status.Status.CurrentBatchCompletedAt.Add(batchSoak))
+	if soakLeft < 0 {
+		soakLeft = 0
+	}
+	return soakLeft, nil, nil
+}
+
 func (r *ClusterGroupUpgradeReconciler) getClusterProgress(
 	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterName string, startIndex int) (int, bool, error) {
 	switch clusterGroupUpgrade.RolloutType() {
 	case ranv1alpha1.RolloutTypes.Policy:
 		return r.getNextNonCompliantPolicyForCluster(ctx, clusterGroupUpgrade, clusterName, startIndex)
+	case ranv1alpha1.RolloutTypes.ImageBasedUpgrade:
+		return r.getNextImageBasedUpgradeStageForCluster(ctx, clusterGroupUpgrade, clusterName, startIndex)
 	default:
 		return r.getNextManifestWorkForCluster(ctx, clusterGroupUpgrade, clusterName, startIndex)
 	}
@@ -880,6 +1132,9 @@ func (r *ClusterGroupUpgradeReconciler) remediateCurrentBatch(
 		err = r.processMonitoredObjects(ctx, clusterGroupUpgrade)
 		return err
 
+	case ranv1alpha1.RolloutTypes.ImageBasedUpgrade:
+		return r.updateImageBasedUpgradeForCurrentBatch(ctx, clusterGroupUpgrade)
+
 	default:
 		return r.updateManifestWorkForCurrentBatch(ctx, clusterGroupUpgrade)
 	}
@@ -912,9 +1167,9 @@ func (r *ClusterGroupUpgradeReconciler) buildRemediationPlan(ctx context.Context
 	if len(managedPolicies) > 0 {
 		// Get all clusters from the CR that are non compliant with at least one of the managedPolicies.
 		clusterMap = r.getClustersNonCompliantWithManagedPolicies(clusters, managedPolicies)
-	} else if len(clusterGroupUpgrade.Spec.ManifestWorkTemplates) > 0 {
+	} else if clusterGroupUpgrade.RolloutType() != ranv1alpha1.RolloutTypes.Policy {
 		clusterMap = make(map[string]bool, len(clusters))
-		// Assume all clusters need manifest work rollout
+		// Assume all clusters need manifest work or image based upgrade rollout
 		for _, cluster := range clusters {
 			clusterMap[cluster] = true
 		}
@@ -1212,6 +1467,10 @@ func (r *ClusterGroupUpgradeReconciler) handleCguFinalizer(
 					return utils.StopReconciling, err
 				}
 			}
+			// The manifestworks kept in place outlive the completion of the upgrade, they are deleted with it
+			if err := utils.DeleteKeptInPlaceManifestWorks(ctx, r.Client, clusterGroupUpgrade); err != nil {
+				return utils.StopReconciling, err
+			}
 
 			// Remove cguFinalizer. Once all finalizers have been removed, the object will be deleted.
 			controllerutil.RemoveFinalizer(clusterGroupUpgrade, utils.CleanupFinalizer)
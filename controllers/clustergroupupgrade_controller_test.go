@@ -17,13 +17,20 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	policiesv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -198,3 +205,142 @@ func TestClusterGroupUpgradeReconciler_getCGUControllerWorkerCount(t *testing.T)
 		})
 	}
 }
+
+func TestClusterGroupUpgradeReconciler_soakCompletedBatch(t *testing.T) {
+	newPolicy := func(name string, compliance map[string]policiesv1.ComplianceState) *policiesv1.Policy {
+		policy := &policiesv1.Policy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+		for clusterName, state := range compliance {
+			policy.Status.Status = append(policy.Status.Status,
+				&policiesv1.CompliancePerClusterStatus{ClusterName: clusterName, ComplianceState: state})
+		}
+		return policy
+	}
+	newCGU := func(batchSoakSeconds int, completedAt time.Time) *ranv1alpha1.ClusterGroupUpgrade {
+		return &ranv1alpha1.ClusterGroupUpgrade{
+			Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+				RemediationStrategy: &ranv1alpha1.RemediationStrategySpec{MaxConcurrency: 2, BatchSoakSeconds: batchSoakSeconds},
+			},
+			Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+				ManagedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{
+					{Name: "policy1", Namespace: "default"},
+					{Name: "policy2", Namespace: "default"},
+				},
+				Status: ranv1alpha1.UpgradeStatus{
+					CurrentBatchCompletedAt: metav1.NewTime(completedAt),
+					CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+						"spoke1": {State: ranv1alpha1.Completed},
+						"spoke2": {State: ranv1alpha1.TimedOut},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		cgu           *ranv1alpha1.ClusterGroupUpgrade
+		policies      []client.Object
+		wantSoaking   bool
+		wantRegressed map[string][]string
+	}{
+		{
+			name: "no batch soak",
+			cgu:  newCGU(0, time.Time{}),
+		},
+		{
+			name: "soak starts",
+			cgu:  newCGU(3600, time.Time{}),
+			policies: []client.Object{
+				newPolicy("policy1", map[string]policiesv1.ComplianceState{"spoke1": policiesv1.Compliant}),
+				newPolicy("policy2", map[string]policiesv1.ComplianceState{"spoke1": policiesv1.Compliant}),
+			},
+			wantSoaking: true,
+		},
+		{
+			name: "soak is over",
+			cgu:  newCGU(3600, time.Now().Add(-2*time.Hour)),
+			policies: []client.Object{
+				newPolicy("policy1", map[string]policiesv1.ComplianceState{"spoke1": policiesv1.Compliant}),
+				newPolicy("policy2", map[string]policiesv1.ComplianceState{"spoke1": policiesv1.Compliant}),
+			},
+		},
+		{
+			name: "completed cluster regressed",
+			cgu:  newCGU(3600, time.Now()),
+			policies: []client.Object{
+				newPolicy("policy1", map[string]policiesv1.ComplianceState{"spoke1": policiesv1.Compliant}),
+				newPolicy("policy2", map[string]policiesv1.ComplianceState{
+					"spoke1": policiesv1.NonCompliant, "spoke2": policiesv1.NonCompliant}),
+			},
+			wantRegressed: map[string][]string{"spoke1": {"policy2"}},
+		},
+		{
+			name: "completed cluster pending or missing from the status",
+			cgu:  newCGU(3600, time.Now()),
+			policies: []client.Object{
+				newPolicy("policy1", map[string]policiesv1.ComplianceState{"spoke1": policiesv1.Pending}),
+				newPolicy("policy2", nil),
+			},
+			wantSoaking: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := getFakeClientFromObjects(tt.policies...)
+			r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: testscheme}
+
+			soakLeft, regressed, err := r.soakCompletedBatch(context.TODO(), tt.cgu)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSoaking, soakLeft > 0)
+			if tt.wantRegressed == nil {
+				assert.Empty(t, regressed)
+			} else {
+				assert.Equal(t, tt.wantRegressed, regressed)
+			}
+			assert.Equal(t, tt.cgu.Spec.RemediationStrategy.BatchSoakSeconds != 0, !tt.cgu.Status.Status.CurrentBatchCompletedAt.IsZero())
+		})
+	}
+}
+
+func TestClusterGroupUpgradeReconciler_hasFailedClusters(t *testing.T) {
+	policy := &policiesv1.Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "policy1", Namespace: "default"},
+		Status: policiesv1.PolicyStatus{Status: []*policiesv1.CompliancePerClusterStatus{
+			{ClusterName: "spoke1", ComplianceState: policiesv1.NonCompliant},
+			{ClusterName: "spoke2", ComplianceState: policiesv1.Compliant},
+		}},
+	}
+	c, _ := getFakeClientFromObjects(policy)
+	r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: testscheme}
+
+	// spoke1 failed in the first batch, the last batch completes on spoke2
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			RemediationStrategy: &ranv1alpha1.RemediationStrategySpec{MaxConcurrency: 1},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			ManagedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{{Name: "policy1", Namespace: "default"}},
+			RemediationPlan:           [][]string{{"spoke1"}, {"spoke2"}},
+			Clusters: []ranv1alpha1.ClusterState{
+				{Name: "spoke1", State: utils.ClusterRemediationFailed},
+			},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatch: 2,
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+					"spoke2": {State: ranv1alpha1.Completed},
+				},
+			},
+		},
+	}
+
+	var nextReconcile ctrl.Result
+	isUpgradeComplete, err := r.remediateLastBatch(context.TODO(), cgu, &nextReconcile)
+	assert.NoError(t, err)
+	assert.True(t, isUpgradeComplete)
+	assert.True(t, hasFailedClusters(cgu))
+	assert.False(t, hasPolicyTimedOutClusters(cgu))
+
+	cgu.Status.Clusters = []ranv1alpha1.ClusterState{{Name: "spoke1", State: utils.ClusterRemediationComplete}}
+	assert.False(t, hasFailedClusters(cgu))
+}
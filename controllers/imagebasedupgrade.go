@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+// validateImageBasedUpgrade checks that the ImageBasedUpgrade rollout is not combined with other rollouts and that
+// its stages can follow each other
+func (r *ClusterGroupUpgradeReconciler) validateImageBasedUpgrade(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) (bool, []string) {
+	var invalid []string
+	if len(clusterGroupUpgrade.Spec.ManagedPolicies) > 0 || len(clusterGroupUpgrade.Spec.ManagedPolicySets) > 0 {
+		invalid = append(invalid, "imageBasedUpgrade can't be combined with managedPolicies or managedPolicySets")
+	}
+	if len(clusterGroupUpgrade.Spec.ManifestWorkTemplates) > 0 {
+		invalid = append(invalid, "imageBasedUpgrade can't be combined with manifestWorkTemplates")
+	}
+	if err := utils.ValidateImageBasedUpgradeStages(clusterGroupUpgrade.GetImageBasedUpgradeStages()); err != nil {
+		invalid = append(invalid, err.Error())
+	}
+	return len(invalid) == 0, invalid
+}
+
+/*
+getNextImageBasedUpgradeStageForCluster checks if the cluster completed the ImageBasedUpgrade stage at startIndex.
+A cluster whose Upgrade stage failed is rolled back, and fails once the rollback completes.
+
+	returns: the index of the next stage of the cluster
+	         error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) getNextImageBasedUpgradeStageForCluster(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterName string, startIndex int) (int, bool, error) {
+	clusterProgress, ok := clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName]
+	if !ok || clusterProgress.ImageBasedUpgrade == nil {
+		// The stage is not applied yet
+		return startIndex, false, nil
+	}
+
+	mw, err := utils.GetImageBasedUpgradeManifestWorkForCluster(ctx, r.Client, clusterGroupUpgrade, clusterName)
+	if errors.IsNotFound(err) {
+		return startIndex, false, nil
+	}
+	if err != nil {
+		return startIndex, false, err
+	}
+
+	progress := clusterProgress.ImageBasedUpgrade
+	stageStatus := utils.GetImageBasedUpgradeStageStatus(mw, progress.Stage)
+	progress.Completed = stageStatus.Completed
+	progress.Message = stageStatus.Message
+
+	switch {
+	case progress.RollbackReason != "" && stageStatus.Completed:
+		err = r.handleFailedCluster(ctx, clusterGroupUpgrade, clusterName,
+			fmt.Sprintf("ImageBasedUpgrade Upgrade stage failed and the cluster was rolled back: %s", progress.RollbackReason))
+	case progress.RollbackReason != "" && stageStatus.Failed:
+		err = r.handleFailedCluster(ctx, clusterGroupUpgrade, clusterName,
+			fmt.Sprintf("ImageBasedUpgrade Upgrade stage failed: %s, and its rollback failed: %s", progress.RollbackReason, stageStatus.Message))
+	case stageStatus.Failed && progress.Stage == ranv1alpha1.ImageBasedUpgradeStages.Upgrade:
+		r.Log.Info("[getNextImageBasedUpgradeStageForCluster] Upgrade failed, rolling back", "cluster", clusterName, "message", stageStatus.Message)
+		clusterProgress.ImageBasedUpgrade = &ranv1alpha1.ImageBasedUpgradeProgress{
+			Stage:          ranv1alpha1.ImageBasedUpgradeStages.Rollback,
+			RollbackReason: stageStatus.Message,
+		}
+		err = utils.ApplyImageBasedUpgradeStageForCluster(ctx, r.Client, clusterGroupUpgrade, clusterName, ranv1alpha1.ImageBasedUpgradeStages.Rollback)
+	case stageStatus.Failed:
+		err = r.handleFailedCluster(ctx, clusterGroupUpgrade, clusterName,
+			fmt.Sprintf("ImageBasedUpgrade %s stage failed: %s", progress.Stage, stageStatus.Message))
+	case stageStatus.Completed && progress.Stage == clusterGroupUpgrade.GetImageBasedUpgradeStages()[startIndex]:
+		return startIndex + 1, false, nil
+	}
+	return startIndex, false, err
+}
+
+/*
+updateImageBasedUpgradeForCurrentBatch applies the current ImageBasedUpgrade stage to the clusters of the batch. The
+clusters move to the next stage only once all the clusters of the batch completed the current one.
+
+	returns: error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) updateImageBasedUpgradeForCurrentBatch(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+	stages := clusterGroupUpgrade.GetImageBasedUpgradeStages()
+
+	// The batch is at the earliest stage of its clusters
+	batchIndex := -1
+	for _, clusterProgress := range clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress {
+		if clusterProgress.State != ranv1alpha1.InProgress || clusterProgress.ImageBasedUpgradeIndex == nil {
+			continue
+		}
+		if batchIndex == -1 || *clusterProgress.ImageBasedUpgradeIndex < batchIndex {
+			batchIndex = *clusterProgress.ImageBasedUpgradeIndex
+		}
+	}
+	if batchIndex == -1 || batchIndex >= len(stages) {
+		return nil
+	}
+
+	for clusterName, clusterProgress := range clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress {
+		if clusterProgress.State != ranv1alpha1.InProgress || clusterProgress.ImageBasedUpgradeIndex == nil ||
+			*clusterProgress.ImageBasedUpgradeIndex != batchIndex {
+			continue
+		}
+		stage := stages[batchIndex]
+		switch {
+		case clusterProgress.ImageBasedUpgrade == nil || clusterProgress.ImageBasedUpgrade.Stage != stage && clusterProgress.ImageBasedUpgrade.RollbackReason == "":
+			clusterProgress.ImageBasedUpgrade = &ranv1alpha1.ImageBasedUpgradeProgress{Stage: stage}
+		case clusterProgress.ImageBasedUpgrade.RollbackReason != "":
+			// The cluster is rolling back its failed upgrade
+			stage = ranv1alpha1.ImageBasedUpgradeStages.Rollback
+		}
+		if err := utils.ApplyImageBasedUpgradeStageForCluster(ctx, r.Client, clusterGroupUpgrade, clusterName, stage); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *ClusterGroupUpgradeReconciler) handleImageBasedUpgradeTimeoutForCluster(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	clusterName string, clusterState *ranv1alpha1.ClusterState) {
+	clusterProgress := clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName]
+	if clusterProgress.ImageBasedUpgrade == nil {
+		r.Log.Info("[handleImageBasedUpgradeTimeoutForCluster] Missing stage for cluster", "clusterName", clusterName, "clusterProgress", clusterProgress)
+		return
+	}
+	if clusterState.Message == "" {
+		clusterState.Message = fmt.Sprintf("ImageBasedUpgrade %s stage did not complete", clusterProgress.ImageBasedUpgrade.Stage)
+	}
+}
@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-logr/logr"
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	mwv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestImageBasedUpgrade_validateImageBasedUpgrade(t *testing.T) {
+	testcases := []struct {
+		name            string
+		managedPolicies []string
+		templates       []string
+		stages          []ranv1alpha1.ImageBasedUpgradeStage
+		wantValid       bool
+		wantInvalid     []string
+	}{
+		{
+			name:      "default stages are valid",
+			wantValid: true,
+		},
+		{
+			name:      "prep is aborted",
+			stages:    []ranv1alpha1.ImageBasedUpgradeStage{"Prep", "Idle"},
+			wantValid: true,
+		},
+		{
+			name:            "combined with policies",
+			managedPolicies: []string{"policy1"},
+			wantInvalid:     []string{"imageBasedUpgrade can't be combined with managedPolicies or managedPolicySets"},
+		},
+		{
+			name:        "combined with manifestwork templates",
+			templates:   []string{"template1"},
+			wantInvalid: []string{"imageBasedUpgrade can't be combined with manifestWorkTemplates"},
+		},
+		{
+			name:        "stages are out of order",
+			stages:      []ranv1alpha1.ImageBasedUpgradeStage{"Upgrade", "Prep"},
+			wantInvalid: []string{"stage Prep can't follow stage Upgrade"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+					ManagedPolicies:       tc.managedPolicies,
+					ManifestWorkTemplates: tc.templates,
+					ImageBasedUpgrade:     &ranv1alpha1.ImageBasedUpgradeSpec{Stages: tc.stages},
+				},
+			}
+			// The ImageBasedUpgrade validation applies whatever else is set
+			assert.Equal(t, ranv1alpha1.RolloutTypes.ImageBasedUpgrade, cgu.RolloutType())
+			r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+			valid, invalid := r.validateImageBasedUpgrade(cgu)
+			assert.Equal(t, tc.wantValid, valid)
+			assert.Equal(t, tc.wantInvalid, invalid)
+		})
+	}
+}
+
+// setImageBasedUpgradeFeedback reports the stage and condition of the ImageBasedUpgrade through the status feedback
+func setImageBasedUpgradeFeedback(t *testing.T, r *ClusterGroupUpgradeReconciler,
+	cgu *ranv1alpha1.ClusterGroupUpgrade, clusterName, stage, condition, status, reason, message string) {
+	mw, err := utils.GetImageBasedUpgradeManifestWorkForCluster(context.TODO(), r.Client, cgu, clusterName)
+	assert.NoError(t, err)
+	stringValue := func(name, value string) mwv1.FeedbackValue {
+		return mwv1.FeedbackValue{Name: name, Value: mwv1.FieldValue{Type: mwv1.String, String: &value}}
+	}
+	mw.Status.ResourceStatus.Manifests = []mwv1.ManifestCondition{{
+		ResourceMeta: mwv1.ManifestResourceMeta{Ordinal: 0},
+		Conditions: []metav1.Condition{
+			{Type: mwv1.ManifestApplied, Status: metav1.ConditionTrue},
+			{Type: mwv1.ManifestAvailable, Status: metav1.ConditionTrue},
+			{Type: "StatusFeedbackSynced", Status: metav1.ConditionTrue},
+		},
+		StatusFeedbacks: mwv1.StatusFeedbackResult{Values: []mwv1.FeedbackValue{
+			stringValue("stage", stage),
+			stringValue(condition+".status", status),
+			stringValue(condition+".reason", reason),
+			stringValue(condition+".message", message),
+		}},
+	}}
+	assert.NoError(t, r.Client.Update(context.TODO(), mw))
+}
+
+func TestImageBasedUpgrade_stages(t *testing.T) {
+	ibuScheme := runtime.NewScheme()
+	assert.NoError(t, ranv1alpha1.AddToScheme(ibuScheme))
+	assert.NoError(t, mwv1.AddToScheme(ibuScheme))
+
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cgu", Namespace: "default",
+			Annotations: map[string]string{utils.NameSuffixAnnotation: "kuttl"},
+		},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			ImageBasedUpgrade: &ranv1alpha1.ImageBasedUpgradeSpec{
+				SeedImageRef: ranv1alpha1.SeedImageRef{Image: "quay.io/seed:4.15", Version: "4.15.0"},
+			},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			RemediationPlan: [][]string{{"spoke1", "spoke2"}},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+					"spoke1": {State: ranv1alpha1.InProgress, ImageBasedUpgradeIndex: new(int)},
+					"spoke2": {State: ranv1alpha1.InProgress, ImageBasedUpgradeIndex: new(int)},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(ibuScheme).Build()
+	r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: ibuScheme}
+	progress := cgu.Status.Status.CurrentBatchRemediationProgress
+	getStage := func(clusterName string) ranv1alpha1.ImageBasedUpgradeStage {
+		mw, err := utils.GetImageBasedUpgradeManifestWorkForCluster(context.TODO(), r.Client, cgu, clusterName)
+		assert.NoError(t, err)
+		assert.Equal(t, "default.cgu-ibu-kuttl", mw.Name)
+		assert.Equal(t, mwv1.DeletePropagationPolicyTypeOrphan, mw.Spec.DeleteOption.PropagationPolicy)
+		assert.Contains(t, string(mw.Spec.Workload.Manifests[0].Raw), `"seedImageRef":{"image":"quay.io/seed:4.15","version":"4.15.0"}`)
+		var ibu struct {
+			Spec struct {
+				Stage ranv1alpha1.ImageBasedUpgradeStage `json:"stage"`
+			} `json:"spec"`
+		}
+		assert.NoError(t, json.Unmarshal(mw.Spec.Workload.Manifests[0].Raw, &ibu))
+		return ibu.Spec.Stage
+	}
+	nextStage := func(clusterName string) int {
+		index, _, err := r.getNextImageBasedUpgradeStageForCluster(context.TODO(), cgu, clusterName, *progress[clusterName].ImageBasedUpgradeIndex)
+		assert.NoError(t, err)
+		*progress[clusterName].ImageBasedUpgradeIndex = index
+		return index
+	}
+
+	// The first stage is applied to all the clusters of the batch
+	assert.NoError(t, r.updateImageBasedUpgradeForCurrentBatch(context.TODO(), cgu))
+	assert.Equal(t, ranv1alpha1.ImageBasedUpgradeStages.Prep, getStage("spoke1"))
+	assert.Equal(t, ranv1alpha1.ImageBasedUpgradeStages.Prep, getStage("spoke2"))
+	assert.Equal(t, 0, nextStage("spoke1"))
+
+	// A cluster which completed the stage waits for the rest of the batch
+	setImageBasedUpgradeFeedback(t, r, cgu, "spoke1", "Prep", "PrepCompleted", "True", "Completed", "Prep completed")
+	assert.Equal(t, 1, nextStage("spoke1"))
+	assert.True(t, progress["spoke1"].ImageBasedUpgrade.Completed)
+	assert.NoError(t, r.updateImageBasedUpgradeForCurrentBatch(context.TODO(), cgu))
+	assert.Equal(t, ranv1alpha1.ImageBasedUpgradeStages.Prep, getStage("spoke1"))
+
+	// The batch moves to the next stage once all its clusters completed the current one
+	setImageBasedUpgradeFeedback(t, r, cgu, "spoke2", "Prep", "PrepCompleted", "True", "Completed", "Prep completed")
+	assert.Equal(t, 1, nextStage("spoke2"))
+	assert.NoError(t, r.updateImageBasedUpgradeForCurrentBatch(context.TODO(), cgu))
+	assert.Equal(t, ranv1alpha1.ImageBasedUpgradeStages.Upgrade, getStage("spoke1"))
+	assert.Equal(t, ranv1alpha1.ImageBasedUpgradeStages.Upgrade, getStage("spoke2"))
+	assert.False(t, progress["spoke1"].ImageBasedUpgrade.Completed)
+
+	// A failed upgrade is rolled back
+	setImageBasedUpgradeFeedback(t, r, cgu, "spoke2", "Upgrade", "UpgradeCompleted", "False", "Failed", "reboot failed")
+	assert.Equal(t, 1, nextStage("spoke2"))
+	assert.Equal(t, ranv1alpha1.ImageBasedUpgradeStages.Rollback, getStage("spoke2"))
+	assert.Equal(t, "reboot failed", progress["spoke2"].ImageBasedUpgrade.RollbackReason)
+	assert.NoError(t, r.updateImageBasedUpgradeForCurrentBatch(context.TODO(), cgu))
+	assert.Equal(t, ranv1alpha1.ImageBasedUpgradeStages.Rollback, getStage("spoke2"))
+
+	// The cluster fails once rolled back
+	setImageBasedUpgradeFeedback(t, r, cgu, "spoke2", "Rollback", "RollbackCompleted", "True", "Completed", "Rollback completed")
+	assert.Equal(t, 1, nextStage("spoke2"))
+	assert.Equal(t, ranv1alpha1.Failed, progress["spoke2"].State)
+	assert.Equal(t, "ImageBasedUpgrade Upgrade stage failed and the cluster was rolled back: reboot failed", cgu.Status.Clusters[0].Message)
+
+	// The failed cluster no longer holds the batch
+	setImageBasedUpgradeFeedback(t, r, cgu, "spoke1", "Upgrade", "UpgradeCompleted", "True", "Completed", "Upgrade completed")
+	assert.Equal(t, 2, nextStage("spoke1"))
+	assert.NoError(t, r.updateImageBasedUpgradeForCurrentBatch(context.TODO(), cgu))
+	assert.Equal(t, ranv1alpha1.ImageBasedUpgradeStages.Idle, getStage("spoke1"))
+	assert.Equal(t, ranv1alpha1.ImageBasedUpgradeStages.Rollback, getStage("spoke2"))
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -54,6 +55,8 @@ type templateData struct {
 	ViewUpdateIntervalSec   int
 	ExcludePrecachePatterns []string
 	AdditionalImages        []string
+	// RegistryMirrors lists each source repository followed by its mirrors
+	RegistryMirrors []string
 }
 
 // operatorsData provides operators data for template rendering
@@ -86,6 +89,7 @@ var precacheDependenciesViewTemplates = []resourceTemplate{
 var precacheCreateTemplates = []resourceTemplate{
 	{"precache-job-create", templates.MngClusterActCreateJob},
 	{"view-precache-job", templates.MngClusterViewJob},
+	{precacheProgressView, templates.MngClusterViewProgressConfigMap},
 }
 var precacheJobView = []resourceTemplate{
 	{"view-precache-job", templates.MngClusterViewJob},
@@ -95,6 +99,9 @@ var precacheDeleteTemplates = []resourceTemplate{
 	{"precache-crb-delete", templates.MngClusterActDeletePrecachingCRB},
 }
 
+// precacheProgressView is the view of the progress configmap written by the precaching job
+const precacheProgressView = "view-precache-progress"
+
 var precacheNSViewTemplates = []resourceTemplate{
 	{"view-precache-namespace", templates.MngClusterViewNamespace},
 }
@@ -102,6 +109,7 @@ var precacheNSViewTemplates = []resourceTemplate{
 var precacheAllViews = []resourceTemplate{
 	{"view-precache-namespace", utils.ManagedClusterViewPrefix},
 	{"view-precache-job", utils.ManagedClusterViewPrefix},
+	{precacheProgressView, utils.ManagedClusterViewPrefix},
 	{"view-precache-spec-configmap", utils.ManagedClusterViewPrefix},
 	{"view-precache-service-acct", utils.ManagedClusterViewPrefix},
 	{"view-precache-cluster-role-binding", utils.ManagedClusterViewPrefix},
@@ -398,6 +406,42 @@ func (r *ClusterGroupUpgradeReconciler) getPreparingConditions(
 	return NoNsFoundOnSpoke, nil
 }
 
+// getPrecachingProgress gets the progress reported by the precaching job from its view
+// returns: *ranv1alpha1.PrecachingProgress (nil if not reported yet)
+//
+//	error
+func (r *ClusterGroupUpgradeReconciler) getPrecachingProgress(
+	ctx context.Context, cluster, resourceName string) (*ranv1alpha1.PrecachingProgress, error) {
+
+	progressView, present, err := r.getView(ctx, resourceName, cluster)
+	if err != nil || !present {
+		return nil, err
+	}
+	data, exists, err := unstructured.NestedStringMap(progressView.Object, "status", "result", "data")
+	if err != nil || !exists {
+		return nil, err
+	}
+	count := func(key string) int {
+		value, _ := strconv.Atoi(data[key])
+		return value
+	}
+	bytesPulled, _ := strconv.ParseInt(data["bytesPulled"], 10, 64)
+	progress := &ranv1alpha1.PrecachingProgress{
+		Phase:        data["phase"],
+		ImagesTotal:  count("imagesTotal"),
+		ImagesPulled: count("imagesPulled"),
+		ImagesFailed: count("imagesFailed"),
+		BytesPulled:  bytesPulled,
+		Message:      data["message"],
+	}
+	for _, image := range strings.Split(data["failedImages"], "\n") {
+		if image != "" {
+			progress.FailedImages = append(progress.FailedImages, image)
+		}
+	}
+	return progress, nil
+}
+
 // getJobStatus gets job status from its view
 // returns: condition (string)
 //
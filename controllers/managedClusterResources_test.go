@@ -11,6 +11,7 @@ import (
 
 	"github.com/go-logr/logr"
 	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/templates"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
 	viewv1beta1 "github.com/stolostron/cluster-lifecycle-api/view/v1beta1"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,6 +39,7 @@ func TestMCR_renderYamlTemplates(t *testing.T) {
 				ResourceName:            "precache-spec",
 				ExcludePrecachePatterns: []string{"aws", "thanos"},
 				AdditionalImages:        []string{"image1:tag", "image2:tag"},
+				RegistryMirrors:         []string{"quay.io/openshift-release-dev mirror.example.com/ocp"},
 				SpaceRequired:           "45",
 			},
 			template: templates.MngClusterActCreatePrecachingSpecCM,
@@ -60,6 +62,8 @@ spec:
         additionalImages: |
           image1:tag 
           image2:tag 
+        registryMirrors: |
+          quay.io/openshift-release-dev mirror.example.com/ocp 
         operators.indexes: ""
         operators.packagesAndChannels: ""
         platform.image:
@@ -611,6 +615,48 @@ func TestMCR_getView(t *testing.T) {
 	}
 }
 
+func TestMCR_getPrecachingProgress(t *testing.T) {
+	r := &ClusterGroupUpgradeReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects().Build(),
+		Log:    logr.Discard(),
+		Scheme: scheme.Scheme,
+	}
+
+	// Nothing is reported until the view exists
+	progress, err := r.getPrecachingProgress(context.TODO(), "test", precacheProgressView)
+	assert.NoError(t, err)
+	assert.Nil(t, progress)
+
+	obj := &unstructured.Unstructured{}
+	w, err := r.renderYamlTemplate(precacheProgressView, templates.MngClusterViewProgressConfigMap, templateData{Cluster: "test"})
+	assert.NoError(t, err)
+	dec := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+	_, _, err = dec.Decode(w.Bytes(), nil, obj)
+	assert.NoError(t, err)
+	assert.NoError(t, unstructured.SetNestedStringMap(obj.Object, map[string]string{
+		"phase":        "platform",
+		"imagesTotal":  "10",
+		"imagesPulled": "8",
+		"imagesFailed": "2",
+		"bytesPulled":  "12884901888",
+		"failedImages": "quay.io/1\nquay.io/2\n",
+		"message":      "2 images could not be pulled for platform-images",
+	}, "status", "result", "data"))
+	assert.NoError(t, r.Create(context.TODO(), obj))
+
+	progress, err = r.getPrecachingProgress(context.TODO(), "test", precacheProgressView)
+	assert.NoError(t, err)
+	assert.Equal(t, &ranv1alpha1.PrecachingProgress{
+		Phase:        "platform",
+		ImagesTotal:  10,
+		ImagesPulled: 8,
+		ImagesFailed: 2,
+		BytesPulled:  12884901888,
+		FailedImages: []string{"quay.io/1", "quay.io/2"},
+		Message:      "2 images could not be pulled for platform-images",
+	}, progress)
+}
+
 func TestMCR_createResourcesFromTemplates(t *testing.T) {
 	testcases := []struct {
 		name         string
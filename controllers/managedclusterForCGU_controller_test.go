@@ -47,6 +47,7 @@ func init() {
 	testscheme.AddKnownTypes(clusterv1.GroupVersion, &clusterv1.ManagedCluster{})
 	testscheme.AddKnownTypes(ranv1alpha1.SchemeGroupVersion, &ranv1alpha1.ClusterGroupUpgrade{})
 	testscheme.AddKnownTypes(ranv1alpha1.SchemeGroupVersion, &ranv1alpha1.ClusterGroupUpgradeList{})
+	testscheme.AddKnownTypes(ranv1alpha1.SchemeGroupVersion, &ranv1alpha1.UpgradeReport{})
 	testscheme.AddKnownTypes(policiesv1.GroupVersion, &policiesv1.Policy{})
 	testscheme.AddKnownTypes(policiesv1.GroupVersion, &policiesv1.PolicyList{})
 }
@@ -2,64 +2,212 @@ package controllers
 
 import (
 	"context"
+	goerrors "errors"
 	"fmt"
 
 	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/strings/slices"
 	mwv1 "open-cluster-management.io/api/work/v1"
+	mwv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// manifestWorkTemplatesInfo holds the result of the validation of the manifestwork templates
+type manifestWorkTemplatesInfo struct {
+	missingTemplates []string
+	// invalidTemplates holds the validation error of each invalid template
+	invalidTemplates []string
+	manifests        []mwv1.Manifest
+}
+
+/*
+validateManifestWorkTemplates checks that all the manifestwork templates exist as ManifestWorkReplicaSets in the
+CGU namespace and are valid.
+
+	returns: true/false if all the templates exist and are valid
+	         the missing and invalid templates, and the manifests of all the templates
+	         error/nil
+*/
 func (r *ClusterGroupUpgradeReconciler) validateManifestWorkTemplates(
-	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) (bool, []mwv1.Manifest, error) {
-	return true, nil, nil
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) (bool, manifestWorkTemplatesInfo, error) {
+	var templatesInfo manifestWorkTemplatesInfo
+
+	for _, templateName := range clusterGroupUpgrade.Spec.ManifestWorkTemplates {
+		mwrs := &mwv1alpha1.ManifestWorkReplicaSet{}
+		err := r.Client.Get(ctx, types.NamespacedName{Name: templateName, Namespace: clusterGroupUpgrade.Namespace}, mwrs)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				r.Log.Info("[validateManifestWorkTemplates] Manifestwork template is missing", "name", templateName)
+				templatesInfo.missingTemplates = append(templatesInfo.missingTemplates, templateName)
+				continue
+			}
+			return false, templatesInfo, err
+		}
+
+		if err := utils.ValidateManifestWorkReplicaSet(mwrs); err != nil {
+			r.Log.Info("[validateManifestWorkTemplates] Manifestwork template is invalid", "name", templateName, "error", err.Error())
+			templatesInfo.invalidTemplates = append(templatesInfo.invalidTemplates, fmt.Sprintf("%s: %s", templateName, err))
+			continue
+		}
+		if clusterGroupUpgrade.Spec.PreCaching {
+			if err := validateManifestsForPrecaching(mwrs.Spec.ManifestWorkTemplate.Workload.Manifests); err != nil {
+				r.Log.Info("[validateManifestWorkTemplates] Manifestwork template can't be pre-cached", "name", templateName, "error", err.Error())
+				templatesInfo.invalidTemplates = append(templatesInfo.invalidTemplates, fmt.Sprintf("%s: %s", templateName, err))
+				continue
+			}
+		}
+		templatesInfo.manifests = append(templatesInfo.manifests, mwrs.Spec.ManifestWorkTemplate.Workload.Manifests...)
+	}
+
+	templatesInfo.invalidTemplates = append(templatesInfo.invalidTemplates, getInvalidManifestWorkStageTemplates(clusterGroupUpgrade)...)
+
+	allTemplatesValid := len(templatesInfo.missingTemplates) == 0 && len(templatesInfo.invalidTemplates) == 0
+	return allTemplatesValid, templatesInfo, nil
+}
+
+// getInvalidManifestWorkStageTemplates checks that each manifestwork template belongs to exactly one stage and
+// that the stages only refer to manifestwork templates
+func getInvalidManifestWorkStageTemplates(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) []string {
+	if len(clusterGroupUpgrade.Spec.ManifestWorkStages) == 0 {
+		return nil
+	}
+
+	var invalidTemplates []string
+	stagesPerTemplate := make(map[string]int)
+	for _, stage := range clusterGroupUpgrade.Spec.ManifestWorkStages {
+		for _, templateName := range stage.Templates {
+			if !slices.Contains(clusterGroupUpgrade.Spec.ManifestWorkTemplates, templateName) {
+				invalidTemplates = append(invalidTemplates, fmt.Sprintf("%s: stage refers to a template which is not in manifestWorkTemplates", templateName))
+				continue
+			}
+			stagesPerTemplate[templateName]++
+		}
+	}
+	for _, templateName := range clusterGroupUpgrade.Spec.ManifestWorkTemplates {
+		switch {
+		case stagesPerTemplate[templateName] == 0:
+			invalidTemplates = append(invalidTemplates, fmt.Sprintf("%s: template is not part of any stage", templateName))
+		case stagesPerTemplate[templateName] > 1:
+			invalidTemplates = append(invalidTemplates, fmt.Sprintf("%s: template is part of several stages", templateName))
+		}
+	}
+	return invalidTemplates
+}
+
+/*
+getManifestWorksForStage gets the manifestworks of the templates of the given stage for the cluster.
+
+	returns: the manifestworks of the stage, nil for the ones not created yet or not taken over by the
+	         ClusterGroupUpgrade, such as the ones kept in place by a previous ClusterGroupUpgrade
+	         error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) getManifestWorksForStage(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	stage ranv1alpha1.ManifestWorkStage, clusterName string) ([]*mwv1.ManifestWork, error) {
+	manifestWorks := make([]*mwv1.ManifestWork, len(stage.Templates))
+	for i, templateName := range stage.Templates {
+		templateIndex := slices.Index(clusterGroupUpgrade.Spec.ManifestWorkTemplates, templateName)
+		manifestWork, err := utils.GetManifestWorkForCluster(ctx, r.Client, clusterGroupUpgrade, templateIndex, clusterName)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !utils.IsManifestWorkOwnedBy(manifestWork, clusterGroupUpgrade) {
+			continue
+		}
+		manifestWorks[i] = manifestWork
+	}
+	return manifestWorks, nil
 }
 
 func (r *ClusterGroupUpgradeReconciler) getNextManifestWorkForCluster(
 	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterName string, startIndex int) (int, bool, error) {
-	currentManifestWork, err := utils.GetManifestWorkForCluster(ctx, r.Client, clusterGroupUpgrade, startIndex, clusterName)
-	if err == nil {
-		completed, err := utils.IsManifestWorkCompleted(currentManifestWork)
-		if completed {
-			return startIndex + 1, false, nil
-		}
+	stages := clusterGroupUpgrade.GetManifestWorkStages()
+	currentManifestWorks, err := r.getManifestWorksForStage(ctx, clusterGroupUpgrade, stages[startIndex], clusterName)
+	if err != nil {
 		return startIndex, false, err
-	} else if errors.IsNotFound(err) {
-		// current mw is not created yet
-		if startIndex > 0 {
-			// clean up previous mw if exists
-			previousManifestWork, err := utils.GetManifestWorkForCluster(ctx, r.Client, clusterGroupUpgrade, startIndex-1, clusterName)
-			if err == nil {
-				// Need to cleanup previous mw for this cluster
-				err = r.Client.Delete(ctx, previousManifestWork)
-				if client.IgnoreNotFound(err) != nil {
-					return startIndex, false, err
-				}
+	}
+	if clusterProgress, ok := clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName]; ok {
+		clusterProgress.ManifestWorks = nil
+		for i, currentManifestWork := range currentManifestWorks {
+			clusterProgress.ManifestWorks = append(clusterProgress.ManifestWorks,
+				utils.GetManifestWorkProgress(stages[startIndex].Templates[i], currentManifestWork))
+		}
+	}
+
+	if !containsMissingManifestWork(currentManifestWorks) {
+		for _, currentManifestWork := range currentManifestWorks {
+			completed, err := utils.IsManifestWorkCompleted(currentManifestWork)
+			if !completed {
+				return startIndex, false, err
 			}
-			return startIndex, false, client.IgnoreNotFound(err)
 		}
+		return startIndex + 1, false, nil
 	}
-	return startIndex, false, client.IgnoreNotFound(err)
+
+	// current stage is not fully created yet, clean up the previous stage unless it is kept in place
+	if startIndex > 0 && !stages[startIndex-1].KeepInPlace {
+		previousManifestWorks, err := r.getManifestWorksForStage(ctx, clusterGroupUpgrade, stages[startIndex-1], clusterName)
+		if err != nil {
+			return startIndex, false, err
+		}
+		for _, previousManifestWork := range previousManifestWorks {
+			if previousManifestWork == nil {
+				continue
+			}
+			// Need to cleanup previous mw for this cluster
+			err = r.Client.Delete(ctx, previousManifestWork)
+			if client.IgnoreNotFound(err) != nil {
+				return startIndex, false, err
+			}
+		}
+	}
+	return startIndex, false, nil
 }
 
 func (r *ClusterGroupUpgradeReconciler) updateManifestWorkForCurrentBatch(
 	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+	stages := clusterGroupUpgrade.GetManifestWorkStages()
 	for clusterName, clusterProgress := range clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress {
+		if clusterProgress.State != ranv1alpha1.InProgress || clusterProgress.ManifestWorkIndex == nil ||
+			*clusterProgress.ManifestWorkIndex >= len(stages) {
+			continue
+		}
 		currentIndex := *clusterProgress.ManifestWorkIndex
-		if currentIndex > 0 {
-			_, err := utils.GetManifestWorkForCluster(ctx, r.Client, clusterGroupUpgrade, currentIndex-1, clusterName)
-			if err == nil {
+		if currentIndex > 0 && !stages[currentIndex-1].KeepInPlace {
+			previousManifestWorks, err := r.getManifestWorksForStage(ctx, clusterGroupUpgrade, stages[currentIndex-1], clusterName)
+			if err != nil {
+				return err
+			}
+			if !isEveryManifestWorkMissing(previousManifestWorks) {
 				// Previous mw still there, can't create the new one yet
 				continue
-			} else if client.IgnoreNotFound(err) != nil {
-				return err
 			}
 		}
 
-		_, err := utils.GetManifestWorkForCluster(ctx, r.Client, clusterGroupUpgrade, currentIndex, clusterName)
-		if errors.IsNotFound(err) {
-			err = utils.CreateManifestWorkForCluster(ctx, r.Client, clusterGroupUpgrade, currentIndex, clusterName)
+		for _, templateName := range stages[currentIndex].Templates {
+			templateIndex := slices.Index(clusterGroupUpgrade.Spec.ManifestWorkTemplates, templateName)
+			manifestWork, err := utils.GetManifestWorkForCluster(ctx, r.Client, clusterGroupUpgrade, templateIndex, clusterName)
+			if errors.IsNotFound(err) {
+				err = utils.CreateManifestWorkForCluster(ctx, r.Client, clusterGroupUpgrade, templateIndex, clusterName)
+			} else if err == nil {
+				// Update the manifestwork if it doesn't match its template anymore or was kept in place by another
+				// ClusterGroupUpgrade
+				err = utils.UpdateManifestWorkForCluster(ctx, r.Client, clusterGroupUpgrade, templateIndex, manifestWork)
+			}
+			var templateErr *utils.ManifestWorkTemplateError
+			if goerrors.As(err, &templateErr) {
+				// The cluster is missing values for the placeholders of the template, it can't be remediated
+				r.Log.Info("[updateManifestWorkForCurrentBatch] Failed to create manifestwork", "cluster", clusterName, "error", err.Error())
+				if err := r.handleFailedCluster(ctx, clusterGroupUpgrade, clusterName, err.Error()); err != nil {
+					return err
+				}
+				break
+			}
 			if err != nil {
 				return err
 			}
@@ -77,18 +225,26 @@ func (r *ClusterGroupUpgradeReconciler) handleManifestWorkTimeoutForCluster(ctx
 	}
 
 	index := *clusterProgress.ManifestWorkIndex
+	stages := clusterGroupUpgrade.GetManifestWorkStages()
 	// Avoid panics because of index out of bound in edge cases
-	if index < len(clusterGroupUpgrade.Spec.ManifestWorkTemplates) {
-		clusterState.CurrentManifestWork = &ranv1alpha1.ManifestWorkStatus{Name: clusterGroupUpgrade.Spec.ManifestWorkTemplates[index]}
-		currentManifestWork, err := utils.GetManifestWorkForCluster(ctx, r.Client, clusterGroupUpgrade, index, clusterName)
-		if errors.IsNotFound(err) {
-			r.Log.Error(err, "[handleManifestWorkTimeoutForCluster] Missing manifestwork", "cluster", clusterName)
+	if index >= len(stages) {
+		return nil
+	}
+	currentManifestWorks, err := r.getManifestWorksForStage(ctx, clusterGroupUpgrade, stages[index], clusterName)
+	if err != nil {
+		return err
+	}
+	// Report the first manifestwork of the stage which is not completed
+	for i, currentManifestWork := range currentManifestWorks {
+		clusterState.CurrentManifestWork = &ranv1alpha1.ManifestWorkStatus{Name: stages[index].Templates[i]}
+		if currentManifestWork == nil {
+			r.Log.Info("[handleManifestWorkTimeoutForCluster] Missing manifestwork", "cluster", clusterName, "template", stages[index].Templates[i])
 			return nil
 		}
-		if err != nil {
-			return err
-		}
 		clusterState.CurrentManifestWork.ManifestStatus = currentManifestWork.Status.ResourceStatus
+		if completed, _ := utils.IsManifestWorkCompleted(currentManifestWork); !completed {
+			return nil
+		}
 	}
 	return nil
 }
@@ -128,3 +284,23 @@ func (r *ClusterGroupUpgradeReconciler) finalCleanupManifestWork(ctx context.Con
 	}
 	return nil
 }
+
+// containsMissingManifestWork checks if any of the manifestworks of a stage is not created yet
+func containsMissingManifestWork(manifestWorks []*mwv1.ManifestWork) bool {
+	for _, manifestWork := range manifestWorks {
+		if manifestWork == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isEveryManifestWorkMissing checks if none of the manifestworks of a stage exist
+func isEveryManifestWorkMissing(manifestWorks []*mwv1.ManifestWork) bool {
+	for _, manifestWork := range manifestWorks {
+		if manifestWork != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,373 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	mwv1 "open-cluster-management.io/api/work/v1"
+	mwv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestManifestWork_validateManifestWorkTemplates(t *testing.T) {
+	mwScheme := runtime.NewScheme()
+	assert.NoError(t, ranv1alpha1.AddToScheme(mwScheme))
+	assert.NoError(t, mwv1alpha1.AddToScheme(mwScheme))
+
+	manifest := mwv1.Manifest{RawExtension: runtime.RawExtension{
+		Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm", "namespace": "default"}}`)}}
+	validTemplate := &mwv1alpha1.ManifestWorkReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "valid", Namespace: "default"},
+		Spec: mwv1alpha1.ManifestWorkReplicaSetSpec{
+			ManifestWorkTemplate: mwv1.ManifestWorkSpec{Workload: mwv1.ManifestsTemplate{Manifests: []mwv1.Manifest{manifest}}},
+		},
+	}
+	emptyTemplate := &mwv1alpha1.ManifestWorkReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: "default"},
+	}
+	templatizedTemplate := &mwv1alpha1.ManifestWorkReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "templatized", Namespace: "default"},
+		Spec: mwv1alpha1.ManifestWorkReplicaSetSpec{
+			ManifestWorkTemplate: mwv1.ManifestWorkSpec{Workload: mwv1.ManifestsTemplate{Manifests: []mwv1.Manifest{{RawExtension: runtime.RawExtension{
+				Raw: []byte(`{"apiVersion": "operators.coreos.com/v1alpha1", "kind": "Subscription", "metadata": {"name": "ptp", "namespace": "openshift-ptp"},
+					"spec": {"name": "ptp-operator", "channel": "{{cgu .ClusterLabels.channel cgu}}"}}`)}}}}},
+		},
+	}
+	otherNamespaceTemplate := validTemplate.DeepCopy()
+	otherNamespaceTemplate.Name = "other"
+	otherNamespaceTemplate.Namespace = "other"
+
+	testcases := []struct {
+		name             string
+		templates        []string
+		stages           []ranv1alpha1.ManifestWorkStage
+		preCaching       bool
+		wantValid        bool
+		wantMissing      []string
+		wantInvalid      []string
+		wantManifestsLen int
+	}{
+		{
+			name:             "all templates are valid",
+			templates:        []string{"valid"},
+			wantValid:        true,
+			wantManifestsLen: 1,
+		},
+		{
+			name:             "template is missing",
+			templates:        []string{"valid", "typo", "other"},
+			wantMissing:      []string{"typo", "other"},
+			wantManifestsLen: 1,
+		},
+		{
+			name:             "template is invalid",
+			templates:        []string{"valid", "empty"},
+			wantInvalid:      []string{"empty: template has no manifests"},
+			wantManifestsLen: 1,
+		},
+		{
+			name:             "templatized template without precaching",
+			templates:        []string{"valid", "templatized"},
+			wantValid:        true,
+			wantManifestsLen: 2,
+		},
+		{
+			name:             "templatized template with precaching",
+			templates:        []string{"valid", "templatized"},
+			preCaching:       true,
+			wantInvalid:      []string{"templatized: templatized Subscription fields not supported with precaching"},
+			wantManifestsLen: 1,
+		},
+		{
+			name:             "all templates are in a stage",
+			templates:        []string{"valid"},
+			stages:           []ranv1alpha1.ManifestWorkStage{{Templates: []string{"valid"}}},
+			wantValid:        true,
+			wantManifestsLen: 1,
+		},
+		{
+			name:      "stages are invalid",
+			templates: []string{"valid", "empty"},
+			stages: []ranv1alpha1.ManifestWorkStage{
+				{Templates: []string{"valid", "typo"}},
+				{Templates: []string{"valid"}},
+			},
+			wantInvalid: []string{
+				"empty: template has no manifests",
+				"typo: stage refers to a template which is not in manifestWorkTemplates",
+				"valid: template is part of several stages",
+				"empty: template is not part of any stage",
+			},
+			wantManifestsLen: 1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+				Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+					ManifestWorkTemplates: tc.templates, ManifestWorkStages: tc.stages, PreCaching: tc.preCaching},
+			}
+			c := fake.NewClientBuilder().WithScheme(mwScheme).
+				WithObjects([]client.Object{validTemplate, emptyTemplate, templatizedTemplate, otherNamespaceTemplate}...).Build()
+			r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: mwScheme}
+
+			valid, templatesInfo, err := r.validateManifestWorkTemplates(context.TODO(), cgu)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantValid, valid)
+			assert.Equal(t, tc.wantMissing, templatesInfo.missingTemplates)
+			assert.Equal(t, tc.wantInvalid, templatesInfo.invalidTemplates)
+			assert.Len(t, templatesInfo.manifests, tc.wantManifestsLen)
+		})
+	}
+}
+
+func TestManifestWork_stages(t *testing.T) {
+	mwScheme := runtime.NewScheme()
+	assert.NoError(t, ranv1alpha1.AddToScheme(mwScheme))
+	assert.NoError(t, mwv1alpha1.AddToScheme(mwScheme))
+	assert.NoError(t, mwv1.AddToScheme(mwScheme))
+
+	var objects []client.Object
+	for _, name := range []string{"addon1", "addon2", "config"} {
+		objects = append(objects, &mwv1alpha1.ManifestWorkReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: mwv1alpha1.ManifestWorkReplicaSetSpec{
+				ManifestWorkTemplate: mwv1.ManifestWorkSpec{Workload: mwv1.ManifestsTemplate{Manifests: []mwv1.Manifest{{
+					RawExtension: runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "` + name + `", "namespace": "default"}}`)},
+				}}}},
+			},
+		})
+	}
+
+	testcases := []struct {
+		name        string
+		keepInPlace bool
+	}{
+		{
+			name: "stage is deleted before the next one starts",
+		},
+		{
+			name:        "stage is kept in place",
+			keepInPlace: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cgu", Namespace: "default",
+					Annotations: map[string]string{utils.NameSuffixAnnotation: "kuttl"},
+				},
+				Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+					ManifestWorkTemplates: []string{"addon1", "addon2", "config"},
+					ManifestWorkStages: []ranv1alpha1.ManifestWorkStage{
+						{Templates: []string{"addon1", "addon2"}, KeepInPlace: tc.keepInPlace},
+						{Templates: []string{"config"}},
+					},
+				},
+				Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+					RemediationPlan: [][]string{{"spoke1"}},
+					Status: ranv1alpha1.UpgradeStatus{
+						CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+							"spoke1": {State: ranv1alpha1.InProgress, ManifestWorkIndex: new(int)},
+						},
+					},
+				},
+			}
+			c := fake.NewClientBuilder().WithScheme(mwScheme).WithObjects(objects...).Build()
+			r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: mwScheme}
+			listManifestWorks := func() []string {
+				mwList := &mwv1.ManifestWorkList{}
+				assert.NoError(t, c.List(context.TODO(), mwList, client.InNamespace("spoke1")))
+				var names []string
+				for _, mw := range mwList.Items {
+					names = append(names, mw.Name)
+				}
+				return names
+			}
+
+			// The templates of the first stage are applied together
+			assert.NoError(t, r.updateManifestWorkForCurrentBatch(context.TODO(), cgu))
+			assert.ElementsMatch(t, []string{"default.addon1-kuttl", "default.addon2-kuttl"}, listManifestWorks())
+			index, _, err := r.getNextManifestWorkForCluster(context.TODO(), cgu, "spoke1", 0)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, index)
+			assert.Equal(t, []ranv1alpha1.ManifestWorkProgress{{Name: "addon1"}, {Name: "addon2"}},
+				cgu.Status.Status.CurrentBatchRemediationProgress["spoke1"].ManifestWorks)
+
+			// The stage completes once all its manifestworks are completed
+			for _, name := range []string{"default.addon1-kuttl", "default.addon2-kuttl"} {
+				mw := &mwv1.ManifestWork{}
+				assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "spoke1"}, mw))
+				mw.Status.Conditions = []metav1.Condition{
+					{Type: mwv1.ManifestApplied, Status: metav1.ConditionTrue},
+					{Type: mwv1.ManifestAvailable, Status: metav1.ConditionTrue},
+				}
+				assert.NoError(t, c.Update(context.TODO(), mw))
+			}
+			index, _, err = r.getNextManifestWorkForCluster(context.TODO(), cgu, "spoke1", 0)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, index)
+			for _, progress := range cgu.Status.Status.CurrentBatchRemediationProgress["spoke1"].ManifestWorks {
+				assert.Equal(t, metav1.ConditionTrue, progress.Applied)
+				assert.True(t, progress.Completed)
+			}
+			*cgu.Status.Status.CurrentBatchRemediationProgress["spoke1"].ManifestWorkIndex = index
+
+			// The next stage starts once the previous one is deleted, unless it is kept in place
+			index, _, err = r.getNextManifestWorkForCluster(context.TODO(), cgu, "spoke1", 1)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, index)
+			assert.NoError(t, r.updateManifestWorkForCurrentBatch(context.TODO(), cgu))
+			if tc.keepInPlace {
+				assert.ElementsMatch(t, []string{"default.addon1-kuttl", "default.addon2-kuttl", "default.config-kuttl"}, listManifestWorks())
+			} else {
+				assert.ElementsMatch(t, []string{"default.config-kuttl"}, listManifestWorks())
+			}
+
+			// The manifestworks kept in place are not cleaned up with the batch
+			assert.NoError(t, utils.CleanupManifestWorkForBatch(context.TODO(), c, cgu, 0))
+			if tc.keepInPlace {
+				assert.ElementsMatch(t, []string{"default.addon1-kuttl", "default.addon2-kuttl"}, listManifestWorks())
+			} else {
+				assert.Empty(t, listManifestWorks())
+			}
+
+			// They are deleted with the ClusterGroupUpgrade
+			assert.NoError(t, utils.DeleteKeptInPlaceManifestWorks(context.TODO(), c, cgu))
+			assert.Empty(t, listManifestWorks())
+		})
+	}
+}
+
+func TestManifestWork_keptInPlaceByPreviousUpgrade(t *testing.T) {
+	mwScheme := runtime.NewScheme()
+	assert.NoError(t, ranv1alpha1.AddToScheme(mwScheme))
+	assert.NoError(t, mwv1alpha1.AddToScheme(mwScheme))
+	assert.NoError(t, mwv1.AddToScheme(mwScheme))
+
+	newManifests := func(data string) []mwv1.Manifest {
+		return []mwv1.Manifest{{RawExtension: runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"v1","data":{"version":"` + data + `"},"kind":"ConfigMap","metadata":{"name":"addon","namespace":"default"}}`)}}}
+	}
+	mwrs := &mwv1alpha1.ManifestWorkReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "addon", Namespace: "default"},
+		Spec: mwv1alpha1.ManifestWorkReplicaSetSpec{
+			ManifestWorkTemplate: mwv1.ManifestWorkSpec{Workload: mwv1.ManifestsTemplate{Manifests: newManifests("2")}},
+		},
+	}
+	// The completed manifestwork of the previous version of the template, kept in place by another upgrade
+	previous := &mwv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default.addon-kuttl", Namespace: "spoke1", Generation: 1,
+			Labels: map[string]string{
+				"openshift-cluster-group-upgrades/clusterGroupUpgrade":          "previous-cgu",
+				"openshift-cluster-group-upgrades/clusterGroupUpgradeNamespace": "default",
+				"openshift-cluster-group-upgrades/keepInPlace":                  "true",
+			},
+		},
+		Spec: mwv1.ManifestWorkSpec{Workload: mwv1.ManifestsTemplate{Manifests: newManifests("1")}},
+		Status: mwv1.ManifestWorkStatus{Conditions: []metav1.Condition{
+			{Type: mwv1.ManifestApplied, Status: metav1.ConditionTrue, ObservedGeneration: 1},
+			{Type: mwv1.ManifestAvailable, Status: metav1.ConditionTrue, ObservedGeneration: 1},
+		}},
+	}
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cgu", Namespace: "default",
+			Annotations: map[string]string{utils.NameSuffixAnnotation: "kuttl"},
+		},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			ManifestWorkTemplates: []string{"addon"},
+			ManifestWorkStages:    []ranv1alpha1.ManifestWorkStage{{Templates: []string{"addon"}, KeepInPlace: true}},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			RemediationPlan: [][]string{{"spoke1"}},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+					"spoke1": {State: ranv1alpha1.InProgress, ManifestWorkIndex: new(int)},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(mwScheme).WithObjects(mwrs, previous).Build()
+	r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: mwScheme}
+
+	// The manifestwork of the other upgrade doesn't complete the stage
+	index, _, err := r.getNextManifestWorkForCluster(context.TODO(), cgu, "spoke1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, index)
+
+	// It is taken over and updated to the current version of the template
+	assert.NoError(t, r.updateManifestWorkForCurrentBatch(context.TODO(), cgu))
+	mw := &mwv1.ManifestWork{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "default.addon-kuttl", Namespace: "spoke1"}, mw))
+	assert.Equal(t, mwrs.Spec.ManifestWorkTemplate.Workload.Manifests, mw.Spec.Workload.Manifests)
+	assert.True(t, utils.IsManifestWorkOwnedBy(mw, cgu))
+
+	// The conditions of the previous version don't complete the stage either
+	mw.Generation = 2
+	assert.NoError(t, c.Update(context.TODO(), mw))
+	index, _, err = r.getNextManifestWorkForCluster(context.TODO(), cgu, "spoke1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, index)
+}
+
+func TestManifestWork_failedCluster(t *testing.T) {
+	mwScheme := runtime.NewScheme()
+	assert.NoError(t, ranv1alpha1.AddToScheme(mwScheme))
+	assert.NoError(t, clusterv1.AddToScheme(mwScheme))
+	assert.NoError(t, mwv1alpha1.AddToScheme(mwScheme))
+	assert.NoError(t, mwv1.AddToScheme(mwScheme))
+
+	// spoke1 has no ManagedCluster to fill the placeholders of the template
+	mwrs := &mwv1alpha1.ManifestWorkReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "default"},
+		Spec: mwv1alpha1.ManifestWorkReplicaSetSpec{
+			ManifestWorkTemplate: mwv1.ManifestWorkSpec{Workload: mwv1.ManifestsTemplate{Manifests: []mwv1.Manifest{{
+				RawExtension: runtime.RawExtension{Raw: []byte(
+					`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "config", "namespace": "default"}, ` +
+						`"data": {"cluster": "{{cgu .ClusterName cgu}}"}}`)},
+			}}}},
+		},
+	}
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cgu", Namespace: "default",
+			Annotations: map[string]string{utils.NameSuffixAnnotation: "kuttl"},
+		},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			ManifestWorkTemplates: []string{"config"},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			RemediationPlan: [][]string{{"spoke1"}},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+					"spoke1": {State: ranv1alpha1.InProgress, ManifestWorkIndex: new(int)},
+				},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(mwScheme).WithObjects(mwrs).Build()
+	r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: mwScheme}
+
+	// The failed cluster is recorded once across reconciles
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, r.updateManifestWorkForCurrentBatch(context.TODO(), cgu))
+	}
+	assert.Equal(t, ranv1alpha1.Failed, cgu.Status.Status.CurrentBatchRemediationProgress["spoke1"].State)
+	assert.Len(t, cgu.Status.Clusters, 1)
+	assert.Equal(t, utils.ClusterRemediationFailed, cgu.Status.Clusters[0].State)
+}
@@ -5,9 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
@@ -17,6 +20,8 @@ type ConfigurationObject struct {
 	Name       string  `json:"name,omitempty"`
 	APIVersion string  `json:"apiVersion,omitempty"`
 	Namespace  *string `json:"namespace,omitempty"`
+	// ExpectedCSV is the ClusterServiceVersion the InstallPlans of a Subscription are expected to install
+	ExpectedCSV string `json:"expectedCSV,omitempty"`
 }
 
 func (r *ClusterGroupUpgradeReconciler) processManagedPolicyForMonitoredObjects(
@@ -141,20 +146,27 @@ func (r *ClusterGroupUpgradeReconciler) getMonitoredObjects(managedPolicy *unstr
 				continue
 			}
 
-			_, ok = objectDefinitionMetadataContent["namespace"]
-			if !ok {
-				r.Log.Info(
-					"[getPolicyContent] Policy is missing its spec.policy-templates.objectDefinition.spec.object-templates.metadata.namespace",
-					"policyName", managedPolicyName)
-				continue
+			// ClusterVersion is cluster scoped
+			namespace := ""
+			if kind != utils.ClusterVersionGroupVersionKind().Kind {
+				_, ok = objectDefinitionMetadataContent["namespace"]
+				if !ok {
+					r.Log.Info(
+						"[getPolicyContent] Policy is missing its spec.policy-templates.objectDefinition.spec.object-templates.metadata.namespace",
+						"policyName", managedPolicyName)
+					continue
+				}
+				namespace = objectDefinitionMetadataContent["namespace"].(string)
 			}
 
 			var object ConfigurationObject
 			object.Kind = innerObjectDefinitionContent["kind"].(string)
 			object.Name = objectDefinitionMetadataContent["name"].(string)
 			object.APIVersion = innerObjectDefinitionContent["apiVersion"].(string)
-			namespace := objectDefinitionMetadataContent["namespace"].(string)
 			object.Namespace = &namespace
+			if kind == utils.SubscriptionGroupVersionKind().Kind {
+				object.ExpectedCSV = getExpectedCSV(innerObjectDefinitionContent)
+			}
 
 			objects = append(objects, object)
 		}
@@ -164,9 +176,20 @@ func (r *ClusterGroupUpgradeReconciler) getMonitoredObjects(managedPolicy *unstr
 	return objects, nil
 }
 
+// getExpectedCSV returns the ClusterServiceVersion expected to be installed for a Subscription defined in a policy,
+// from its ran.openshift.io/expected-csv annotation or else its spec.startingCSV
+func getExpectedCSV(subscription map[string]interface{}) string {
+	expectedCSV, found, _ := unstructured.NestedString(subscription, "metadata", "annotations", utils.ExpectedCSVAnnotation)
+	if found && expectedCSV != "" {
+		return expectedCSV
+	}
+	startingCSV, _, _ := unstructured.NestedString(subscription, "spec", "startingCSV")
+	return startingCSV
+}
+
 func isMonitoredObjectType(kind interface{}) bool {
-	// TODO add utils.ClusterVersionGroupVersionKind().Kind
-	if kind == utils.SubscriptionGroupVersionKind().Kind {
+	switch kind {
+	case utils.SubscriptionGroupVersionKind().Kind, utils.ClusterVersionGroupVersionKind().Kind:
 		return true
 	}
 	return false
@@ -195,6 +218,10 @@ func (r *ClusterGroupUpgradeReconciler) processMonitoredObjects(
 			if err != nil {
 				return err
 			}
+			// The cluster might have failed while processing the object
+			if clusterProgress.State != ranv1alpha1.InProgress {
+				break
+			}
 		}
 	}
 	return nil
@@ -216,11 +243,37 @@ func (r *ClusterGroupUpgradeReconciler) processMonitoredObject(
 
 	switch object.Kind {
 	case utils.SubscriptionGroupVersionKind().Kind:
+		operatorProgress, err := utils.GetOperatorUpgradeProgress(ctx, r.Client, clusterGroupUpgrade, clusterName, mcv)
+		if err != nil {
+			r.Log.Info("An error occurred trying to get the operator upgrade progress", "error", err.Error())
+		} else if operatorProgress != nil {
+			operatorProgress.ExpectedCSV = object.ExpectedCSV
+			clusterProgress := clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName]
+			setOperatorUpgradeProgress(clusterProgress, *operatorProgress)
+			operatorProgress = getOperatorUpgradeProgress(clusterProgress, operatorProgress.Subscription, operatorProgress.Namespace)
+			if operatorProgress.CSVPhase == string(operatorsv1alpha1.CSVPhaseFailed) {
+				if operatorProgress.CSVFailedSince == nil {
+					now := metav1.Now()
+					operatorProgress.CSVFailedSince = &now
+				}
+				// OLM reports Failed briefly while retrying an install, only give up once it is stuck in Failed
+				if time.Since(operatorProgress.CSVFailedSince.Time) >= utils.CSVFailedGracePeriod {
+					r.Log.Info("Operator upgrade failed", "cluster name", clusterName,
+						"csv", operatorProgress.TargetCSV, "message", operatorProgress.Message)
+					return r.handleFailedCluster(ctx, clusterGroupUpgrade, clusterName,
+						fmt.Sprintf("Operator upgrade failed: ClusterServiceVersion %s in namespace %s is Failed: %s",
+							operatorProgress.TargetCSV, operatorProgress.Namespace, operatorProgress.Message))
+				}
+				r.Log.Info("Operator upgrade is failing", "cluster name", clusterName,
+					"csv", operatorProgress.TargetCSV, "failedSince", operatorProgress.CSVFailedSince)
+			}
+		}
+
 		r.Log.Info("[approveInstallPlan] Attempt to approve install plan for subscription",
 			"name", object.Name, "in namespace", object.Namespace)
 		// If the specific managedClusterView was found, check that it's condition Reason is "GetResourceProcessing"
 		installPlanStatus, err := utils.ProcessSubscriptionManagedClusterView(
-			ctx, r.Client, clusterGroupUpgrade, clusterName, mcv)
+			ctx, r.Client, clusterGroupUpgrade, clusterName, mcv, object.ExpectedCSV)
 		// If there is an error in trying to approve the install plan, just print the error and continue.
 		if err != nil {
 			r.Log.Info("An error occurred trying to approve install plan", "error", err.Error())
@@ -233,12 +286,122 @@ func (r *ClusterGroupUpgradeReconciler) processMonitoredObject(
 			r.Log.Info("InstallPlan for subscription could not be approved due to a MultiCloud object pending status, "+
 				"retry again later", "subscription name", object.Name)
 			return nil
+		} else if installPlanStatus == utils.InstallPlanUnexpectedCSV {
+			r.Log.Info("InstallPlan for subscription was refused as it doesn't install the expected CSV",
+				"subscription name", object.Name, "expectedCSV", object.ExpectedCSV)
+			if operatorProgress != nil {
+				operatorProgress = getOperatorUpgradeProgress(
+					clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName], operatorProgress.Subscription, operatorProgress.Namespace)
+				operatorProgress.InstallPlanRefusal = fmt.Sprintf(
+					"InstallPlan %s was not approved as it doesn't install the expected ClusterServiceVersion %s or an older version on the way to it",
+					operatorProgress.InstallPlan, object.ExpectedCSV)
+			}
+			return nil
 		} else if installPlanStatus == utils.InstallPlanWasApproved {
 			r.Log.Info("InstallPlan for subscription was approved", "subscription name", object.Name)
+			if operatorProgress != nil {
+				operatorProgress = getOperatorUpgradeProgress(
+					clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName], operatorProgress.Subscription, operatorProgress.Namespace)
+				if _, ok := utils.FindStringInSlice(operatorProgress.ApprovedInstallPlans, operatorProgress.InstallPlan); !ok {
+					operatorProgress.ApprovedInstallPlans = append(operatorProgress.ApprovedInstallPlans, operatorProgress.InstallPlan)
+					operatorProgress.Hops = len(operatorProgress.ApprovedInstallPlans)
+				}
+			}
 		}
 
 	case utils.ClusterVersionGroupVersionKind().Kind:
-		// TODO gather useful info from CV status and update the cluster/policy status in CGU
+		clusterVersionProgress, isFailing, err := utils.ProcessClusterVersionManagedClusterView(mcv)
+		if err != nil {
+			r.Log.Info("An error occurred trying to process the cluster version", "error", err.Error())
+			return nil
+		}
+		if clusterVersionProgress == nil {
+			return nil
+		}
+		clusterProgress := clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName]
+		clusterProgress.ClusterVersion = clusterVersionProgress
+		if isFailing {
+			r.Log.Info("Platform upgrade is failing", "cluster name", clusterName,
+				"failures", clusterVersionProgress.Failures)
+			return r.handleFailedCluster(ctx, clusterGroupUpgrade, clusterName,
+				"Platform upgrade failed: "+strings.Join(clusterVersionProgress.Failures, "; "))
+		}
+	}
+	return nil
+}
+
+// isOperatorUpgradeChainInProgress checks if an operator of the cluster has yet to install its expected CSV or a newer
+// one, while none of its InstallPlans was refused
+func isOperatorUpgradeChainInProgress(clusterProgress *ranv1alpha1.ClusterRemediationProgress) bool {
+	for _, operatorProgress := range clusterProgress.Operators {
+		if operatorProgress.ExpectedCSV != "" && utils.IsCSVOlderThan(operatorProgress.InstalledCSV, operatorProgress.ExpectedCSV) &&
+			operatorProgress.InstallPlanRefusal == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// setOperatorUpgradeProgress adds or updates the upgrade progress of an operator in the progress of a cluster
+func setOperatorUpgradeProgress(clusterProgress *ranv1alpha1.ClusterRemediationProgress, operatorProgress ranv1alpha1.OperatorUpgradeProgress) {
+	if existing := getOperatorUpgradeProgress(clusterProgress, operatorProgress.Subscription, operatorProgress.Namespace); existing != nil {
+		// Keep track of the upgrade chain
+		operatorProgress.ApprovedInstallPlans = existing.ApprovedInstallPlans
+		operatorProgress.Hops = existing.Hops
+		// Keep track of how long the same target ClusterServiceVersion has been Failed
+		if operatorProgress.CSVPhase == existing.CSVPhase && operatorProgress.TargetCSV == existing.TargetCSV {
+			operatorProgress.CSVFailedSince = existing.CSVFailedSince
+		}
+		*existing = operatorProgress
+		return
+	}
+	clusterProgress.Operators = append(clusterProgress.Operators, operatorProgress)
+}
+
+// getOperatorUpgradeProgress returns the upgrade progress of an operator in the progress of a cluster
+func getOperatorUpgradeProgress(
+	clusterProgress *ranv1alpha1.ClusterRemediationProgress, subscription, namespace string) *ranv1alpha1.OperatorUpgradeProgress {
+	for i, existing := range clusterProgress.Operators {
+		if existing.Subscription == subscription && existing.Namespace == namespace {
+			return &clusterProgress.Operators[i]
+		}
 	}
 	return nil
 }
+
+// handleFailedCluster stops remediating a cluster of the current batch whose upgrade reported a failure,
+// instead of waiting out the batch timeout
+func (r *ClusterGroupUpgradeReconciler) handleFailedCluster(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterName, message string) error {
+
+	clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName].State = ranv1alpha1.Failed
+	clusterFinalState := ranv1alpha1.ClusterState{
+		Name: clusterName, State: utils.ClusterRemediationFailed, Message: message,
+		StartedAt: clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt, CompletedAt: metav1.Now()}
+	switch clusterGroupUpgrade.RolloutType() {
+	case ranv1alpha1.RolloutTypes.ManifestWork:
+		if err := r.handleManifestWorkTimeoutForCluster(ctx, clusterGroupUpgrade, clusterName, &clusterFinalState); err != nil {
+			r.Log.Error(err, "[handleFailedCluster] Failed to get the current manifestwork", "cluster", clusterName)
+		}
+	case ranv1alpha1.RolloutTypes.ImageBasedUpgrade:
+		r.handleImageBasedUpgradeTimeoutForCluster(clusterGroupUpgrade, clusterName, &clusterFinalState)
+	default:
+		r.handlePolicyTimeoutForCluster(clusterGroupUpgrade, clusterName, &clusterFinalState)
+		if err := r.removeClusterFromPolicyPlacement(ctx, clusterGroupUpgrade, clusterName); err != nil {
+			return err
+		}
+	}
+	utils.DeleteMultiCloudObjects(ctx, r.Client, clusterGroupUpgrade, clusterName)
+	clusterGroupUpgrade.Status.Clusters = append(clusterGroupUpgrade.Status.Clusters, clusterFinalState)
+	return nil
+}
+
+// isClusterInFinalState checks if the cluster was recorded with the given state in the final cluster states
+func isClusterInFinalState(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterName, state string) bool {
+	for _, clusterState := range clusterGroupUpgrade.Status.Clusters {
+		if clusterState.Name == clusterName && clusterState.State == state {
+			return true
+		}
+	}
+	return false
+}
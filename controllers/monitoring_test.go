@@ -0,0 +1,357 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	actionv1beta1 "github.com/stolostron/cluster-lifecycle-api/action/v1beta1"
+	viewv1beta1 "github.com/stolostron/cluster-lifecycle-api/view/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMonitoring_getMonitoredObjects(t *testing.T) {
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "policy1", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"policy-templates": []interface{}{
+				map[string]interface{}{
+					"objectDefinition": map[string]interface{}{
+						"spec": map[string]interface{}{
+							"object-templates": []interface{}{
+								map[string]interface{}{
+									"complianceType": "musthave",
+									"objectDefinition": map[string]interface{}{
+										"apiVersion": "config.openshift.io/v1",
+										"kind":       "ClusterVersion",
+										"metadata":   map[string]interface{}{"name": "version"},
+									},
+								},
+								map[string]interface{}{
+									"complianceType": "musthave",
+									"objectDefinition": map[string]interface{}{
+										"apiVersion": "operators.coreos.com/v1alpha1",
+										"kind":       "Subscription",
+										"metadata":   map[string]interface{}{"name": "ptp"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+	objects, err := r.getMonitoredObjects(policy)
+	assert.NoError(t, err)
+	// The namespaced Subscription is skipped as it's missing its namespace
+	assert.Len(t, objects, 1)
+	assert.Equal(t, "ClusterVersion", objects[0].Kind)
+	assert.Equal(t, "version", objects[0].Name)
+	assert.Equal(t, "", *objects[0].Namespace)
+}
+
+func TestMonitoring_processMonitoredObjects_clusterVersion(t *testing.T) {
+	testcases := []struct {
+		name           string
+		clusterVersion string
+		expectedState  string
+		expectedFailed bool
+	}{
+		{
+			name: "upgrade in progress",
+			clusterVersion: `{"status": {"desired": {"version": "4.14.2"},
+				"history": [{"state": "Partial", "version": "4.14.2"}, {"state": "Completed", "version": "4.14.1"}],
+				"conditions": [{"type": "Progressing", "status": "True", "message": "Working towards 4.14.2: 700 of 859 done (81% complete)"}]}}`,
+			expectedState: ranv1alpha1.InProgress,
+		},
+		{
+			name: "upgrade failing",
+			clusterVersion: `{"status": {"desired": {"version": "4.14.2"},
+				"history": [{"state": "Partial", "version": "4.14.2"}, {"state": "Completed", "version": "4.14.1"}],
+				"conditions": [{"type": "Failing", "status": "True", "message": "Cluster operator etcd is degraded"}]}}`,
+			expectedState:  ranv1alpha1.Failed,
+			expectedFailed: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			monitoringScheme := runtime.NewScheme()
+			assert.NoError(t, ranv1alpha1.AddToScheme(monitoringScheme))
+			assert.NoError(t, viewv1beta1.AddToScheme(monitoringScheme))
+			assert.NoError(t, actionv1beta1.AddToScheme(monitoringScheme))
+			monitoringScheme.AddKnownTypeWithName(utils.PlacementRuleGroupVersionKind(), &unstructured.Unstructured{})
+
+			policyIndex := 0
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cgu",
+					Namespace:   "default",
+					Annotations: map[string]string{utils.NameSuffixAnnotation: "kuttl"},
+				},
+				Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+					SafeResourceNames: map[string]string{
+						utils.PrefixNameWithNamespace("default", "cgu-policy1-placement"): "cgu-policy1-placement-kuttl",
+					},
+					ManagedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{{Name: "policy1", Namespace: "default"}},
+					ManagedPoliciesContent: map[string]string{
+						"policy1": `[{"kind":"ClusterVersion","name":"version","apiVersion":"config.openshift.io/v1","namespace":""}]`,
+					},
+					Status: ranv1alpha1.UpgradeStatus{
+						CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+							"spoke1": {State: ranv1alpha1.InProgress, PolicyIndex: &policyIndex},
+						},
+					},
+				},
+			}
+			mcvName := utils.GetMultiCloudObjectName(cgu, "ClusterVersion", "version")
+			mcv := &viewv1beta1.ManagedClusterView{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      utils.GetSafeResourceName(mcvName, "", cgu, utils.MaxObjectNameLength),
+					Namespace: "spoke1",
+				},
+				Status: viewv1beta1.ViewStatus{
+					Conditions: []metav1.Condition{{
+						Type:   viewv1beta1.ConditionViewProcessing,
+						Status: metav1.ConditionTrue,
+						Reason: viewv1beta1.ReasonGetResource,
+					}},
+					Result: runtime.RawExtension{Raw: []byte(tc.clusterVersion)},
+				},
+			}
+			placementRule := &unstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"clusters": []interface{}{map[string]interface{}{"name": "spoke1"}},
+				},
+			}}
+			placementRule.SetGroupVersionKind(utils.PlacementRuleGroupVersionKind())
+			placementRule.SetName("cgu-policy1-placement-kuttl")
+			placementRule.SetNamespace("default")
+			c := fake.NewClientBuilder().WithScheme(monitoringScheme).WithObjects(mcv, placementRule).Build()
+			r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: monitoringScheme}
+
+			assert.NoError(t, r.processMonitoredObjects(context.TODO(), cgu))
+
+			clusterProgress := cgu.Status.Status.CurrentBatchRemediationProgress["spoke1"]
+			assert.Equal(t, tc.expectedState, clusterProgress.State)
+			assert.Equal(t, "4.14.1", clusterProgress.ClusterVersion.CurrentVersion)
+			assert.Equal(t, "4.14.2", clusterProgress.ClusterVersion.DesiredVersion)
+			assert.Equal(t, tc.expectedFailed, isClusterInFinalState(cgu, "spoke1", utils.ClusterRemediationFailed))
+
+			err := c.Get(context.TODO(), types.NamespacedName{Name: mcv.Name, Namespace: "spoke1"}, &viewv1beta1.ManagedClusterView{})
+			if tc.expectedFailed {
+				// The views of the failed cluster are cleaned up
+				assert.Error(t, err)
+				assert.Equal(t, "Platform upgrade failed: Failing=True: Cluster operator etcd is degraded",
+					cgu.Status.Clusters[0].Message)
+				assert.Equal(t, "policy1", cgu.Status.Clusters[0].CurrentPolicy.Name)
+			} else {
+				assert.NoError(t, err)
+				assert.Empty(t, cgu.Status.Clusters)
+			}
+
+			// The failed cluster is removed from the placement of the policy
+			assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: placementRule.GetName(), Namespace: "default"}, placementRule))
+			clusters, _, _ := unstructured.NestedSlice(placementRule.Object, "spec", "clusters")
+			if tc.expectedFailed {
+				assert.Empty(t, clusters)
+			} else {
+				assert.Len(t, clusters, 1)
+			}
+		})
+	}
+}
+
+func TestMonitoring_processMonitoredObjects_subscription(t *testing.T) {
+	monitoringScheme := runtime.NewScheme()
+	assert.NoError(t, ranv1alpha1.AddToScheme(monitoringScheme))
+	assert.NoError(t, viewv1beta1.AddToScheme(monitoringScheme))
+	assert.NoError(t, actionv1beta1.AddToScheme(monitoringScheme))
+	monitoringScheme.AddKnownTypeWithName(utils.PlacementRuleGroupVersionKind(), &unstructured.Unstructured{})
+
+	policyIndex := 0
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cgu",
+			Namespace:   "default",
+			Annotations: map[string]string{utils.NameSuffixAnnotation: "kuttl"},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			SafeResourceNames: map[string]string{
+				utils.PrefixNameWithNamespace("default", "cgu-policy1-placement"): "cgu-policy1-placement-kuttl",
+			},
+			ManagedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{{Name: "policy1", Namespace: "default"}},
+			ManagedPoliciesContent: map[string]string{
+				"policy1": `[{"kind":"Subscription","name":"ptp","apiVersion":"operators.coreos.com/v1alpha1","namespace":"openshift-ptp"}]`,
+			},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+					"spoke1": {State: ranv1alpha1.InProgress, PolicyIndex: &policyIndex},
+				},
+			},
+		},
+	}
+	retrievedCondition := []metav1.Condition{{
+		Type:   viewv1beta1.ConditionViewProcessing,
+		Status: metav1.ConditionTrue,
+		Reason: viewv1beta1.ReasonGetResource,
+	}}
+	newView := func(name, result string) *viewv1beta1.ManagedClusterView {
+		return &viewv1beta1.ManagedClusterView{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "spoke1"},
+			Status: viewv1beta1.ViewStatus{
+				Conditions: retrievedCondition,
+				Result:     runtime.RawExtension{Raw: []byte(result)},
+			},
+		}
+	}
+	subscriptionView := newView(
+		utils.GetSafeResourceName(utils.GetMultiCloudObjectName(cgu, "Subscription", "ptp"), "", cgu, utils.MaxObjectNameLength),
+		`{"metadata": {"name": "ptp", "namespace": "openshift-ptp"},
+			"status": {"state": "UpgradePending", "currentCSV": "ptp-operator.v4.14.2", "installplan": {"name": "install-abcde"}}}`)
+	installPlanView := newView("install-abcde", `{"status": {"phase": "Complete"}}`)
+	csvView := newView(
+		utils.GetSafeResourceName(utils.GetMultiCloudObjectName(cgu, "ClusterServiceVersion", "ptp-operator.v4.14.2"), "", cgu, utils.MaxObjectNameLength),
+		`{"status": {"phase": "Failed", "message": "install strategy failed"}}`)
+
+	c := fake.NewClientBuilder().WithScheme(monitoringScheme).WithObjects(subscriptionView, installPlanView, csvView).Build()
+	r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: monitoringScheme}
+
+	assert.NoError(t, r.processMonitoredObjects(context.TODO(), cgu))
+
+	// The cluster isn't failed as soon as the CSV is seen Failed
+	clusterProgress := cgu.Status.Status.CurrentBatchRemediationProgress["spoke1"]
+	assert.Equal(t, ranv1alpha1.InProgress, clusterProgress.State)
+	assert.Len(t, clusterProgress.Operators, 1)
+	failedSince := clusterProgress.Operators[0].CSVFailedSince
+	assert.NotNil(t, failedSince)
+	assert.Empty(t, cgu.Status.Clusters)
+
+	// The time it was first seen Failed is kept
+	assert.NoError(t, r.processMonitoredObjects(context.TODO(), cgu))
+	assert.Equal(t, ranv1alpha1.InProgress, clusterProgress.State)
+	assert.Equal(t, failedSince, clusterProgress.Operators[0].CSVFailedSince)
+
+	// The cluster fails once the CSV is stuck in Failed
+	stuckSince := metav1.NewTime(time.Now().Add(-utils.CSVFailedGracePeriod))
+	clusterProgress.Operators[0].CSVFailedSince = &stuckSince
+	assert.NoError(t, r.processMonitoredObjects(context.TODO(), cgu))
+	assert.Equal(t, ranv1alpha1.Failed, clusterProgress.State)
+	assert.Equal(t, []ranv1alpha1.OperatorUpgradeProgress{{
+		Subscription:     "ptp",
+		Namespace:        "openshift-ptp",
+		TargetCSV:        "ptp-operator.v4.14.2",
+		CSVPhase:         "Failed",
+		CSVFailedSince:   &stuckSince,
+		InstallPlan:      "install-abcde",
+		InstallPlanPhase: "Complete",
+		Message:          "install strategy failed",
+	}}, clusterProgress.Operators)
+	assert.Equal(t, "Operator upgrade failed: ClusterServiceVersion ptp-operator.v4.14.2 in namespace openshift-ptp is Failed: "+
+		"install strategy failed", cgu.Status.Clusters[0].Message)
+	assert.True(t, isClusterInFinalState(cgu, "spoke1", utils.ClusterRemediationFailed))
+}
+
+func TestMonitoring_getExpectedCSV(t *testing.T) {
+	subscription := map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "ptp"},
+		"spec":     map[string]interface{}{"startingCSV": "ptp-operator.v4.14.1"},
+	}
+	assert.Equal(t, "ptp-operator.v4.14.1", getExpectedCSV(subscription))
+
+	subscription["metadata"] = map[string]interface{}{
+		"name":        "ptp",
+		"annotations": map[string]interface{}{utils.ExpectedCSVAnnotation: "ptp-operator.v4.14.2"},
+	}
+	assert.Equal(t, "ptp-operator.v4.14.2", getExpectedCSV(subscription))
+
+	assert.Equal(t, "", getExpectedCSV(map[string]interface{}{"metadata": map[string]interface{}{"name": "ptp"}}))
+}
+
+func TestMonitoring_processMonitoredObjects_upgradeChain(t *testing.T) {
+	monitoringScheme := runtime.NewScheme()
+	assert.NoError(t, ranv1alpha1.AddToScheme(monitoringScheme))
+	assert.NoError(t, viewv1beta1.AddToScheme(monitoringScheme))
+	assert.NoError(t, actionv1beta1.AddToScheme(monitoringScheme))
+
+	policyIndex := 0
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cgu",
+			Namespace:   "default",
+			Annotations: map[string]string{utils.NameSuffixAnnotation: "kuttl"},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			SafeResourceNames:         map[string]string{},
+			ManagedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{{Name: "policy1", Namespace: "default"}},
+			ManagedPoliciesContent: map[string]string{
+				"policy1": `[{"kind":"Subscription","name":"ptp","apiVersion":"operators.coreos.com/v1alpha1",` +
+					`"namespace":"openshift-ptp","expectedCSV":"ptp-operator.v4.14.2"}]`,
+			},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+					"spoke1": {
+						State:       ranv1alpha1.InProgress,
+						PolicyIndex: &policyIndex,
+						Operators: []ranv1alpha1.OperatorUpgradeProgress{{
+							Subscription:         "ptp",
+							Namespace:            "openshift-ptp",
+							ApprovedInstallPlans: []string{"install-first"},
+							Hops:                 1,
+						}},
+					},
+				},
+			},
+		},
+	}
+	retrievedCondition := []metav1.Condition{{
+		Type:   viewv1beta1.ConditionViewProcessing,
+		Status: metav1.ConditionTrue,
+		Reason: viewv1beta1.ReasonGetResource,
+	}}
+	newView := func(name, result string) *viewv1beta1.ManagedClusterView {
+		return &viewv1beta1.ManagedClusterView{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "spoke1"},
+			Status: viewv1beta1.ViewStatus{
+				Conditions: retrievedCondition,
+				Result:     runtime.RawExtension{Raw: []byte(result)},
+			},
+		}
+	}
+	// The second hop of the upgrade chain goes through an intermediate version
+	subscriptionView := newView(
+		utils.GetSafeResourceName(utils.GetMultiCloudObjectName(cgu, "Subscription", "ptp"), "", cgu, utils.MaxObjectNameLength),
+		`{"metadata": {"name": "ptp", "namespace": "openshift-ptp"},
+			"status": {"state": "UpgradePending", "installedCSV": "ptp-operator.v4.14.0", "currentCSV": "ptp-operator.v4.14.1",
+			"installplan": {"name": "install-second"}}}`)
+	installPlanView := newView("install-second",
+		`{"metadata": {"name": "install-second", "namespace": "openshift-ptp"},
+			"spec": {"approval": "Manual", "approved": false, "clusterServiceVersionNames": ["ptp-operator.v4.14.1"]},
+			"status": {"phase": "RequiresApproval"}}`)
+
+	c := fake.NewClientBuilder().WithScheme(monitoringScheme).WithObjects(subscriptionView, installPlanView).Build()
+	r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: monitoringScheme}
+
+	assert.NoError(t, r.processMonitoredObjects(context.TODO(), cgu))
+
+	operatorProgress := cgu.Status.Status.CurrentBatchRemediationProgress["spoke1"].Operators[0]
+	assert.Equal(t, "ptp-operator.v4.14.2", operatorProgress.ExpectedCSV)
+	assert.Equal(t, []string{"install-first", "install-second"}, operatorProgress.ApprovedInstallPlans)
+	assert.Equal(t, 2, operatorProgress.Hops)
+	assert.Equal(t, "ptp-operator.v4.14.0 -> ptp-operator.v4.14.1", operatorProgress.CurrentHop)
+	assert.Empty(t, operatorProgress.InstallPlanRefusal)
+
+	mca := &actionv1beta1.ManagedClusterAction{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "install-second", Namespace: "spoke1"}, mca))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	policiesv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -40,7 +42,12 @@ func (r *ClusterGroupUpgradeReconciler) updatePlacementRules(ctx context.Context
 		placementRuleName := utils.GetResourceName(clusterGroupUpgrade, policyName+"-placement")
 		if prSafeName, ok := clusterGroupUpgrade.Status.SafeResourceNames[utils.PrefixNameWithNamespace(policyNamespace, placementRuleName)]; ok {
 			// The PR should be in the same namespace as where the policy is created
-			err := r.updatePlacementRuleWithClusters(ctx, clusterNames, prSafeName, policyNamespace)
+			var err error
+			if r.UsePlacementAPI {
+				err = r.updatePlacementWithClusters(ctx, clusterNames, prSafeName, policyNamespace)
+			} else {
+				err = r.updatePlacementRuleWithClusters(ctx, clusterNames, prSafeName, policyNamespace)
+			}
 			if err != nil {
 				return err
 			}
@@ -108,6 +115,87 @@ func (r *ClusterGroupUpgradeReconciler) updatePlacementRuleWithClusters(
 	return nil
 }
 
+// updatePlacementWithClusters adds the clusters to a batch Placement by labeling the ManagedClusters
+// with the label selected by the Placement
+func (r *ClusterGroupUpgradeReconciler) updatePlacementWithClusters(
+	ctx context.Context, clusterNames []string, placementName, placementNamespace string) error {
+
+	label := utils.GetPlacementClusterLabel(placementNamespace, placementName)
+	for _, clusterName := range clusterNames {
+		managedCluster := &clusterv1.ManagedCluster{}
+		if err := r.Get(ctx, types.NamespacedName{Name: clusterName}, managedCluster); err != nil {
+			return err
+		}
+		if _, found := managedCluster.GetLabels()[label]; found {
+			continue
+		}
+
+		patch := client.MergeFrom(managedCluster.DeepCopy())
+		labels := managedCluster.GetLabels()
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[label] = ""
+		managedCluster.SetLabels(labels)
+		if err := r.Patch(ctx, managedCluster, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeClusterFromPolicyPlacement removes a cluster from the batch placement of the policy it was remediating, so
+// the enforced copy of the policy isn't applied to it anymore
+func (r *ClusterGroupUpgradeReconciler) removeClusterFromPolicyPlacement(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterName string) error {
+
+	clusterProgress := clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName]
+	if clusterProgress == nil || clusterProgress.PolicyIndex == nil ||
+		*clusterProgress.PolicyIndex >= len(clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade) {
+		return nil
+	}
+	policy := clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade[*clusterProgress.PolicyIndex]
+	placementRuleName := utils.GetResourceName(clusterGroupUpgrade, policy.Name+"-placement")
+	prSafeName, ok := clusterGroupUpgrade.Status.SafeResourceNames[utils.PrefixNameWithNamespace(policy.Namespace, placementRuleName)]
+	if !ok {
+		return fmt.Errorf("placement object name %s not found in CGU %s", placementRuleName, clusterGroupUpgrade.Name)
+	}
+
+	if r.UsePlacementAPI {
+		managedCluster := &clusterv1.ManagedCluster{}
+		if err := r.Get(ctx, types.NamespacedName{Name: clusterName}, managedCluster); err != nil {
+			return client.IgnoreNotFound(err)
+		}
+		label := utils.GetPlacementClusterLabel(policy.Namespace, prSafeName)
+		if _, found := managedCluster.GetLabels()[label]; !found {
+			return nil
+		}
+		patch := client.MergeFrom(managedCluster.DeepCopy())
+		delete(managedCluster.Labels, label)
+		return r.Patch(ctx, managedCluster, patch)
+	}
+
+	placementRule := &unstructured.Unstructured{}
+	placementRule.SetGroupVersionKind(utils.PlacementRuleGroupVersionKind())
+	err := r.Client.Get(ctx, client.ObjectKey{Name: prSafeName, Namespace: policy.Namespace}, placementRule)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	placementRuleSpecClusters := placementRule.Object["spec"].(map[string]interface{})
+	currentClusters, _ := placementRuleSpecClusters["clusters"].([]interface{})
+	var updatedClusters []interface{}
+	for _, clusterEntry := range currentClusters {
+		if clusterEntry.(map[string]interface{})["name"] != clusterName {
+			updatedClusters = append(updatedClusters, clusterEntry)
+		}
+	}
+	if len(updatedClusters) == len(currentClusters) {
+		return nil
+	}
+	placementRuleSpecClusters["clusters"] = updatedClusters
+	return r.Client.Update(ctx, placementRule)
+}
+
 func (r *ClusterGroupUpgradeReconciler) cleanupPlacementRules(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
 	var targetNamespaces []string
 	for _, policy := range clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade {
@@ -133,6 +221,18 @@ func (r *ClusterGroupUpgradeReconciler) cleanupPlacementRules(ctx context.Contex
 				errorMap[plr.GetName()] = err.Error()
 			}
 		}
+
+		placements, err := r.getPlacements(ctx, clusterGroupUpgrade, ns)
+		if err != nil {
+			return err
+		}
+
+		for _, placement := range placements.Items {
+			err = utils.RemovePlacementClusterLabel(ctx, r.Client, placement.GetNamespace(), placement.GetName())
+			if err != nil {
+				errorMap[placement.GetName()] = err.Error()
+			}
+		}
 	}
 
 	if len(errorMap) != 0 {
@@ -229,7 +329,15 @@ func (r *ClusterGroupUpgradeReconciler) doManagedPoliciesExist(
 	clusterGroupUpgrade.Status.ManagedPoliciesNs = make(map[string]string)
 	clusterGroupUpgrade.Status.ManagedPoliciesContent = make(map[string]string)
 
-	for _, managedPolicyName := range clusterGroupUpgrade.Spec.ManagedPolicies {
+	managedPolicyNames, err := r.expandManagedPolicySets(ctx, clusterGroupUpgrade, &managedPoliciesInfo)
+	if err != nil {
+		return false, managedPoliciesInfo, err
+	}
+	if len(managedPoliciesInfo.missingPolicySets) != 0 || len(managedPoliciesInfo.duplicatedPolicySetsNs) != 0 {
+		return false, managedPoliciesInfo, nil
+	}
+
+	for _, managedPolicyName := range managedPolicyNames {
 		if policyEnforce[managedPolicyName] {
 			r.Log.Info("Ignoring policy " + managedPolicyName + " with remediationAction enforce")
 			continue
@@ -302,6 +410,100 @@ func (r *ClusterGroupUpgradeReconciler) doManagedPoliciesExist(
 	return true, managedPoliciesInfo, nil
 }
 
+/*
+expandManagedPolicySets returns the names of the managed policies of the CGU: spec.managedPolicies followed by
+the member policies of spec.managedPolicySets that aren't listed already. The members are sorted by their
+ztp-deploy-wave annotation, members without a valid wave come last, and the sort is stable so ties keep the
+order of the policy sets and of their spec.policies.
+
+	returns: []string the names of the managed policies
+	         error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) expandManagedPolicySets(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, managedPoliciesInfo *policiesInfo) ([]string, error) {
+
+	managedPolicyNames := append([]string{}, clusterGroupUpgrade.Spec.ManagedPolicies...)
+	if len(clusterGroupUpgrade.Spec.ManagedPolicySets) == 0 {
+		return managedPolicyNames, nil
+	}
+
+	type policySetMember struct {
+		name string
+		wave *int
+	}
+	var members []policySetMember
+	for _, policySetName := range clusterGroupUpgrade.Spec.ManagedPolicySets {
+		// The policy set can be in any namespace, only the policy sets with its name are listed
+		policySetsList := &unstructured.UnstructuredList{}
+		policySetsList.SetGroupVersionKind(schema.GroupVersionKind{
+			Group:   utils.PolicySetGroupVersionKind().Group,
+			Kind:    utils.PolicySetGroupVersionKind().Kind + "List",
+			Version: utils.PolicySetGroupVersionKind().Version,
+		})
+		// Without the PolicySet API, all the managed policy sets are missing
+		err := r.List(ctx, policySetsList, client.MatchingFields{"metadata.name": policySetName})
+		if err != nil && !meta.IsNoMatchError(err) {
+			return nil, err
+		}
+		found := policySetsList.Items
+		if len(found) == 0 {
+			managedPoliciesInfo.missingPolicySets = append(managedPoliciesInfo.missingPolicySets, policySetName)
+			continue
+		}
+		if len(found) > 1 {
+			if managedPoliciesInfo.duplicatedPolicySetsNs == nil {
+				managedPoliciesInfo.duplicatedPolicySetsNs = make(map[string][]string)
+			}
+			for _, policySet := range found {
+				managedPoliciesInfo.duplicatedPolicySetsNs[policySetName] = append(
+					managedPoliciesInfo.duplicatedPolicySetsNs[policySetName], policySet.GetNamespace())
+			}
+			sort.Strings(managedPoliciesInfo.duplicatedPolicySetsNs[policySetName])
+			continue
+		}
+
+		policyNames, _, err := unstructured.NestedStringSlice(found[0].Object, "spec", "policies")
+		if err != nil {
+			return nil, fmt.Errorf("invalid spec.policies in policy set %s: %v", policySetName, err)
+		}
+		for _, policyName := range policyNames {
+			if _, ok := utils.FindStringInSlice(managedPolicyNames, policyName); ok {
+				continue
+			}
+			managedPolicyNames = append(managedPolicyNames, policyName)
+
+			// A missing member is reported as a missing managed policy by the caller
+			member := policySetMember{name: policyName}
+			policy, err := r.getPolicyByName(ctx, policyName, found[0].GetNamespace())
+			if err != nil && !errors.IsNotFound(err) {
+				return nil, err
+			}
+			if err == nil {
+				if deployWave, ok := policy.GetAnnotations()[ztpDeployWaveAnnotation]; ok {
+					if wave, err := strconv.Atoi(deployWave); err == nil {
+						member.wave = &wave
+					} else {
+						r.Log.Info("[expandManagedPolicySets] Ignoring invalid deploy wave", "policy", policyName, "wave", deployWave)
+					}
+				}
+			}
+			members = append(members, member)
+		}
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].wave == nil || members[j].wave == nil {
+			return members[i].wave != nil && members[j].wave == nil
+		}
+		return *members[i].wave < *members[j].wave
+	})
+	managedPolicyNames = managedPolicyNames[:len(clusterGroupUpgrade.Spec.ManagedPolicies)]
+	for _, member := range members {
+		managedPolicyNames = append(managedPolicyNames, member.name)
+	}
+	return managedPolicyNames, nil
+}
+
 func (r *ClusterGroupUpgradeReconciler) ensureBatchPlacementRule(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, managedPolicy *unstructured.Unstructured) (string, error) {
 
 	name := utils.GetResourceName(clusterGroupUpgrade, managedPolicy.GetName()+"-placement")
@@ -370,10 +572,96 @@ func (r *ClusterGroupUpgradeReconciler) newBatchPlacementRule(clusterGroupUpgrad
 	return u
 }
 
+func (r *ClusterGroupUpgradeReconciler) ensureBatchPlacement(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, managedPolicy *unstructured.Unstructured) (string, error) {
+
+	name := utils.GetResourceName(clusterGroupUpgrade, managedPolicy.GetName()+"-placement")
+	safeName := utils.GetSafeResourceName(name, managedPolicy.GetNamespace(), clusterGroupUpgrade, utils.MaxObjectNameLength)
+	placement := r.newBatchPlacement(clusterGroupUpgrade, managedPolicy.GetName(), managedPolicy.GetNamespace(), safeName, name)
+
+	foundPlacement := &unstructured.Unstructured{}
+	foundPlacement.SetGroupVersionKind(utils.PlacementGroupVersionKind())
+
+	err := r.Client.Get(ctx, client.ObjectKey{
+		Name:      safeName,
+		Namespace: managedPolicy.GetNamespace(),
+	}, foundPlacement)
+
+	if err != nil {
+		if errors.IsNotFound(err) {
+			err = r.Client.Create(ctx, placement)
+			if err != nil {
+				return "", err
+			}
+		} else {
+			return "", err
+		}
+	} else {
+		placement.SetResourceVersion(foundPlacement.GetResourceVersion())
+		err = r.Client.Update(ctx, placement)
+		if err != nil {
+			return "", err
+		}
+	}
+	return safeName, nil
+}
+
+// newBatchPlacement returns a Placement selecting the ManagedClusters labeled for it. Clusters are added
+// to the Placement by labeling them, the tolerations keep them selected while they are unreachable during the upgrade.
+func (r *ClusterGroupUpgradeReconciler) newBatchPlacement(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, policyName, policyNamespace, placementName, desiredName string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.Object = map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":      placementName,
+			"namespace": policyNamespace,
+			"labels": map[string]interface{}{
+				"app": "openshift-cluster-group-upgrades",
+				"openshift-cluster-group-upgrades/clusterGroupUpgrade":          clusterGroupUpgrade.Name,
+				"openshift-cluster-group-upgrades/clusterGroupUpgradeNamespace": clusterGroupUpgrade.Namespace,
+				"openshift-cluster-group-upgrades/forPolicy":                    policyName,
+				utils.ExcludeFromClusterBackup:                                  "true",
+			},
+			"annotations": map[string]interface{}{
+				utils.DesiredResourceName: utils.PrefixNameWithNamespace(clusterGroupUpgrade.Namespace, desiredName),
+			},
+		},
+		"spec": map[string]interface{}{
+			"predicates": []interface{}{
+				map[string]interface{}{
+					"requiredClusterSelector": map[string]interface{}{
+						"labelSelector": map[string]interface{}{
+							"matchExpressions": []interface{}{
+								map[string]interface{}{
+									"key":      utils.GetPlacementClusterLabel(policyNamespace, placementName),
+									"operator": "Exists",
+								},
+							},
+						},
+					},
+				},
+			},
+			"tolerations": []interface{}{
+				map[string]interface{}{
+					"key":      "cluster.open-cluster-management.io/unreachable",
+					"operator": "Exists",
+				},
+				map[string]interface{}{
+					"key":      "cluster.open-cluster-management.io/unavailable",
+					"operator": "Exists",
+				},
+			},
+		},
+	}
+
+	u.SetGroupVersionKind(utils.PlacementGroupVersionKind())
+
+	return u
+}
+
 /*
 getNextNonCompliantPolicyForCluster goes through all the policies in the managedPolicies list, starting with the
 
 	policy index for the requested cluster and returns the index of the first policy that has the cluster as NonCompliant.
+	If the cluster has exceeded the remediation timeout of that policy, its progress state is set to TimedOut.
 
 	returns: policyIndex the index of the next policy for which the cluster is NonCompliant or -1 if no policy found
 	         error/nil
@@ -401,10 +689,17 @@ func (r *ClusterGroupUpgradeReconciler) getNextNonCompliantPolicyForCluster(
 		}
 
 		if clusterStatus == utils.ClusterStatusCompliant {
-			_, ok := clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName]
+			clusterProgress, ok := clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName]
 			if !ok {
 				continue
 			}
+			// OLM only creates the InstallPlan of the next hop of an upgrade chain once the previous hop is installed,
+			// keep the cluster on the policy until the expected CSV is installed
+			if currentPolicyIndex == startIndex && isOperatorUpgradeChainInProgress(clusterProgress) {
+				r.Log.Info("Policy is compliant but the expected CSV is not installed yet",
+					"cluster name", clusterName, "policyName", currentManagedPolicy.GetName())
+				break
+			}
 			shouldSoak, err := utils.ShouldSoak(currentManagedPolicy, clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName].FirstCompliantAt)
 			if err != nil {
 				r.Log.Info(err.Error())
@@ -423,6 +718,25 @@ func (r *ClusterGroupUpgradeReconciler) getNextNonCompliantPolicyForCluster(
 		}
 
 		if clusterStatus == utils.ClusterStatusNonCompliant {
+			clusterProgress, ok := clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName]
+			if !ok {
+				break
+			}
+			if currentPolicyIndex != startIndex || clusterProgress.PolicyStartedAt.IsZero() {
+				clusterProgress.PolicyStartedAt = metav1.Now()
+				// The monitored objects progress belongs to the previous policy
+				clusterProgress.ClusterVersion = nil
+				clusterProgress.Operators = nil
+			}
+			isTimedOut, err := utils.IsRemediationTimedOut(currentManagedPolicy, clusterProgress.PolicyStartedAt)
+			if err != nil {
+				r.Log.Info(err.Error())
+				break
+			}
+			if isTimedOut {
+				r.Log.Info("Policy remediation timed out", "cluster name", clusterName, "policyName", currentManagedPolicy.GetName())
+				clusterProgress.State = ranv1alpha1.TimedOut
+			}
 			break
 		}
 	}
@@ -496,6 +810,16 @@ func (r *ClusterGroupUpgradeReconciler) newBatchPlacementBinding(clusterGroupUpg
 	subject["apiGroup"] = "policy.open-cluster-management.io"
 	subjects = append(subjects, subject)
 
+	placementRef := map[string]interface{}{
+		"name":     placementRuleName,
+		"kind":     "PlacementRule",
+		"apiGroup": "apps.open-cluster-management.io",
+	}
+	if r.UsePlacementAPI {
+		placementRef["kind"] = utils.PlacementGroupVersionKind().Kind
+		placementRef["apiGroup"] = utils.PlacementGroupVersionKind().Group
+	}
+
 	u := &unstructured.Unstructured{}
 	u.Object = map[string]interface{}{
 		"metadata": map[string]interface{}{
@@ -517,12 +841,8 @@ func (r *ClusterGroupUpgradeReconciler) newBatchPlacementBinding(clusterGroupUpg
 		"bindingOverrides": map[string]interface{}{
 			"remediationAction": "enforce",
 		},
-		"placementRef": map[string]interface{}{
-			"name":     placementRuleName,
-			"kind":     "PlacementRule",
-			"apiGroup": "apps.open-cluster-management.io",
-		},
-		"subjects": subjects,
+		"placementRef": placementRef,
+		"subjects":     subjects,
 	}
 	u.SetGroupVersionKind(schema.GroupVersionKind{
 		Group:   "policy.open-cluster-management.io",
@@ -552,13 +872,22 @@ func (r *ClusterGroupUpgradeReconciler) getPlacementRules(ctx context.Context, c
 		Kind:    "PlacementRuleList",
 		Version: "v1",
 	})
-	if err := r.List(ctx, placementRulesList, listOpts...); err != nil {
+	// The PlacementRule API might not be served when the Placement API is in use
+	if err := r.List(ctx, placementRulesList, listOpts...); err != nil && !meta.IsNoMatchError(err) {
 		return nil, err
 	}
 
 	return placementRulesList, nil
 }
 
+func (r *ClusterGroupUpgradeReconciler) getPlacements(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, policyNamespace string) (*unstructured.UnstructuredList, error) {
+	var placementLabels = map[string]string{
+		"openshift-cluster-group-upgrades/clusterGroupUpgrade":          clusterGroupUpgrade.Name,
+		"openshift-cluster-group-upgrades/clusterGroupUpgradeNamespace": clusterGroupUpgrade.Namespace,
+	}
+	return utils.ListPlacements(ctx, r.Client, policyNamespace, placementLabels)
+}
+
 func (r *ClusterGroupUpgradeReconciler) getPlacementBindings(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, policyNamespace string) (*unstructured.UnstructuredList, error) {
 	var placementBindingLabels = map[string]string{
 		"openshift-cluster-group-upgrades/clusterGroupUpgrade":          clusterGroupUpgrade.Name,
@@ -584,7 +913,13 @@ func (r *ClusterGroupUpgradeReconciler) getPlacementBindings(ctx context.Context
 func (r *ClusterGroupUpgradeReconciler) reconcileResources(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, managedPoliciesPresent []*unstructured.Unstructured) error {
 	// Reconcile resources
 	for _, managedPolicy := range managedPoliciesPresent {
-		placementRuleName, err := r.ensureBatchPlacementRule(ctx, clusterGroupUpgrade, managedPolicy)
+		var placementRuleName string
+		var err error
+		if r.UsePlacementAPI {
+			placementRuleName, err = r.ensureBatchPlacement(ctx, clusterGroupUpgrade, managedPolicy)
+		} else {
+			placementRuleName, err = r.ensureBatchPlacementRule(ctx, clusterGroupUpgrade, managedPolicy)
+		}
 		if err != nil {
 			return err
 		}
@@ -700,6 +1035,19 @@ func (r *ClusterGroupUpgradeReconciler) getClusterComplianceWithPolicy(
 	return utils.ClusterNotMatchedWithPolicy
 }
 
+// isClusterReportedNonCompliant checks if the policy status explicitly reports the cluster as NonCompliant. Unlike
+// getClusterComplianceWithPolicy, a missing or Pending compliance status is not treated as NonCompliant.
+func (r *ClusterGroupUpgradeReconciler) isClusterReportedNonCompliant(
+	clusterName string, policy *unstructured.Unstructured) bool {
+	for _, crtSubStatusCrt := range r.getPolicyClusterStatus(policy) {
+		crtSubStatusMap := crtSubStatusCrt.(map[string]interface{})
+		if clusterName == crtSubStatusMap["clustername"].(string) {
+			return crtSubStatusMap["compliant"] == utils.ClusterStatusNonCompliant
+		}
+	}
+	return false
+}
+
 func (r *ClusterGroupUpgradeReconciler) getClustersNonCompliantWithManagedPolicies(clusters []string, managedPolicies []*unstructured.Unstructured) map[string]bool {
 	clustersNonCompliantMap := make(map[string]bool)
 
@@ -724,6 +1072,12 @@ func (r *ClusterGroupUpgradeReconciler) arePreviousBatchesCompleteForPolicies(ct
 	// Check previous batches
 	for i := 0; i < len(clusterGroupUpgrade.Status.RemediationPlan)-1; i++ {
 		for _, batchClusterName := range clusterGroupUpgrade.Status.RemediationPlan[i] {
+			// Clusters whose upgrade failed or that exceeded the remediation timeout of a policy are not remediated
+			// anymore. Clusters that timed out on the batch timeout are still waited for.
+			if isClusterInFinalState(clusterGroupUpgrade, batchClusterName, utils.ClusterRemediationFailed) ||
+				isClusterInFinalState(clusterGroupUpgrade, batchClusterName, utils.ClusterRemediationPolicyTimedout) {
+				continue
+			}
 			// Start with policy index 0 as we don't keep progress info from previous batches
 			nextNonCompliantPolicyIndex, isSoaking, err := r.getNextNonCompliantPolicyForCluster(ctx, clusterGroupUpgrade, batchClusterName, 0)
 			if err != nil || nextNonCompliantPolicyIndex < len(clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade) {
@@ -772,6 +1126,7 @@ func (r *ClusterGroupUpgradeReconciler) updateChildResourceNamesInStatus(ctx con
 	}
 
 	placementRuleNames := make([]string, 0)
+	placementNames := make([]string, 0)
 	placementBindingNames := make([]string, 0)
 	for _, ns := range targetNamespaces {
 		placementRules, err := r.getPlacementRules(ctx, clusterGroupUpgrade, nil, ns)
@@ -787,6 +1142,19 @@ func (r *ClusterGroupUpgradeReconciler) updateChildResourceNamesInStatus(ctx con
 		}
 		clusterGroupUpgrade.Status.PlacementRules = placementRuleNames
 
+		placements, err := r.getPlacements(ctx, clusterGroupUpgrade, ns)
+		if err != nil {
+			return err
+		}
+
+		for _, placement := range placements.Items {
+			placementNames, err = r.checkDuplicateChildResources(ctx, clusterGroupUpgrade.Status.SafeResourceNames, placementNames, &placement)
+			if err != nil {
+				return err
+			}
+		}
+		clusterGroupUpgrade.Status.Placements = placementNames
+
 		placementBindings, err := r.getPlacementBindings(ctx, clusterGroupUpgrade, ns)
 		if err != nil {
 			return err
@@ -851,7 +1219,7 @@ func (r *ClusterGroupUpgradeReconciler) rootPolicyHandlerOnUpdate(ctx context.Co
 			}
 
 			// This policy is not in this CGU, continue searching in rest of CGUs
-			if _, ok := utils.FindStringInSlice(cgu.Spec.ManagedPolicies, newPolicy.Name); !ok {
+			if !isManagedPolicy(&cgu, newPolicy.Name) {
 				continue
 			}
 
@@ -876,3 +1244,19 @@ func (r *ClusterGroupUpgradeReconciler) rootPolicyHandlerOnUpdate(ctx context.Co
 		}
 	}
 }
+
+// isManagedPolicy checks if the policy is one of the managed policies of the CGU, either listed in
+// spec.managedPolicies or expanded from spec.managedPolicySets
+func isManagedPolicy(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, policyName string) bool {
+	if _, ok := utils.FindStringInSlice(clusterGroupUpgrade.Spec.ManagedPolicies, policyName); ok {
+		return true
+	}
+	if len(clusterGroupUpgrade.Spec.ManagedPolicySets) == 0 {
+		return false
+	}
+	if _, ok := clusterGroupUpgrade.Status.ManagedPoliciesNs[policyName]; ok {
+		return true
+	}
+	_, ok := utils.FindStringInSlice(clusterGroupUpgrade.Status.ManagedPoliciesCompliantBeforeUpgrade, policyName)
+	return ok
+}
@@ -0,0 +1,340 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	policiesv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPolicy_batchPlacement(t *testing.T) {
+	placementScheme := runtime.NewScheme()
+	assert.NoError(t, clusterv1.AddToScheme(placementScheme))
+	placementScheme.AddKnownTypeWithName(utils.PlacementGroupVersionKind(), &unstructured.Unstructured{})
+	placementListGVK := utils.PlacementGroupVersionKind()
+	placementListGVK.Kind += "List"
+	placementScheme.AddKnownTypeWithName(placementListGVK, &unstructured.UnstructuredList{})
+
+	policyIndex := 0
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cgu",
+			Namespace:   "ztp-install",
+			Annotations: map[string]string{utils.NameSuffixAnnotation: "kuttl"},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			SafeResourceNames:         map[string]string{},
+			ManagedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{{Name: "policy1", Namespace: "default"}},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+					"spoke1": {State: ranv1alpha1.InProgress, PolicyIndex: &policyIndex},
+					"spoke2": {State: ranv1alpha1.Completed},
+				},
+			},
+		},
+	}
+	policy := &unstructured.Unstructured{}
+	policy.SetName("policy1")
+	policy.SetNamespace("default")
+
+	r := &ClusterGroupUpgradeReconciler{
+		Client: fake.NewClientBuilder().WithScheme(placementScheme).WithObjects(
+			&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "spoke1"}},
+			&clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "spoke2"}},
+		).Build(),
+		Log:             logr.Discard(),
+		Scheme:          placementScheme,
+		UsePlacementAPI: true,
+	}
+
+	placementName, err := r.ensureBatchPlacement(context.TODO(), cgu, policy)
+	assert.NoError(t, err)
+	assert.Equal(t, "cgu-policy1-placement-kuttl", placementName)
+	pb := r.newBatchPlacementBinding(cgu, "policy1", "default", placementName, placementName, placementName)
+	assert.Equal(t, "Placement", pb.Object["placementRef"].(map[string]interface{})["kind"])
+	assert.Equal(t, "cluster.open-cluster-management.io", pb.Object["placementRef"].(map[string]interface{})["apiGroup"])
+
+	assert.NoError(t, r.updateChildResourceNamesInStatus(context.TODO(), cgu))
+	assert.Equal(t, []string{placementName}, cgu.Status.Placements)
+
+	// Only the in progress cluster is labeled for the placement
+	assert.NoError(t, r.updatePlacementRules(context.TODO(), cgu))
+	label := utils.GetPlacementClusterLabel("default", placementName)
+	spoke1 := &clusterv1.ManagedCluster{}
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "spoke1"}, spoke1))
+	assert.Contains(t, spoke1.Labels, label)
+	spoke2 := &clusterv1.ManagedCluster{}
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "spoke2"}, spoke2))
+	assert.NotContains(t, spoke2.Labels, label)
+
+	// A cluster timing out on the policy is removed from its placement
+	assert.NoError(t, r.removeClusterFromPolicyPlacement(context.TODO(), cgu, "spoke1"))
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "spoke1"}, spoke1))
+	assert.NotContains(t, spoke1.Labels, label)
+	assert.NoError(t, r.updatePlacementRules(context.TODO(), cgu))
+
+	assert.NoError(t, r.cleanupPlacementRules(context.TODO(), cgu))
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "spoke1"}, spoke1))
+	assert.NotContains(t, spoke1.Labels, label)
+
+	// Deleting the placements removes them and their cluster labels
+	assert.NoError(t, r.updatePlacementRules(context.TODO(), cgu))
+	labels := map[string]string{
+		"openshift-cluster-group-upgrades/clusterGroupUpgrade":          cgu.Name,
+		"openshift-cluster-group-upgrades/clusterGroupUpgradeNamespace": cgu.Namespace,
+	}
+	assert.NoError(t, utils.DeletePlacementRules(context.TODO(), r.Client, "default", labels))
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "spoke1"}, spoke1))
+	assert.NotContains(t, spoke1.Labels, label)
+	placements, err := utils.ListPlacements(context.TODO(), r.Client, "default", labels)
+	assert.NoError(t, err)
+	assert.Empty(t, placements.Items)
+}
+
+func TestPolicy_expandManagedPolicySets(t *testing.T) {
+	policySetScheme := runtime.NewScheme()
+	assert.NoError(t, policiesv1.AddToScheme(policySetScheme))
+	policySetScheme.AddKnownTypeWithName(utils.PolicySetGroupVersionKind(), &unstructured.Unstructured{})
+	policySetListGVK := utils.PolicySetGroupVersionKind()
+	policySetListGVK.Kind += "List"
+	policySetScheme.AddKnownTypeWithName(policySetListGVK, &unstructured.UnstructuredList{})
+
+	newPolicySet := func(name, namespace string, policies ...interface{}) *unstructured.Unstructured {
+		policySet := &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{"policies": policies},
+		}}
+		policySet.SetGroupVersionKind(utils.PolicySetGroupVersionKind())
+		policySet.SetName(name)
+		policySet.SetNamespace(namespace)
+		return policySet
+	}
+	newPolicy := func(name, wave string) *policiesv1.Policy {
+		policy := &policiesv1.Policy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ztp-site"}}
+		if wave != "" {
+			policy.Annotations = map[string]string{ztpDeployWaveAnnotation: wave}
+		}
+		return policy
+	}
+
+	// The API server serves the metadata.name field selector, the fake client needs an index for it
+	indexedPolicySet := &unstructured.Unstructured{}
+	indexedPolicySet.SetGroupVersionKind(utils.PolicySetGroupVersionKind())
+	c := fake.NewClientBuilder().WithScheme(policySetScheme).WithIndex(indexedPolicySet, "metadata.name",
+		func(obj client.Object) []string { return []string{obj.GetName()} },
+	).WithObjects(
+		newPolicySet("site-profile", "ztp-site", "config", "operators", "no-wave", "upgrade"),
+		newPolicySet("extra", "ztp-site", "operators", "subscriptions"),
+		newPolicySet("duplicated", "ztp-site"),
+		newPolicySet("duplicated", "ztp-other"),
+		newPolicy("config", "10"),
+		newPolicy("operators", "2"),
+		newPolicy("no-wave", ""),
+		newPolicy("upgrade", "100"),
+		newPolicy("subscriptions", "2"),
+	).Build()
+	r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: policySetScheme}
+
+	testcases := []struct {
+		name               string
+		managedPolicies    []string
+		managedPolicySets  []string
+		expected           []string
+		missingPolicySets  []string
+		duplicatedPolicies map[string][]string
+	}{
+		{
+			name:            "no policy sets",
+			managedPolicies: []string{"b", "a"},
+			expected:        []string{"b", "a"},
+		},
+		{
+			name:              "policy set members sorted by wave",
+			managedPolicySets: []string{"site-profile", "extra"},
+			expected:          []string{"operators", "subscriptions", "config", "upgrade", "no-wave"},
+		},
+		{
+			name:              "managed policies come first and are not repeated",
+			managedPolicies:   []string{"upgrade"},
+			managedPolicySets: []string{"site-profile"},
+			expected:          []string{"upgrade", "operators", "config", "no-wave"},
+		},
+		{
+			name:              "missing and duplicated policy sets",
+			managedPolicySets: []string{"missing", "duplicated"},
+			expected:          []string{},
+			missingPolicySets: []string{"missing"},
+			duplicatedPolicies: map[string][]string{
+				"duplicated": {"ztp-other", "ztp-site"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+				ManagedPolicies:   tc.managedPolicies,
+				ManagedPolicySets: tc.managedPolicySets,
+			}}
+			info := policiesInfo{}
+			names, err := r.expandManagedPolicySets(context.TODO(), cgu, &info)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, names)
+			assert.Equal(t, tc.missingPolicySets, info.missingPolicySets)
+			assert.Equal(t, tc.duplicatedPolicies, info.duplicatedPolicySetsNs)
+		})
+	}
+}
+
+func TestPolicy_getNextNonCompliantPolicyForCluster_remediationTimeout(t *testing.T) {
+	newPolicy := func(name string, annotations map[string]string, compliance policiesv1.ComplianceState) *policiesv1.Policy {
+		return &policiesv1.Policy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Annotations: annotations},
+			Status: policiesv1.PolicyStatus{Status: []*policiesv1.CompliancePerClusterStatus{
+				{ClusterName: "spoke1", ComplianceState: compliance},
+			}},
+		}
+	}
+	c, _ := getFakeClientFromObjects(
+		newPolicy("subscriptions", nil, policiesv1.Compliant),
+		newPolicy("upgrade", map[string]string{utils.RemediationTimeoutAnnotation: "600"}, policiesv1.NonCompliant),
+	)
+	r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: testscheme}
+
+	policyIndex := 0
+	clusterProgress := &ranv1alpha1.ClusterRemediationProgress{State: ranv1alpha1.InProgress, PolicyIndex: &policyIndex}
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			ManagedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{
+				{Name: "subscriptions", Namespace: "default"},
+				{Name: "upgrade", Namespace: "default"},
+			},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{"spoke1": clusterProgress},
+			},
+		},
+	}
+
+	// Moving to a new policy starts its remediation timer
+	index, _, err := r.getNextNonCompliantPolicyForCluster(context.TODO(), cgu, "spoke1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+	assert.False(t, clusterProgress.PolicyStartedAt.IsZero())
+	assert.Equal(t, ranv1alpha1.InProgress, clusterProgress.State)
+
+	// The timer keeps running while the cluster stays on the policy
+	clusterProgress.PolicyStartedAt = metav1.NewTime(time.Now().Add(-601 * time.Second))
+	index, _, err = r.getNextNonCompliantPolicyForCluster(context.TODO(), cgu, "spoke1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+	assert.Equal(t, ranv1alpha1.TimedOut, clusterProgress.State)
+}
+
+func TestPolicy_arePreviousBatchesCompleteForPolicies(t *testing.T) {
+	policy := &policiesv1.Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "upgrade", Namespace: "default"},
+		Status: policiesv1.PolicyStatus{Status: []*policiesv1.CompliancePerClusterStatus{
+			{ClusterName: "spoke1", ComplianceState: policiesv1.Compliant},
+			{ClusterName: "spoke2", ComplianceState: policiesv1.NonCompliant},
+			{ClusterName: "spoke3", ComplianceState: policiesv1.NonCompliant},
+			{ClusterName: "spoke4", ComplianceState: policiesv1.NonCompliant},
+		}},
+	}
+	c, _ := getFakeClientFromObjects(policy)
+	r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: testscheme}
+
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			ManagedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{{Name: "upgrade", Namespace: "default"}},
+			RemediationPlan:           [][]string{{"spoke1", "spoke2"}, {"spoke3"}, {"spoke4"}},
+			Clusters: []ranv1alpha1.ClusterState{
+				{Name: "spoke1", State: utils.ClusterRemediationComplete},
+				{Name: "spoke2", State: utils.ClusterRemediationFailed},
+			},
+		},
+	}
+
+	// spoke3 is still non compliant
+	isComplete, _, err := r.arePreviousBatchesCompleteForPolicies(context.TODO(), cgu)
+	assert.NoError(t, err)
+	assert.False(t, isComplete)
+
+	// A cluster that timed out on the batch timeout is still waited for
+	cgu.Status.Clusters = append(cgu.Status.Clusters, ranv1alpha1.ClusterState{Name: "spoke3", State: utils.ClusterRemediationTimedout})
+	isComplete, _, err = r.arePreviousBatchesCompleteForPolicies(context.TODO(), cgu)
+	assert.NoError(t, err)
+	assert.False(t, isComplete)
+	assert.False(t, hasPolicyTimedOutClusters(cgu))
+
+	// The failed clusters and the clusters that exceeded the remediation timeout of a policy are not waited for
+	cgu.Status.Clusters[2].State = utils.ClusterRemediationPolicyTimedout
+	isComplete, _, err = r.arePreviousBatchesCompleteForPolicies(context.TODO(), cgu)
+	assert.NoError(t, err)
+	assert.True(t, isComplete)
+	assert.True(t, hasPolicyTimedOutClusters(cgu))
+}
+
+func TestPolicy_getNextNonCompliantPolicyForCluster_upgradeChain(t *testing.T) {
+	policy := &policiesv1.Policy{
+		ObjectMeta: metav1.ObjectMeta{Name: "subscriptions", Namespace: "default"},
+		Status: policiesv1.PolicyStatus{Status: []*policiesv1.CompliancePerClusterStatus{
+			{ClusterName: "spoke1", ComplianceState: policiesv1.Compliant},
+		}},
+	}
+	c, _ := getFakeClientFromObjects(policy)
+	r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: testscheme}
+
+	policyIndex := 0
+	clusterProgress := &ranv1alpha1.ClusterRemediationProgress{
+		State:       ranv1alpha1.InProgress,
+		PolicyIndex: &policyIndex,
+		Operators: []ranv1alpha1.OperatorUpgradeProgress{{
+			Subscription: "ptp",
+			Namespace:    "openshift-ptp",
+			InstalledCSV: "ptp-operator.v4.14.1",
+			ExpectedCSV:  "ptp-operator.v4.14.2",
+		}},
+	}
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			ManagedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{{Name: "subscriptions", Namespace: "default"}},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{"spoke1": clusterProgress},
+			},
+		},
+	}
+
+	// The compliant cluster stays on the policy while the expected CSV is not installed
+	index, _, err := r.getNextNonCompliantPolicyForCluster(context.TODO(), cgu, "spoke1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, index)
+
+	// A refused InstallPlan won't get to the expected CSV
+	clusterProgress.Operators[0].InstallPlanRefusal = "refused"
+	index, _, err = r.getNextNonCompliantPolicyForCluster(context.TODO(), cgu, "spoke1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	clusterProgress.Operators[0].InstallPlanRefusal = ""
+	clusterProgress.Operators[0].InstalledCSV = "ptp-operator.v4.14.2"
+	index, _, err = r.getNextNonCompliantPolicyForCluster(context.TODO(), cgu, "spoke1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+
+	// A CSV newer than the expected one is already past the upgrade chain
+	clusterProgress.Operators[0].InstalledCSV = "ptp-operator.v4.14.3"
+	index, _, err = r.getNextNonCompliantPolicyForCluster(context.TODO(), cgu, "spoke1", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, index)
+}
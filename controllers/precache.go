@@ -2,12 +2,9 @@ package controllers
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io"
 	"math"
-	"net/http"
 	"os"
 	"strconv"
 
@@ -21,13 +18,15 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	mwv1 "open-cluster-management.io/api/work/v1"
 )
 
 // reconcilePrecaching provides the main precaching entry point
 // returns: 			error
 func (r *ClusterGroupUpgradeReconciler) reconcilePrecaching(
 	ctx context.Context,
-	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusters []string, policies []*unstructured.Unstructured) error {
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusters []string, policies []*unstructured.Unstructured,
+	manifests []mwv1.Manifest) error {
 
 	if clusterGroupUpgrade.Spec.PreCaching && len(clusters) > 0 {
 		// Pre-caching is required
@@ -54,7 +53,7 @@ func (r *ClusterGroupUpgradeReconciler) reconcilePrecaching(
 			return nil
 		}
 		// Precaching is required and not marked as done
-		return r.precachingFsm(ctx, clusterGroupUpgrade, clusters, policies)
+		return r.precachingFsm(ctx, clusterGroupUpgrade, clusters, policies, manifests)
 	}
 	// No precaching required
 	return nil
@@ -65,50 +64,18 @@ func (r *ClusterGroupUpgradeReconciler) reconcilePrecaching(
 // Connecting to the upstream URL with the channel passed as a parameter
 // the update graph is returned as JSON. This function then traverses
 // the nodes list from that JSON to find the version and if found
-// then returns the image
+// then returns the image. The update graph is cached per upstream and channel.
 func (r *ClusterGroupUpgradeReconciler) getImageForVersionFromUpdateGraph(
 	upstream string, channel string, version string) (string, error) {
-	updateGraphURL := upstream + "?channel=" + channel
 
-	insecureSkipVerify := os.Getenv("INSECURE_GRAPH_CALL") == "true"
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecureSkipVerify},
-		Proxy:           http.ProxyFromEnvironment,
-	}
-	client := &http.Client{Transport: tr}
-	req, _ := http.NewRequest("GET", updateGraphURL, nil)
-	req.Header.Add("Accept", "application/json")
-	res, err := client.Do(req)
-
-	if err != nil {
-		return "", fmt.Errorf("unable to request update graph on url %s: %w", updateGraphURL, err)
-	}
-	if res.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("error response from update graph url %s: %d", updateGraphURL, res.StatusCode)
-	}
-
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil && len(body) > 0 {
-		return "", fmt.Errorf("unable to read body from response: %w", err)
-	}
-
-	var graph map[string]interface{}
-	err = json.Unmarshal(body, &graph)
+	payloads, err := r.getUpdateGraphPayloads(upstream, channel)
 	if err != nil {
-		return "", fmt.Errorf("unable to unmarshal body: %w", err)
+		return "", err
 	}
-
-	if nodes, ok := graph["nodes"]; ok {
-		for _, n := range nodes.([]interface{}) {
-			node := n.(map[string]interface{})
-			if node["version"] == version && node["payload"] != "" {
-				return node["payload"].(string), nil
-			}
-		}
+	if payload, ok := payloads[version]; ok {
+		return payload, nil
 	}
-	return "", fmt.Errorf("unable to find version %s on update graph on url %s", version, updateGraphURL)
+	return "", fmt.Errorf("unable to find version %s on update graph on url %s", version, upstream+"?channel="+channel)
 }
 
 // extractPrecachingSpecFromPolicies extracts the software spec to be pre-cached
@@ -124,43 +91,154 @@ func (r *ClusterGroupUpgradeReconciler) getImageForVersionFromUpdateGraph(
 func (r *ClusterGroupUpgradeReconciler) extractPrecachingSpecFromPolicies(
 	policies []*unstructured.Unstructured) (ranv1alpha1.PrecachingSpec, error) {
 
-	var spec ranv1alpha1.PrecachingSpec
-	for _, policy := range policies {
-		objects, err := stripPolicy(policy.Object)
-		if err != nil {
-			return spec, err
+	objects, err := stripPolicies(policies)
+	if err != nil {
+		return *new(ranv1alpha1.PrecachingSpec), err
+	}
+	return r.extractPrecachingSpecFromObjects(objects)
+}
+
+// extractPrecachingSpecFromManifests extracts the software spec to be pre-cached
+// from the manifests of the manifestwork templates, looking at the same object
+// types as extractPrecachingSpecFromPolicies
+//
+// returns: precachingSpec, error
+func (r *ClusterGroupUpgradeReconciler) extractPrecachingSpecFromManifests(
+	manifests []mwv1.Manifest) (ranv1alpha1.PrecachingSpec, error) {
+
+	objects, err := getManifestObjects(manifests)
+	if err != nil {
+		return *new(ranv1alpha1.PrecachingSpec), err
+	}
+	return r.extractPrecachingSpecFromObjects(objects)
+}
+
+// validateManifestsForPrecaching checks that the Subscription and CatalogSource fields pre-caching relies on
+// are not templatized, as the placeholders of the manifestwork templates are only filled per cluster
+// returns: error
+func validateManifestsForPrecaching(manifests []mwv1.Manifest) error {
+	objects, err := getManifestObjects(manifests)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		var fields [][]string
+		switch object["kind"] {
+		case utils.SubscriptionGroupVersionKind().Kind:
+			fields = [][]string{{"spec", "name"}, {"spec", "channel"}}
+		case utils.PolicyTypeCatalogSource:
+			fields = [][]string{{"spec", "image"}}
+		default:
+			continue
 		}
-		for _, object := range objects {
-			kind := object["kind"]
-			switch kind {
-			case utils.SubscriptionGroupVersionKind().Kind:
-				packChan := fmt.Sprintf("%s:%s", object["spec"].(map[string]interface{})["name"],
-					object["spec"].(map[string]interface{})["channel"])
-				spec.OperatorsPackagesAndChannels = append(spec.OperatorsPackagesAndChannels, packChan)
-				r.Log.Info("[extractPrecachingSpecFromPolicies]", "Operator package:channel", packChan)
-				continue
-			case utils.PolicyTypeCatalogSource:
-				index := fmt.Sprintf("%s", object["spec"].(map[string]interface{})["image"])
-				spec.OperatorsIndexes = append(spec.OperatorsIndexes, index)
-				r.Log.Info("[extractPrecachingSpecFromPolicies]", "CatalogSource", index)
-				continue
-			default:
-				continue
+		for _, field := range fields {
+			value, _, _ := unstructured.NestedString(object, field...)
+			if utils.ContainsTemplates(value) {
+				return fmt.Errorf("templatized %s fields not supported with precaching", object["kind"])
 			}
 		}
 	}
+	return nil
+}
+
+// extractPrecachingSpecFromObjects extracts the software spec to be pre-cached from the objects
+// returns: precachingSpec, error
+func (r *ClusterGroupUpgradeReconciler) extractPrecachingSpecFromObjects(
+	objects []map[string]interface{}) (ranv1alpha1.PrecachingSpec, error) {
+
+	var spec ranv1alpha1.PrecachingSpec
+	for _, object := range objects {
+		kind := object["kind"]
+		switch kind {
+		case utils.SubscriptionGroupVersionKind().Kind:
+			packChan := fmt.Sprintf("%s:%s", object["spec"].(map[string]interface{})["name"],
+				object["spec"].(map[string]interface{})["channel"])
+			spec.OperatorsPackagesAndChannels = append(spec.OperatorsPackagesAndChannels, packChan)
+			r.Log.Info("[extractPrecachingSpecFromObjects]", "Operator package:channel", packChan)
+			continue
+		case utils.PolicyTypeCatalogSource:
+			index := fmt.Sprintf("%s", object["spec"].(map[string]interface{})["image"])
+			spec.OperatorsIndexes = append(spec.OperatorsIndexes, index)
+			r.Log.Info("[extractPrecachingSpecFromObjects]", "CatalogSource", index)
+			continue
+		case utils.PolicyTypeImageDigestMirrorSet:
+			mirrors := getRegistryMirrors(object, "imageDigestMirrors")
+			spec.RegistryMirrors = append(spec.RegistryMirrors, mirrors...)
+			r.Log.Info("[extractPrecachingSpecFromObjects]", "ImageDigestMirrorSet", mirrors)
+			continue
+		case utils.PolicyTypeImageContentSourcePolicy:
+			mirrors := getRegistryMirrors(object, "repositoryDigestMirrors")
+			spec.RegistryMirrors = append(spec.RegistryMirrors, mirrors...)
+			r.Log.Info("[extractPrecachingSpecFromObjects]", "ImageContentSourcePolicy", mirrors)
+			continue
+		default:
+			continue
+		}
+	}
 
-	// Get the platform image spec from the policies
-	image, err := r.extractOCPImageFromPolicies(policies)
+	// Get the platform image spec from the objects
+	image, err := r.extractOCPImageFromObjects(objects)
 	if err != nil {
 		return *new(ranv1alpha1.PrecachingSpec), err
 	}
 	spec.PlatformImage = image
-	r.Log.Info("[extractPrecachingSpecFromPolicies]", "ClusterVersion image", spec.PlatformImage)
+	r.Log.Info("[extractPrecachingSpecFromObjects]", "ClusterVersion image", spec.PlatformImage)
 
 	return spec, nil
 }
 
+// getRegistryMirrors returns the source repositories and their mirrors listed under the given spec field of an
+// ImageDigestMirrorSet or ImageContentSourcePolicy
+func getRegistryMirrors(object map[string]interface{}, field string) []ranv1alpha1.RegistryMirror {
+	var registryMirrors []ranv1alpha1.RegistryMirror
+	digestMirrors, _, _ := unstructured.NestedSlice(object, "spec", field)
+	for _, digestMirror := range digestMirrors {
+		digestMirrorMap, ok := digestMirror.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		source, _, _ := unstructured.NestedString(digestMirrorMap, "source")
+		mirrors, _, _ := unstructured.NestedStringSlice(digestMirrorMap, "mirrors")
+		if source == "" || len(mirrors) == 0 {
+			continue
+		}
+		registryMirrors = append(registryMirrors, ranv1alpha1.RegistryMirror{Source: source, Mirrors: mirrors})
+	}
+	return registryMirrors
+}
+
+// stripPolicies returns the underlying objects of all the policies
+// returns: []map[string]interface{} - list of the underlying objects in the policies
+//
+//	error
+func stripPolicies(policies []*unstructured.Unstructured) ([]map[string]interface{}, error) {
+	var objects []map[string]interface{}
+	for _, policy := range policies {
+		policyObjects, err := stripPolicy(policy.Object)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, policyObjects...)
+	}
+	return objects, nil
+}
+
+// getManifestObjects decodes the manifests of the manifestwork templates
+// returns: []map[string]interface{} - list of the objects in the manifests
+//
+//	error
+func getManifestObjects(manifests []mwv1.Manifest) ([]map[string]interface{}, error) {
+	var objects []map[string]interface{}
+	for i, manifest := range manifests {
+		object := make(map[string]interface{})
+		if err := json.Unmarshal(manifest.Raw, &object); err != nil {
+			return nil, fmt.Errorf("[getManifestObjects] manifest %d is not valid: %w", i, err)
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
+
 // stripPolicy strips policy information and returns the underlying objects
 // filters objects with mustnothave compliance type
 // returns: []interface{} - list of the underlying objects in the policy
@@ -330,6 +408,10 @@ func (r *ClusterGroupUpgradeReconciler) getPrecacheSpecTemplateData(
 	rv.ExcludePrecachePatterns = spec.ExcludePrecachePatterns
 	rv.AdditionalImages = spec.AdditionalImages
 	rv.SpaceRequired = spec.SpaceRequired
+	for _, registryMirror := range spec.RegistryMirrors {
+		rv.RegistryMirrors = append(rv.RegistryMirrors,
+			registryMirror.Source+" "+strings.Join(registryMirror.Mirrors, " "))
+	}
 	return rv
 }
 
@@ -421,6 +503,12 @@ func (r *ClusterGroupUpgradeReconciler) includePreCachingConfigs(
 	}
 	rv.SpaceRequired = spaceRequired
 
+	rv.Retries = preCachingConfigSpec.Retries
+	rv.RetryBackoffSeconds = preCachingConfigSpec.RetryBackoffSeconds
+
+	// The images are pulled from the mirrors configured by the policies
+	rv.RegistryMirrors = spec.RegistryMirrors
+
 	return *rv, nil
 }
 
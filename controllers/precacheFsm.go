@@ -19,12 +19,16 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	mwv1 "open-cluster-management.io/api/work/v1"
 )
 
 // Pre-cache states
@@ -58,15 +62,22 @@ const (
 // precachingFsm implements the precaching state machine
 // returns: error
 func (r *ClusterGroupUpgradeReconciler) precachingFsm(ctx context.Context,
-	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusters []string, policies []*unstructured.Unstructured) error {
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusters []string, policies []*unstructured.Unstructured,
+	manifests []mwv1.Manifest) error {
 
 	specCondition := meta.FindStatusCondition(clusterGroupUpgrade.Status.Conditions, utils.PrecacheSpecValidCondition)
 	if specCondition == nil || specCondition.Status == metav1.ConditionFalse {
-		spec, err := r.extractPrecachingSpecFromPolicies(policies)
+		var spec ranv1alpha1.PrecachingSpec
+		var err error
+		if clusterGroupUpgrade.RolloutType() == ranv1alpha1.RolloutTypes.ManifestWork {
+			spec, err = r.extractPrecachingSpecFromManifests(manifests)
+		} else {
+			spec, err = r.extractPrecachingSpecFromPolicies(policies)
+		}
 		if err != nil {
 			return err
 		}
-		r.Log.Info("[precachingFsm]", "PrecacheSpecFromPolicies", spec)
+		r.Log.Info("[precachingFsm]", "PrecacheSpec", spec)
 		spec, err = r.includePreCachingConfigs(ctx, clusterGroupUpgrade, &spec)
 		if err != nil {
 			utils.SetStatusCondition(
@@ -79,6 +90,11 @@ func (r *ClusterGroupUpgradeReconciler) precachingFsm(ctx context.Context,
 			return nil
 		}
 		ok, msg := r.checkPreCacheSpecConsistency(spec)
+		if ok {
+			if _, err := getPrecachingConcurrency(clusterGroupUpgrade, len(clusters)); err != nil {
+				ok, msg = false, err.Error()
+			}
+		}
 		if !ok {
 			utils.SetStatusCondition(
 				&clusterGroupUpgrade.Status.Conditions,
@@ -107,6 +123,19 @@ func (r *ClusterGroupUpgradeReconciler) precachingFsm(ctx context.Context,
 		"Precaching is required and not done",
 	)
 
+	concurrency, err := getPrecachingConcurrency(clusterGroupUpgrade, len(clusters))
+	if err != nil {
+		return err
+	}
+	// Clusters between PreparingToStart and Active count against the concurrency
+	activeClusters := 0
+	for _, cluster := range clusters {
+		switch clusterGroupUpgrade.Status.Precaching.Status[cluster] {
+		case PrecacheStatePreparingToStart, PrecacheStateStarting, PrecacheStateActive:
+			activeClusters++
+		}
+	}
+
 	for _, cluster := range clusters {
 		var currentState string
 		var ok bool
@@ -121,7 +150,23 @@ func (r *ClusterGroupUpgradeReconciler) precachingFsm(ctx context.Context,
 		switch currentState {
 		// Initial State
 		case PrecacheStateNotStarted:
+			if attempts, ok := clusterGroupUpgrade.Status.Precaching.Attempts[cluster]; ok && time.Now().Before(attempts.NextAttemptAt.Time) {
+				// Wait out the backoff of the failed attempt
+				clusterGroupUpgrade.Status.Precaching.Status[cluster] = currentState
+				continue
+			}
+			if activeClusters >= concurrency {
+				// Queue the cluster until another one completes pre-caching
+				r.Log.Info("[precachingFsm] Concurrency reached, queuing", "cluster", cluster, "concurrency", concurrency)
+				clusterGroupUpgrade.Status.Precaching.Status[cluster] = currentState
+				continue
+			}
+			// Count the attempt as it starts
+			recordPrecachingAttempt(clusterGroupUpgrade, cluster)
 			nextState, err = r.handleNotStarted(ctx, cluster)
+			if err == nil && nextState != currentState {
+				activeClusters++
+			}
 
 		case PrecacheStatePreparingToStart:
 			nextState, err = r.handlePreparing(ctx, cluster)
@@ -130,7 +175,7 @@ func (r *ClusterGroupUpgradeReconciler) precachingFsm(ctx context.Context,
 			nextState, err = r.handleStarting(ctx, clusterGroupUpgrade, cluster)
 
 		case PrecacheStateActive:
-			nextState, err = r.handleActive(ctx, cluster)
+			nextState, err = r.handleActive(ctx, clusterGroupUpgrade, cluster)
 
 		// Final states that don't change for the life of the CR
 		case PrecacheStateSucceeded, PrecacheStateTimeout, PrecacheStateError:
@@ -149,6 +194,15 @@ func (r *ClusterGroupUpgradeReconciler) precachingFsm(ctx context.Context,
 				nextState = PrecacheStateError
 			}
 		}
+		// Only the failures reported by the pre-caching job are retried, starting over would delete the job of the
+		// running attempt on a reconcile error
+		if err == nil && (nextState == PrecacheStateTimeout || nextState == PrecacheStateError) {
+			failure := getPrecachingFailure(nextState, clusterGroupUpgrade.Status.Precaching.Progress[cluster])
+			if r.retryPrecaching(clusterGroupUpgrade, cluster, failure) {
+				// Start over, the cleanup of NotStarted removes the resources of the failed attempt
+				nextState = PrecacheStateNotStarted
+			}
+		}
 		clusterGroupUpgrade.Status.Precaching.Status[cluster] = nextState
 		if currentState != nextState {
 			r.Log.Info("[precachingFsm]", "previousState", currentState, "nextState", nextState, "cluster", cluster)
@@ -273,18 +327,21 @@ func (r *ClusterGroupUpgradeReconciler) handleStarting(ctx context.Context,
 		if err != nil {
 			return nextState, err
 		}
+		r.updatePrecachingProgress(ctx, clusterGroupUpgrade, cluster)
 		nextState = PrecacheStateSucceeded
 	case JobDeadline:
 		err = r.deleteDependenciesViews(ctx, cluster)
 		if err != nil {
 			return nextState, err
 		}
+		r.updatePrecachingProgress(ctx, clusterGroupUpgrade, cluster)
 		nextState = PrecacheStateTimeout
 	case JobBackoffLimitExceeded:
 		err = r.deleteDependenciesViews(ctx, cluster)
 		if err != nil {
 			return nextState, err
 		}
+		r.updatePrecachingProgress(ctx, clusterGroupUpgrade, cluster)
 		nextState = PrecacheStateError
 
 	default:
@@ -301,13 +358,14 @@ func (r *ClusterGroupUpgradeReconciler) handleStarting(ctx context.Context,
 // handleActive handles conditions in PrecacheStateActive
 // returns: error
 func (r *ClusterGroupUpgradeReconciler) handleActive(ctx context.Context,
-	cluster string) (string, error) {
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) (string, error) {
 
 	nextState, currentState := PrecacheStateActive, PrecacheStateActive
 	condition, err := r.getActiveConditions(ctx, cluster, precacheJobView[0].resourceName)
 	if err != nil {
 		return nextState, err
 	}
+	r.updatePrecachingProgress(ctx, clusterGroupUpgrade, cluster)
 	switch condition {
 	case JobDeadline:
 		err = r.deleteDependenciesViews(ctx, cluster)
@@ -343,6 +401,7 @@ func (r *ClusterGroupUpgradeReconciler) checkAllPrecachingDone(
 	// Counts for the various cluster states
 	var failedPrecacheCount int = 0
 	var progressingPrecacheCount int = 0
+	var queuedPrecacheCount int = 0
 	var successfulPrecacheCount int = 0
 
 	// Loop over all the clusters and take count of all their states
@@ -352,6 +411,9 @@ func (r *ClusterGroupUpgradeReconciler) checkAllPrecachingDone(
 			successfulPrecacheCount++
 		case PrecacheStateActive, PrecacheStateStarting, PrecacheStatePreparingToStart:
 			progressingPrecacheCount++
+		case PrecacheStateNotStarted:
+			// Queued by the pre-caching concurrency
+			queuedPrecacheCount++
 		default:
 			failedPrecacheCount++
 		}
@@ -388,12 +450,119 @@ func (r *ClusterGroupUpgradeReconciler) checkAllPrecachingDone(
 		)
 	// Clusters are still in progress
 	default:
+		message := fmt.Sprintf("Precaching in progress for %d clusters", progressingPrecacheCount)
+		if queuedPrecacheCount > 0 {
+			message += fmt.Sprintf(", %d clusters queued", queuedPrecacheCount)
+		}
 		utils.SetStatusCondition(
 			&clusterGroupUpgrade.Status.Conditions,
 			utils.ConditionTypes.PrecachingSuceeded,
 			utils.ConditionReasons.InProgress,
 			metav1.ConditionFalse,
-			fmt.Sprintf("Precaching in progress for %d clusters", progressingPrecacheCount),
+			message,
 		)
 	}
 }
+
+// getPrecachingConcurrency returns how many clusters can pre-cache at once, all of them if
+// spec.preCachingConcurrency is unset
+func getPrecachingConcurrency(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, numClusters int) (int, error) {
+	if clusterGroupUpgrade.Spec.PreCachingConcurrency == nil {
+		return numClusters, nil
+	}
+	concurrency, err := intstr.GetScaledValueFromIntOrPercent(clusterGroupUpgrade.Spec.PreCachingConcurrency, numClusters, true)
+	if err != nil {
+		return 0, fmt.Errorf("invalid preCachingConcurrency: %w", err)
+	}
+	if concurrency < 1 {
+		return 0, fmt.Errorf("invalid preCachingConcurrency: %s must amount to at least one cluster",
+			clusterGroupUpgrade.Spec.PreCachingConcurrency.String())
+	}
+	return concurrency, nil
+}
+
+// recordPrecachingAttempt counts a new pre-caching attempt of the cluster
+func recordPrecachingAttempt(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) {
+	if clusterGroupUpgrade.Status.Precaching.Attempts == nil {
+		clusterGroupUpgrade.Status.Precaching.Attempts = make(map[string]ranv1alpha1.PrecachingAttempts)
+	}
+	attempts := clusterGroupUpgrade.Status.Precaching.Attempts[cluster]
+	attempts.Count++
+	attempts.NextAttemptAt = metav1.Time{}
+	clusterGroupUpgrade.Status.Precaching.Attempts[cluster] = attempts
+	// The progress of the previous attempt no longer applies
+	delete(clusterGroupUpgrade.Status.Precaching.Progress, cluster)
+}
+
+// updatePrecachingProgress records the progress reported by the pre-caching job of the cluster
+func (r *ClusterGroupUpgradeReconciler) updatePrecachingProgress(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) {
+
+	progress, err := r.getPrecachingProgress(ctx, cluster, precacheProgressView)
+	if err != nil {
+		// The progress is informative only, don't fail the pre-caching for it
+		r.Log.Info("[precachingFsm] Failed to get the pre-caching progress", "cluster", cluster, "err", err)
+		return
+	}
+	if progress == nil {
+		return
+	}
+	if clusterGroupUpgrade.Status.Precaching.Progress == nil {
+		clusterGroupUpgrade.Status.Precaching.Progress = make(map[string]ranv1alpha1.PrecachingProgress)
+	}
+	clusterGroupUpgrade.Status.Precaching.Progress[cluster] = *progress
+}
+
+// getPrecachingFailure describes why the pre-caching job of a cluster ended in the given state, with the failure
+// reported by the job if any
+func getPrecachingFailure(state string, progress ranv1alpha1.PrecachingProgress) string {
+	failure := "pre-caching job exceeded its backoff limit"
+	if state == PrecacheStateTimeout {
+		failure = "pre-caching job exceeded its deadline"
+	}
+	if progress.Phase != "" {
+		failure += fmt.Sprintf(" in the %s phase", progress.Phase)
+	}
+	if progress.Message != "" {
+		failure += ": " + progress.Message
+	}
+	if len(progress.FailedImages) > 0 {
+		failure += fmt.Sprintf(" (failed images: %s)", strings.Join(progress.FailedImages, ", "))
+	}
+	return failure
+}
+
+// retryPrecaching records the failure of the pre-caching of a cluster and schedules its next attempt with an
+// exponential backoff while retries are left
+// returns: true if the pre-caching is retried
+func (r *ClusterGroupUpgradeReconciler) retryPrecaching(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	cluster, failure string) bool {
+
+	if clusterGroupUpgrade.Status.Precaching.Attempts == nil {
+		clusterGroupUpgrade.Status.Precaching.Attempts = make(map[string]ranv1alpha1.PrecachingAttempts)
+	}
+	attempts := clusterGroupUpgrade.Status.Precaching.Attempts[cluster]
+	attempts.LastFailure = failure
+	attempts.LastFailedAt = metav1.Now()
+	defer func() { clusterGroupUpgrade.Status.Precaching.Attempts[cluster] = attempts }()
+
+	spec := clusterGroupUpgrade.Status.Precaching.Spec
+	if spec == nil || attempts.Count > spec.Retries {
+		return false
+	}
+	backoffSeconds := spec.RetryBackoffSeconds
+	if backoffSeconds == 0 {
+		backoffSeconds = utils.DefaultPrecachingRetryBackoffSeconds
+	}
+	backoff := time.Duration(backoffSeconds) * time.Second
+	for i := 1; i < attempts.Count && backoff < utils.MaxPrecachingRetryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > utils.MaxPrecachingRetryBackoff {
+		backoff = utils.MaxPrecachingRetryBackoff
+	}
+	attempts.NextAttemptAt = metav1.NewTime(attempts.LastFailedAt.Add(backoff))
+	r.Log.Info("[precachingFsm] Retrying failed pre-caching", "cluster", cluster, "attempt", attempts.Count,
+		"failure", failure, "nextAttemptAt", attempts.NextAttemptAt)
+	return true
+}
@@ -1,8 +1,21 @@
 package controllers
 
 import (
-	"github.com/stretchr/testify/assert"
+	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 func TestPrecache_parseSpaceRequired(t *testing.T) {
@@ -120,3 +133,181 @@ func TestPrecache_parseSpaceRequired(t *testing.T) {
 		})
 	}
 }
+
+func TestPrecache_getPrecachingConcurrency(t *testing.T) {
+	testCases := []struct {
+		name                string
+		concurrency         *intstr.IntOrString
+		expectedConcurrency int
+		expectedError       bool
+	}{
+		{
+			name:                "all clusters if unset",
+			expectedConcurrency: 10,
+		},
+		{
+			name:                "number of clusters",
+			concurrency:         &intstr.IntOrString{Type: intstr.Int, IntVal: 3},
+			expectedConcurrency: 3,
+		},
+		{
+			name:                "percentage of clusters rounded up",
+			concurrency:         &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+			expectedConcurrency: 3,
+		},
+		{
+			name:          "no cluster",
+			concurrency:   &intstr.IntOrString{Type: intstr.Int, IntVal: 0},
+			expectedError: true,
+		},
+		{
+			name:          "invalid percentage",
+			concurrency:   &intstr.IntOrString{Type: intstr.String, StrVal: "half"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				Spec: ranv1alpha1.ClusterGroupUpgradeSpec{PreCachingConcurrency: tc.concurrency},
+			}
+			concurrency, err := getPrecachingConcurrency(cgu, 10)
+			if tc.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedConcurrency, concurrency)
+			}
+		})
+	}
+}
+
+func TestPrecache_checkAllPrecachingDoneWithQueuedClusters(t *testing.T) {
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Precaching: &ranv1alpha1.PrecachingStatus{
+				Status: map[string]string{
+					"spoke1": PrecacheStateSucceeded,
+					"spoke2": PrecacheStateActive,
+					"spoke3": PrecacheStateNotStarted,
+				},
+			},
+		},
+	}
+	r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+	r.checkAllPrecachingDone(cgu)
+	condition := meta.FindStatusCondition(cgu.Status.Conditions, "PrecachingSuceeded")
+	assert.NotNil(t, condition)
+	assert.Equal(t, "InProgress", condition.Reason)
+	assert.Equal(t, "Precaching in progress for 1 clusters, 1 clusters queued", condition.Message)
+}
+
+func TestPrecache_retryPrecaching(t *testing.T) {
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Precaching: &ranv1alpha1.PrecachingStatus{
+				Spec:   &ranv1alpha1.PrecachingSpec{Retries: 2, RetryBackoffSeconds: 30},
+				Status: map[string]string{"spoke1": PrecacheStateActive},
+			},
+		},
+	}
+	r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+
+	// The backoff doubles at each retry
+	for i, backoff := range []time.Duration{30 * time.Second, time.Minute} {
+		recordPrecachingAttempt(cgu, "spoke1")
+		assert.True(t, r.retryPrecaching(cgu, "spoke1", getPrecachingFailure(PrecacheStateTimeout, ranv1alpha1.PrecachingProgress{})))
+		attempts := cgu.Status.Precaching.Attempts["spoke1"]
+		assert.Equal(t, i+1, attempts.Count)
+		assert.Equal(t, "pre-caching job exceeded its deadline", attempts.LastFailure)
+		assert.Equal(t, backoff, attempts.NextAttemptAt.Sub(attempts.LastFailedAt.Time))
+	}
+
+	// No retry is left
+	recordPrecachingAttempt(cgu, "spoke1")
+	assert.True(t, cgu.Status.Precaching.Attempts["spoke1"].NextAttemptAt.Time.IsZero())
+	assert.False(t, r.retryPrecaching(cgu, "spoke1", getPrecachingFailure(PrecacheStateError, ranv1alpha1.PrecachingProgress{})))
+	attempts := cgu.Status.Precaching.Attempts["spoke1"]
+	assert.Equal(t, 3, attempts.Count)
+	assert.Equal(t, "pre-caching job exceeded its backoff limit", attempts.LastFailure)
+	assert.True(t, attempts.NextAttemptAt.IsZero())
+}
+
+func TestPrecache_getPrecachingFailure(t *testing.T) {
+	progress := ranv1alpha1.PrecachingProgress{
+		Phase:        "platform",
+		Message:      "2 images could not be pulled for platform-images",
+		FailedImages: []string{"quay.io/1", "quay.io/2"},
+	}
+	assert.Equal(t, "pre-caching job exceeded its backoff limit in the platform phase: "+
+		"2 images could not be pulled for platform-images (failed images: quay.io/1, quay.io/2)",
+		getPrecachingFailure(PrecacheStateError, progress))
+	assert.Equal(t, "pre-caching job exceeded its deadline in the platform phase: "+
+		"2 images could not be pulled for platform-images (failed images: quay.io/1, quay.io/2)",
+		getPrecachingFailure(PrecacheStateTimeout, progress))
+}
+
+func TestPrecache_precachingFsmFailedStart(t *testing.T) {
+	enable := true
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{Enable: &enable, PreCaching: true},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []metav1.Condition{{Type: utils.PrecacheSpecValidCondition, Status: metav1.ConditionTrue}},
+			Precaching: &ranv1alpha1.PrecachingStatus{
+				Spec:   &ranv1alpha1.PrecachingSpec{Retries: 1, RetryBackoffSeconds: 30},
+				Status: map[string]string{},
+			},
+		},
+	}
+	// The resources starting the pre-caching can't be created
+	r := &ClusterGroupUpgradeReconciler{
+		Client: fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(context.Context, client.WithWatch, client.Object, ...client.CreateOption) error {
+				return errors.New("create failed")
+			},
+		}).Build(),
+		Log: logr.Discard(),
+	}
+
+	// The error of the controller isn't retried, only the failures of the pre-caching job are
+	assert.NoError(t, r.precachingFsm(context.TODO(), cgu, []string{"spoke1"}, nil, nil))
+	assert.Equal(t, PrecacheStateError, cgu.Status.Precaching.Status["spoke1"])
+	assert.Equal(t, 1, cgu.Status.Precaching.Attempts["spoke1"].Count)
+	assert.Empty(t, cgu.Status.Precaching.Attempts["spoke1"].LastFailure)
+}
+
+func TestPrecache_precachingFsmActiveError(t *testing.T) {
+	enable := true
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{Enable: &enable, PreCaching: true},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []metav1.Condition{{Type: utils.PrecacheSpecValidCondition, Status: metav1.ConditionTrue}},
+			Precaching: &ranv1alpha1.PrecachingStatus{
+				Spec:     &ranv1alpha1.PrecachingSpec{Retries: 1, RetryBackoffSeconds: 30},
+				Status:   map[string]string{"spoke1": PrecacheStateActive},
+				Attempts: map[string]ranv1alpha1.PrecachingAttempts{"spoke1": {Count: 1}},
+			},
+		},
+	}
+	// The view of the running job can't be read
+	deleted := false
+	r := &ClusterGroupUpgradeReconciler{
+		Client: fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+				return errors.New("get failed")
+			},
+			Delete: func(context.Context, client.WithWatch, client.Object, ...client.DeleteOption) error {
+				deleted = true
+				return nil
+			},
+		}).Build(),
+		Log: logr.Discard(),
+	}
+
+	// The pre-caching doesn't start over, which would delete the running job
+	assert.NoError(t, r.precachingFsm(context.TODO(), cgu, []string{"spoke1"}, nil, nil))
+	assert.Equal(t, PrecacheStateError, cgu.Status.Precaching.Status["spoke1"])
+	assert.Equal(t, 1, cgu.Status.Precaching.Attempts["spoke1"].Count)
+	assert.False(t, deleted)
+}
@@ -59,6 +59,8 @@ spec:
           {{ . }} {{ end }}
         additionalImages: |{{ range .AdditionalImages }}
           {{ . }} {{ end }}
+        registryMirrors: |{{ range .RegistryMirrors }}
+          {{ . }} {{ end }}
         platform.image: {{ .PlatformImage }}
         spaceRequired: "{{ .SpaceRequired }}"
       kind: ConfigMap
@@ -313,6 +315,18 @@ spec:
     updateIntervalSeconds: {{ .ViewUpdateIntervalSec }}
 `
 
+// MngClusterViewProgressConfigMap creates mcv to monitor the progress configmap of the precaching job
+const MngClusterViewProgressConfigMap string = `
+{{ template "viewGVK"}}
+{{ template "metadata" . }}
+spec:
+  scope:
+    resource: configmap
+    name: pre-cache-progress
+    namespace: openshift-talo-pre-cache
+    updateIntervalSeconds: {{ .ViewUpdateIntervalSec }}
+`
+
 // MngClusterViewServiceAcct creates mcv to monitor serviceaccount
 const MngClusterViewServiceAcct string = `
 {{ template "viewGVK"}}
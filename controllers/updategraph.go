@@ -0,0 +1,222 @@
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// updateGraphCacheEntry holds the release payloads of the update graph of an upstream and channel, keyed by version
+type updateGraphCacheEntry struct {
+	payloads  map[string]string
+	fetchedAt time.Time
+}
+
+// updateGraphCache caches the update graph responses keyed by upstream and channel. It is shared by all the
+// reconciles since the validation and the pre-caching of every CGU resolve the same few versions.
+var updateGraphCache = struct {
+	sync.Mutex
+	entries map[string]updateGraphCacheEntry
+}{entries: make(map[string]updateGraphCacheEntry)}
+
+// getUpdateGraphCacheTTL returns how long an update graph response is cached
+func (r *ClusterGroupUpgradeReconciler) getUpdateGraphCacheTTL() time.Duration {
+	ttlSeconds := utils.DefaultUpdateGraphCacheTTLSeconds
+	if value, isSet := os.LookupEnv(utils.UpdateGraphCacheTTLSecondsEnv); isSet {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			r.Log.Info("Invalid update graph cache TTL, using the default", "value", value,
+				"env", utils.UpdateGraphCacheTTLSecondsEnv, "default", utils.DefaultUpdateGraphCacheTTLSeconds)
+		} else {
+			ttlSeconds = seconds
+		}
+	}
+	return time.Duration(ttlSeconds) * time.Second
+}
+
+// getUpdateGraphPayloads returns the release payloads of the update graph of the given upstream and channel keyed by
+// version, from the cache while the cached response is fresh
+// returns: map[string]string, error
+func (r *ClusterGroupUpgradeReconciler) getUpdateGraphPayloads(upstream, channel string) (map[string]string, error) {
+	updateGraphURL := upstream + "?channel=" + channel
+	ttl := r.getUpdateGraphCacheTTL()
+
+	updateGraphCache.Lock()
+	entry, ok := updateGraphCache.entries[updateGraphURL]
+	updateGraphCache.Unlock()
+	if ok && time.Since(entry.fetchedAt) < ttl {
+		return entry.payloads, nil
+	}
+
+	client, err := r.newUpdateGraphClient(upstream)
+	if err != nil {
+		return nil, fmt.Errorf("unable to configure the client for update graph url %s: %w", updateGraphURL, err)
+	}
+	req, _ := http.NewRequest("GET", updateGraphURL, nil)
+	req.Header.Add("Accept", "application/json")
+	res, err := client.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to request update graph on url %s: %w", updateGraphURL, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error response from update graph url %s: %d", updateGraphURL, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil && len(body) > 0 {
+		return nil, fmt.Errorf("unable to read body from response: %w", err)
+	}
+
+	var graph map[string]interface{}
+	err = json.Unmarshal(body, &graph)
+	if err != nil {
+		return nil, fmt.Errorf("unable to unmarshal body: %w", err)
+	}
+
+	payloads := make(map[string]string)
+	if nodes, ok := graph["nodes"].([]interface{}); ok {
+		for _, n := range nodes {
+			node, ok := n.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			version, _ := node["version"].(string)
+			payload, _ := node["payload"].(string)
+			if version != "" && payload != "" {
+				payloads[version] = payload
+			}
+		}
+	}
+
+	if ttl > 0 {
+		updateGraphCache.Lock()
+		updateGraphCache.entries[updateGraphURL] = updateGraphCacheEntry{payloads: payloads, fetchedAt: time.Now()}
+		updateGraphCache.Unlock()
+	}
+	return payloads, nil
+}
+
+// newUpdateGraphClient returns the http client calling the update graph of the given upstream. It trusts the CA
+// bundle ConfigMap on top of the system CAs, presents the client certificate of the upstream host if one is provided
+// and goes through the update graph proxy if one is set, the proxy environment otherwise
+// returns: *http.Client, error
+func (r *ClusterGroupUpgradeReconciler) newUpdateGraphClient(upstream string) (*http.Client, error) {
+	upstreamURL, err := url.Parse(upstream)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream: %w", err)
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: os.Getenv("INSECURE_GRAPH_CALL") == "true"}
+	if tlsConfig.RootCAs, err = r.getUpdateGraphRootCAs(); err != nil {
+		return nil, err
+	}
+	if certificate, err := getUpdateGraphClientCertificate(upstreamURL.Hostname()); err != nil {
+		return nil, err
+	} else if certificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*certificate}
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if value := os.Getenv(utils.UpdateGraphProxyEnv); value != "" {
+		proxyURL, err := url.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", utils.UpdateGraphProxyEnv, err)
+		}
+		proxy = getUpdateGraphProxy(proxyURL, os.Getenv(utils.UpdateGraphNoProxyEnv))
+	}
+
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: proxy}}, nil
+}
+
+// getUpdateGraphRootCAs returns the system CAs along with the CA bundle of the ConfigMap named by
+// UPDATE_GRAPH_CA_BUNDLE_CONFIGMAP in the operator namespace, or nil to use the system CAs only
+// returns: *x509.CertPool, error
+func (r *ClusterGroupUpgradeReconciler) getUpdateGraphRootCAs() (*x509.CertPool, error) {
+	name := os.Getenv(utils.UpdateGraphCABundleConfigMapEnv)
+	if name == "" {
+		return nil, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := r.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: os.Getenv(utils.PodNamespaceEnv)}, cm)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the update graph CA bundle: %w", err)
+	}
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if !rootCAs.AppendCertsFromPEM([]byte(cm.Data[utils.UpdateGraphCABundleKey])) {
+		return nil, fmt.Errorf("no certificate found under %s in ConfigMap %s", utils.UpdateGraphCABundleKey, name)
+	}
+	return rootCAs, nil
+}
+
+// getUpdateGraphClientCertificate loads the client certificate of the upstream host from
+// UPDATE_GRAPH_CLIENT_CERTS_DIR, if any
+// returns: *tls.Certificate, error
+func getUpdateGraphClientCertificate(host string) (*tls.Certificate, error) {
+	dir := os.Getenv(utils.UpdateGraphClientCertsDirEnv)
+	if dir == "" || host == "" {
+		return nil, nil
+	}
+
+	certFile := filepath.Join(dir, host+".crt")
+	if _, err := os.Stat(certFile); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	certificate, err := tls.LoadX509KeyPair(certFile, filepath.Join(dir, host+".key"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the client certificate of %s: %w", host, err)
+	}
+	return &certificate, nil
+}
+
+// getUpdateGraphProxy returns the proxy function sending the requests through the proxy, except for the hosts
+// matching the comma separated noProxy list. An entry matches the host itself and, when it is a domain, its
+// subdomains. * disables the proxy.
+func getUpdateGraphProxy(proxyURL *url.URL, noProxy string) func(*http.Request) (*url.URL, error) {
+	return func(req *http.Request) (*url.URL, error) {
+		host := req.URL.Hostname()
+		for _, entry := range strings.Split(noProxy, ",") {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			if entry == "*" {
+				return nil, nil
+			}
+			if _, cidr, err := net.ParseCIDR(entry); err == nil {
+				if ip := net.ParseIP(host); ip != nil && cidr.Contains(ip) {
+					return nil, nil
+				}
+				continue
+			}
+			domain := strings.TrimPrefix(entry, ".")
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return nil, nil
+			}
+		}
+		return proxyURL, nil
+	}
+}
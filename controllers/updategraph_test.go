@@ -0,0 +1,170 @@
+package controllers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const testUpdateGraph = `{"version":1,"nodes":[{"version":"4.14.2","payload":"quay.io/openshift-release-dev/ocp-release@sha256:1234"}]}`
+
+func newUpdateGraphHandler(hits *int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*hits++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(testUpdateGraph))
+	}
+}
+
+func TestUpdateGraph_cache(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(newUpdateGraphHandler(&hits))
+	defer server.Close()
+	r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+
+	// The update graph of the channel is fetched once
+	for i := 0; i < 2; i++ {
+		image, err := r.getImageForVersionFromUpdateGraph(server.URL, "stable-4.14", "4.14.2")
+		assert.NoError(t, err)
+		assert.Equal(t, "quay.io/openshift-release-dev/ocp-release@sha256:1234", image)
+	}
+	_, err := r.getImageForVersionFromUpdateGraph(server.URL, "stable-4.14", "4.14.3")
+	assert.ErrorContains(t, err, "unable to find version 4.14.3")
+	assert.Equal(t, 1, hits)
+
+	// Another channel is fetched separately
+	_, err = r.getImageForVersionFromUpdateGraph(server.URL, "fast-4.14", "4.14.2")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, hits)
+
+	// The cache is disabled with a TTL of 0
+	t.Setenv(utils.UpdateGraphCacheTTLSecondsEnv, "0")
+	_, err = r.getImageForVersionFromUpdateGraph(server.URL, "candidate-4.14", "4.14.2")
+	assert.NoError(t, err)
+	_, err = r.getImageForVersionFromUpdateGraph(server.URL, "candidate-4.14", "4.14.2")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, hits)
+}
+
+func TestUpdateGraph_caBundle(t *testing.T) {
+	t.Setenv(utils.UpdateGraphCacheTTLSecondsEnv, "0")
+	var hits int
+	server := httptest.NewTLSServer(newUpdateGraphHandler(&hits))
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	r := &ClusterGroupUpgradeReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "update-graph-ca", Namespace: "openshift-cluster-group-upgrades"},
+			Data:       map[string]string{utils.UpdateGraphCABundleKey: string(caBundle)},
+		}).Build(),
+		Log: logr.Discard(),
+	}
+
+	// The internal CA is not trusted by default
+	_, err := r.getImageForVersionFromUpdateGraph(server.URL, "stable-4.14", "4.14.2")
+	assert.ErrorContains(t, err, "unable to request update graph")
+
+	t.Setenv(utils.PodNamespaceEnv, "openshift-cluster-group-upgrades")
+	t.Setenv(utils.UpdateGraphCABundleConfigMapEnv, "update-graph-ca")
+	image, err := r.getImageForVersionFromUpdateGraph(server.URL, "stable-4.14", "4.14.2")
+	assert.NoError(t, err)
+	assert.Equal(t, "quay.io/openshift-release-dev/ocp-release@sha256:1234", image)
+
+	t.Setenv(utils.UpdateGraphCABundleConfigMapEnv, "missing")
+	_, err = r.getImageForVersionFromUpdateGraph(server.URL, "stable-4.14", "4.14.2")
+	assert.ErrorContains(t, err, "unable to get the update graph CA bundle")
+}
+
+func TestUpdateGraph_clientCertificate(t *testing.T) {
+	t.Setenv(utils.UpdateGraphCacheTTLSecondsEnv, "0")
+	t.Setenv("INSECURE_GRAPH_CALL", "true")
+	var hits int
+	server := httptest.NewUnstartedServer(newUpdateGraphHandler(&hits))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+	r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+
+	// The server requires a client certificate
+	_, err := r.getImageForVersionFromUpdateGraph(server.URL, "stable-4.14", "4.14.2")
+	assert.Error(t, err)
+
+	// Present the certificate of the server as the client certificate of its host
+	dir := t.TempDir()
+	t.Setenv(utils.UpdateGraphClientCertsDirEnv, dir)
+	certificate := server.TLS.Certificates[0]
+	key, err := x509.MarshalPKCS8PrivateKey(certificate.PrivateKey)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "127.0.0.1.crt"),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate.Certificate[0]}), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "127.0.0.1.key"),
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0o600))
+
+	image, err := r.getImageForVersionFromUpdateGraph(server.URL, "stable-4.14", "4.14.2")
+	assert.NoError(t, err)
+	assert.Equal(t, "quay.io/openshift-release-dev/ocp-release@sha256:1234", image)
+}
+
+func TestUpdateGraph_proxy(t *testing.T) {
+	t.Setenv(utils.UpdateGraphCacheTTLSecondsEnv, "0")
+	var hits int
+	proxy := httptest.NewServer(newUpdateGraphHandler(&hits))
+	defer proxy.Close()
+	r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+
+	t.Setenv(utils.UpdateGraphProxyEnv, proxy.URL)
+	image, err := r.getImageForVersionFromUpdateGraph("http://graph.example.invalid/api/upgrades_info/v1/graph", "stable-4.14", "4.14.2")
+	assert.NoError(t, err)
+	assert.Equal(t, "quay.io/openshift-release-dev/ocp-release@sha256:1234", image)
+	assert.Equal(t, 1, hits)
+
+	t.Setenv(utils.UpdateGraphNoProxyEnv, ".example.invalid")
+	_, err = r.getImageForVersionFromUpdateGraph("http://graph.example.invalid/api/upgrades_info/v1/graph", "stable-4.14", "4.14.2")
+	assert.Error(t, err)
+	assert.Equal(t, 1, hits)
+}
+
+func TestUpdateGraph_getUpdateGraphProxy(t *testing.T) {
+	proxyURL, _ := url.Parse("http://proxy.example.com:3128")
+	testcases := []struct {
+		noProxy   string
+		upstream  string
+		wantProxy bool
+	}{
+		{noProxy: "", upstream: "https://graph.example.com/graph", wantProxy: true},
+		{noProxy: "example.com", upstream: "https://graph.example.com/graph"},
+		{noProxy: ".example.com", upstream: "https://example.com/graph"},
+		{noProxy: "other.com, example.com", upstream: "https://example.com:8443/graph"},
+		{noProxy: "ample.com", upstream: "https://graph.example.com/graph", wantProxy: true},
+		{noProxy: "10.0.0.0/8", upstream: "https://10.1.2.3/graph"},
+		{noProxy: "10.0.0.0/8", upstream: "https://192.168.1.1/graph", wantProxy: true},
+		{noProxy: "*", upstream: "https://graph.example.com/graph"},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.noProxy+" "+tc.upstream, func(t *testing.T) {
+			req, _ := http.NewRequest("GET", tc.upstream, nil)
+			got, err := getUpdateGraphProxy(proxyURL, tc.noProxy)(req)
+			assert.NoError(t, err)
+			if tc.wantProxy {
+				assert.Equal(t, proxyURL, got)
+			} else {
+				assert.Nil(t, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// newUpgradeReport builds the UpgradeReport for a completed ClusterGroupUpgrade
+func newUpgradeReport(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, name string) *ranv1alpha1.UpgradeReport {
+	report := &ranv1alpha1.UpgradeReport{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: clusterGroupUpgrade.Namespace,
+			Labels: map[string]string{
+				"openshift-cluster-group-upgrades/clusterGroupUpgrade":          clusterGroupUpgrade.Name,
+				"openshift-cluster-group-upgrades/clusterGroupUpgradeNamespace": clusterGroupUpgrade.Namespace,
+			},
+		},
+		Spec: ranv1alpha1.UpgradeReportSpec{
+			ClusterGroupUpgrade: ranv1alpha1.ClusterGroupUpgradeReference{
+				Name:      clusterGroupUpgrade.Name,
+				Namespace: clusterGroupUpgrade.Namespace,
+				UID:       clusterGroupUpgrade.UID,
+			},
+			StartedAt:                             clusterGroupUpgrade.Status.Status.StartedAt,
+			CompletedAt:                           clusterGroupUpgrade.Status.Status.CompletedAt,
+			RemediationPlan:                       clusterGroupUpgrade.Status.RemediationPlan,
+			Clusters:                              clusterGroupUpgrade.Status.Clusters,
+			ManagedPolicies:                       clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade,
+			ManagedPoliciesCompliantBeforeUpgrade: clusterGroupUpgrade.Status.ManagedPoliciesCompliantBeforeUpgrade,
+			ManifestWorkTemplates:                 clusterGroupUpgrade.Spec.ManifestWorkTemplates,
+			Precaching:                            clusterGroupUpgrade.Status.Precaching,
+			Backup:                                clusterGroupUpgrade.Status.Backup,
+			Conditions:                            clusterGroupUpgrade.Status.Conditions,
+		},
+	}
+	clusterGroupUpgrade.Spec.DeepCopyInto(&report.Spec.ClusterGroupUpgradeSpec)
+	return report.DeepCopy()
+}
+
+// getUpgradeReportName returns the name of the UpgradeReport of the ClusterGroupUpgrade. It is derived from the UID
+// so that every reconcile of the same ClusterGroupUpgrade agrees on it, while a ClusterGroupUpgrade recreated with
+// the same name gets its own report.
+func getUpgradeReportName(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) string {
+	suffix := "-" + string(clusterGroupUpgrade.UID)
+	name := clusterGroupUpgrade.Name
+	if len(name)+len(suffix) > utils.MaxObjectNameLength {
+		name = name[:utils.MaxObjectNameLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+// createUpgradeReport writes the UpgradeReport of a completed ClusterGroupUpgrade if it doesn't exist yet, and records
+// its name in the status. The report is not owned by the ClusterGroupUpgrade so it is kept after the
+// ClusterGroupUpgrade is deleted, and it is never updated once created.
+// returns: error/nil
+func (r *ClusterGroupUpgradeReconciler) createUpgradeReport(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+
+	name := getUpgradeReportName(clusterGroupUpgrade)
+	existing := &ranv1alpha1.UpgradeReport{}
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: clusterGroupUpgrade.Namespace}, existing)
+	if err == nil {
+		if existing.Spec.ClusterGroupUpgrade.UID != clusterGroupUpgrade.UID {
+			return fmt.Errorf("UpgradeReport %s already exists for another CGU", name)
+		}
+		clusterGroupUpgrade.Status.UpgradeReport = name
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get UpgradeReport for CGU %s: %v", clusterGroupUpgrade.Name, err)
+	}
+
+	report := newUpgradeReport(clusterGroupUpgrade, name)
+	if err := r.Create(ctx, report); err != nil {
+		return fmt.Errorf("failed to create UpgradeReport for CGU %s: %v", clusterGroupUpgrade.Name, err)
+	}
+	r.Log.Info("[createUpgradeReport] UpgradeReport created", "name", name)
+	clusterGroupUpgrade.Status.UpgradeReport = name
+	return nil
+}
@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestUpgradeReport_createUpgradeReport(t *testing.T) {
+	reportScheme := runtime.NewScheme()
+	assert.NoError(t, ranv1alpha1.AddToScheme(reportScheme))
+
+	enable := true
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cgu",
+			Namespace:   "ztp-install",
+			UID:         types.UID("1234"),
+			Annotations: map[string]string{utils.NameSuffixAnnotation: "kuttl"},
+		},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			Enable:          &enable,
+			Clusters:        []string{"spoke1", "spoke2"},
+			ManagedPolicies: []string{"policy1"},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			RemediationPlan:           [][]string{{"spoke1", "spoke2"}},
+			ManagedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{{Name: "policy1", Namespace: "default"}},
+			Clusters: []ranv1alpha1.ClusterState{
+				{Name: "spoke1", State: utils.ClusterRemediationComplete},
+				{Name: "spoke2", State: utils.ClusterRemediationTimedout, CurrentPolicy: &ranv1alpha1.PolicyStatus{Name: "policy1", Status: utils.ClusterStatusNonCompliant}},
+			},
+			Status: ranv1alpha1.UpgradeStatus{
+				StartedAt:   metav1.Now(),
+				CompletedAt: metav1.Now(),
+			},
+		},
+	}
+
+	r := &ClusterGroupUpgradeReconciler{
+		Client: fake.NewClientBuilder().WithScheme(reportScheme).Build(),
+		Log:    logr.Discard(),
+		Scheme: reportScheme,
+	}
+
+	assert.NoError(t, r.createUpgradeReport(context.TODO(), cgu))
+	assert.Empty(t, cgu.Status.SafeResourceNames)
+	assert.Equal(t, "cgu-1234", cgu.Status.UpgradeReport)
+
+	report := &ranv1alpha1.UpgradeReport{}
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "cgu-1234", Namespace: "ztp-install"}, report))
+	assert.Empty(t, report.OwnerReferences)
+	assert.Equal(t, "cgu", report.Labels["openshift-cluster-group-upgrades/clusterGroupUpgrade"])
+	assert.Equal(t, ranv1alpha1.ClusterGroupUpgradeReference{Name: "cgu", Namespace: "ztp-install", UID: "1234"}, report.Spec.ClusterGroupUpgrade)
+	assert.Equal(t, cgu.Spec.Clusters, report.Spec.ClusterGroupUpgradeSpec.Clusters)
+	assert.Equal(t, cgu.Status.RemediationPlan, report.Spec.RemediationPlan)
+	assert.Equal(t, cgu.Status.Clusters, report.Spec.Clusters)
+	assert.Equal(t, cgu.Status.ManagedPoliciesForUpgrade, report.Spec.ManagedPolicies)
+
+	// A report is written once and never updated
+	cgu.Status.Clusters = nil
+	assert.NoError(t, r.createUpgradeReport(context.TODO(), cgu))
+	reports := &ranv1alpha1.UpgradeReportList{}
+	assert.NoError(t, r.List(context.TODO(), reports, client.InNamespace("ztp-install")))
+	assert.Len(t, reports.Items, 1)
+	assert.Len(t, reports.Items[0].Spec.Clusters, 2)
+
+	// A recreated CGU with the same name gets its own report
+	cgu.UID = types.UID("5678")
+	assert.NoError(t, r.createUpgradeReport(context.TODO(), cgu))
+	assert.NoError(t, r.List(context.TODO(), reports, client.InNamespace("ztp-install")))
+	assert.Len(t, reports.Items, 2)
+	assert.Equal(t, "cgu-5678", cgu.Status.UpgradeReport)
+}
+
+func TestUpgradeReport_reconcileCompleted(t *testing.T) {
+	reportScheme := runtime.NewScheme()
+	assert.NoError(t, ranv1alpha1.AddToScheme(reportScheme))
+
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "cgu",
+			Namespace:  "ztp-install",
+			UID:        types.UID("1234"),
+			Finalizers: []string{utils.CleanupFinalizer},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []metav1.Condition{{
+				Type:   string(utils.ConditionTypes.Succeeded),
+				Status: metav1.ConditionTrue,
+				Reason: string(utils.ConditionReasons.Completed),
+			}},
+			Status: ranv1alpha1.UpgradeStatus{CompletedAt: metav1.Now()},
+		},
+	}
+	reportGets := 0
+	r := &ClusterGroupUpgradeReconciler{
+		Client: fake.NewClientBuilder().WithScheme(reportScheme).WithObjects(cgu).WithStatusSubresource(cgu).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if _, ok := obj.(*ranv1alpha1.UpgradeReport); ok {
+						reportGets++
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+			}).Build(),
+		Log:    logr.Discard(),
+		Scheme: reportScheme,
+	}
+	key := types.NamespacedName{Name: "cgu", Namespace: "ztp-install"}
+
+	// The report missing after the completion was persisted is written and recorded in the status
+	_, err := r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, reportGets)
+	assert.NoError(t, r.Get(context.TODO(), key, cgu))
+	assert.Equal(t, "cgu-1234", cgu.Status.UpgradeReport)
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "cgu-1234", Namespace: "ztp-install"}, &ranv1alpha1.UpgradeReport{}))
+
+	// Once recorded, the report isn't looked up again
+	reportGets = 0
+	_, err = r.Reconcile(context.TODO(), ctrl.Request{NamespacedName: key})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, reportGets)
+}
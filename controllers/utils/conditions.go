@@ -33,67 +33,78 @@ type ConditionReason string
 
 // ConditionReasons define the different reasons that conditions will be set for
 var ConditionReasons = struct {
-	Completed                     ConditionReason
-	ClusterSelectionCompleted     ConditionReason
-	ValidationCompleted           ConditionReason
-	BackupCompleted               ConditionReason
-	PrecachingCompleted           ConditionReason
-	Failed                        ConditionReason
-	IncompleteBlockingCR          ConditionReason
-	InProgress                    ConditionReason
-	InvalidPlatformImage          ConditionReason
-	MissingBlockingCR             ConditionReason
-	NotAllManagedPoliciesExist    ConditionReason
-	AmbiguousManagedPoliciesNames ConditionReason
-	NotEnabled                    ConditionReason
-	NotStarted                    ConditionReason
-	ClusterNotFound               ConditionReason
-	NotPresent                    ConditionReason
-	PartiallyDone                 ConditionReason
-	PrecacheSpecIncomplete        ConditionReason
-	PrecacheSpecIsWellFormed      ConditionReason
-	TimedOut                      ConditionReason
-	UnresolvableDenpendency       ConditionReason
+	Completed                        ConditionReason
+	ClusterSelectionCompleted        ConditionReason
+	ValidationCompleted              ConditionReason
+	BackupCompleted                  ConditionReason
+	PrecachingCompleted              ConditionReason
+	Failed                           ConditionReason
+	IncompleteBlockingCR             ConditionReason
+	InProgress                       ConditionReason
+	InvalidImageBasedUpgrade         ConditionReason
+	InvalidManifestWorkTemplates     ConditionReason
+	InvalidPlatformImage             ConditionReason
+	MissingBlockingCR                ConditionReason
+	NotAllManagedPoliciesExist       ConditionReason
+	NotAllManifestWorkTemplatesExist ConditionReason
+	AmbiguousManagedPoliciesNames    ConditionReason
+	NotEnabled                       ConditionReason
+	NotStarted                       ConditionReason
+	ClusterNotFound                  ConditionReason
+	NotPresent                       ConditionReason
+	PartiallyDone                    ConditionReason
+	Paused                           ConditionReason
+	PrecacheSpecIncomplete           ConditionReason
+	PrecacheSpecIsWellFormed         ConditionReason
+	TimedOut                         ConditionReason
+	UnresolvableDenpendency          ConditionReason
 }{
-	Completed:                     "Completed",
-	ClusterSelectionCompleted:     "ClusterSelectionCompleted",
-	ValidationCompleted:           "ValidationCompleted",
-	BackupCompleted:               "BackupCompleted",
-	PrecachingCompleted:           "PrecachingCompleted",
-	Failed:                        "Failed",
-	IncompleteBlockingCR:          "IncompleteBlockingCR",
-	InProgress:                    "InProgress",
-	InvalidPlatformImage:          "InvalidPlatformImage",
-	MissingBlockingCR:             "MissingBlockingCR",
-	NotAllManagedPoliciesExist:    "NotAllManagedPoliciesExist",
-	AmbiguousManagedPoliciesNames: "AmbiguousManagedPoliciesNames",
-	NotEnabled:                    "NotEnabled",
-	NotStarted:                    "NotStarted",
-	ClusterNotFound:               "ClusterNotFound",
-	NotPresent:                    "NotPresent",
-	PartiallyDone:                 "PartiallyDone",
-	PrecacheSpecIncomplete:        "PrecacheSpecIncomplete",
-	PrecacheSpecIsWellFormed:      "PrecacheSpecIsWellFormed",
-	TimedOut:                      "TimedOut",
-	UnresolvableDenpendency:       "UnresolvableDenpendency",
+	Completed:                        "Completed",
+	ClusterSelectionCompleted:        "ClusterSelectionCompleted",
+	ValidationCompleted:              "ValidationCompleted",
+	BackupCompleted:                  "BackupCompleted",
+	PrecachingCompleted:              "PrecachingCompleted",
+	Failed:                           "Failed",
+	IncompleteBlockingCR:             "IncompleteBlockingCR",
+	InProgress:                       "InProgress",
+	InvalidImageBasedUpgrade:         "InvalidImageBasedUpgrade",
+	InvalidManifestWorkTemplates:     "InvalidManifestWorkTemplates",
+	InvalidPlatformImage:             "InvalidPlatformImage",
+	MissingBlockingCR:                "MissingBlockingCR",
+	NotAllManagedPoliciesExist:       "NotAllManagedPoliciesExist",
+	NotAllManifestWorkTemplatesExist: "NotAllManifestWorkTemplatesExist",
+	AmbiguousManagedPoliciesNames:    "AmbiguousManagedPoliciesNames",
+	NotEnabled:                       "NotEnabled",
+	NotStarted:                       "NotStarted",
+	ClusterNotFound:                  "ClusterNotFound",
+	NotPresent:                       "NotPresent",
+	PartiallyDone:                    "PartiallyDone",
+	Paused:                           "Paused",
+	PrecacheSpecIncomplete:           "PrecacheSpecIncomplete",
+	PrecacheSpecIsWellFormed:         "PrecacheSpecIsWellFormed",
+	TimedOut:                         "TimedOut",
+	UnresolvableDenpendency:          "UnresolvableDenpendency",
 }
 
 // InProgressMessages defines the in progress messages for the conditions by rollout type
 var InProgressMessages = map[ranv1alpha1.RolloutType]string{
-	ranv1alpha1.RolloutTypes.Policy:       "Remediating non-compliant policies",
-	ranv1alpha1.RolloutTypes.ManifestWork: "Rolling out manifestworks",
+	ranv1alpha1.RolloutTypes.Policy:            "Remediating non-compliant policies",
+	ranv1alpha1.RolloutTypes.ManifestWork:      "Rolling out manifestworks",
+	ranv1alpha1.RolloutTypes.ImageBasedUpgrade: "Rolling out the image based upgrade stages",
 }
 
 // TimeoutMessages defines the timeout messages for the conditions by rollout type
 var TimeoutMessages = map[ranv1alpha1.RolloutType]string{
-	ranv1alpha1.RolloutTypes.Policy:       "Policy remediation took too long",
-	ranv1alpha1.RolloutTypes.ManifestWork: "Manifestwork rollout took too long",
+	ranv1alpha1.RolloutTypes.Policy:            "Policy remediation took too long",
+	ranv1alpha1.RolloutTypes.ManifestWork:      "Manifestwork rollout took too long",
+	ranv1alpha1.RolloutTypes.ImageBasedUpgrade: "Image based upgrade took too long",
 }
 
 // CompletedMessages defines the completed messages for the conditions by rollout type
 var CompletedMessages = map[ranv1alpha1.RolloutType]string{
-	ranv1alpha1.RolloutTypes.Policy:       "All clusters are compliant with all the managed policies",
-	ranv1alpha1.RolloutTypes.ManifestWork: "All manifestworks rolled out successfully on all clusters",
+	ranv1alpha1.RolloutTypes.Policy:            "All clusters are compliant with all the managed policies",
+	ranv1alpha1.RolloutTypes.ManifestWork:      "All manifestworks rolled out successfully on all clusters",
+	ranv1alpha1.RolloutTypes.ImageBasedUpgrade: "All image based upgrade stages completed on all clusters",
 }
 
 // SetStatusCondition is a convenience wrapper for meta.SetStatusCondition that takes in the types defined here and converts them to strings
@@ -1,11 +1,36 @@
 package utils
 
-import "k8s.io/apimachinery/pkg/runtime/schema"
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
 
 // CGU controller constants
 const (
 	CGUControllerWorkerCountEnv     = "TALM_CGU_CTRL_WORKER_COUNT"
 	DefaultCGUControllerWorkerCount = 5
+	CGUTTLSecondsAfterFinishedEnv   = "TALM_CGU_TTL_SECONDS_AFTER_FINISHED"
+)
+
+// Update graph constants
+const (
+	// PodNamespaceEnv is the namespace of the operator, where the update graph CA bundle ConfigMap is looked up
+	PodNamespaceEnv = "POD_NAMESPACE"
+	// UpdateGraphCABundleConfigMapEnv names the ConfigMap holding the CA bundle trusted for the update graph
+	// calls under UpdateGraphCABundleKey, in addition to the system CAs
+	UpdateGraphCABundleConfigMapEnv = "UPDATE_GRAPH_CA_BUNDLE_CONFIGMAP"
+	UpdateGraphCABundleKey          = "ca-bundle.crt"
+	// UpdateGraphClientCertsDirEnv is the directory holding the client certificate <host>.crt and key <host>.key
+	// presented to the upstream of the given host
+	UpdateGraphClientCertsDirEnv = "UPDATE_GRAPH_CLIENT_CERTS_DIR"
+	// UpdateGraphProxyEnv and UpdateGraphNoProxyEnv override the proxy environment for the update graph calls
+	UpdateGraphProxyEnv   = "UPDATE_GRAPH_PROXY"
+	UpdateGraphNoProxyEnv = "UPDATE_GRAPH_NO_PROXY"
+	// UpdateGraphCacheTTLSecondsEnv is how long the update graph of an upstream and channel is cached, 0 disables
+	// the cache
+	UpdateGraphCacheTTLSecondsEnv     = "UPDATE_GRAPH_CACHE_TTL_SECONDS"
+	DefaultUpdateGraphCacheTTLSeconds = 300
 )
 
 // RemediationActionEnforce - Policy remediation for policies.
@@ -27,6 +52,9 @@ const (
 const (
 	ClusterRemediationComplete = "complete"
 	ClusterRemediationTimedout = "timedout"
+	ClusterRemediationFailed   = "failed"
+	// ClusterRemediationPolicyTimedout is the state of a cluster that exceeded the remediation timeout of a policy
+	ClusterRemediationPolicyTimedout = "policytimedout"
 )
 
 // Label specific to ACM child policies.
@@ -72,7 +100,9 @@ const (
 
 // Policy types used within the operator
 const (
-	PolicyTypeCatalogSource = "CatalogSource"
+	PolicyTypeCatalogSource            = "CatalogSource"
+	PolicyTypeImageDigestMirrorSet     = "ImageDigestMirrorSet"
+	PolicyTypeImageContentSourcePolicy = "ImageContentSourcePolicy"
 )
 
 // SubscriptionGroupVersionKind for monitoring and other type specific logic
@@ -104,6 +134,7 @@ const (
 	NoActionForApprovingInstallPlan = 2
 	MultiCloudPendingStatus         = 3
 	InstallPlanAlreadyApproved      = 4
+	InstallPlanUnexpectedCSV        = 5
 
 	MultiCloudWaitTimeSec = 3
 
@@ -162,6 +193,61 @@ const (
 // and other disk allocations that can happen between the job and the actual upgrade kicked off by the CGU.
 const SpaceRequiredForPrecache = "35 GiB"
 
+// DefaultPrecachingRetryBackoffSeconds is the delay before the first retry of a failed pre-caching, doubled at each
+// following retry
+const DefaultPrecachingRetryBackoffSeconds = 60
+
+// MaxPrecachingRetryBackoff caps the delay between the retries of a failed pre-caching
+const MaxPrecachingRetryBackoff = time.Hour
+
+// CSVFailedGracePeriod is how long the target ClusterServiceVersion of an operator has to stay Failed before the
+// upgrade of the cluster is considered failed, as OLM reports Failed briefly while retrying an install
+const CSVFailedGracePeriod = 5 * time.Minute
+
 // SoakAnnotation is the annotation that can be set on policies, which indicates the least number of seconds
 // which policies should be compliant before the cgu moves on from that policy
 const SoakAnnotation = "ran.openshift.io/soak-seconds"
+
+// RemediationTimeoutAnnotation is the annotation that can be set on policies, which indicates the most number of
+// seconds a cluster can take to become compliant with the policy before it is timed out
+const RemediationTimeoutAnnotation = "ran.openshift.io/remediation-timeout-seconds"
+
+// ExpectedCSVAnnotation is the annotation that can be set on a Subscription in a policy, which indicates the
+// ClusterServiceVersion its InstallPlans are expected to install. It takes precedence over spec.startingCSV
+const ExpectedCSVAnnotation = "ran.openshift.io/expected-csv"
+
+// Placement API used for the batch placements of policies
+const (
+	PlacementAPIAuto          = "auto"
+	PlacementAPIPlacementRule = "PlacementRule"
+	PlacementAPIPlacement     = "Placement"
+	// PlacementClusterLabelPrefix prefixes the ManagedCluster label selected by a batch Placement
+	PlacementClusterLabelPrefix = "openshift-cluster-group-upgrades/placement-"
+)
+
+// PolicySetGroupVersionKind is the GroupVersionKind of the PolicySet resource
+func PolicySetGroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "policy.open-cluster-management.io",
+		Kind:    "PolicySet",
+		Version: "v1beta1",
+	}
+}
+
+// PlacementRuleGroupVersionKind is the GroupVersionKind of the PlacementRule resource
+func PlacementRuleGroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "apps.open-cluster-management.io",
+		Kind:    "PlacementRule",
+		Version: "v1",
+	}
+}
+
+// PlacementGroupVersionKind is the GroupVersionKind of the Placement resource
+func PlacementGroupVersionKind() schema.GroupVersionKind {
+	return schema.GroupVersionKind{
+		Group:   "cluster.open-cluster-management.io",
+		Kind:    "Placement",
+		Version: "v1beta1",
+	}
+}
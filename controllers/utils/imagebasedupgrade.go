@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	mwv1 "open-cluster-management.io/api/work/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ImageBasedUpgrade of the lifecycle-agent, a singleton on the spoke
+const (
+	imageBasedUpgradeGroup    = "lca.openshift.io"
+	imageBasedUpgradeVersion  = "v1"
+	imageBasedUpgradeKind     = "ImageBasedUpgrade"
+	imageBasedUpgradeResource = "imagebasedupgrades"
+	imageBasedUpgradeName     = "upgrade"
+)
+
+// imageBasedUpgradeStageFeedback is the name of the feedback value reporting the stage applied on the spoke
+const imageBasedUpgradeStageFeedback = "stage"
+
+// imageBasedUpgradeStageConditions maps the stages to the ImageBasedUpgrade condition reporting their completion
+var imageBasedUpgradeStageConditions = map[ranv1alpha1.ImageBasedUpgradeStage]string{
+	ranv1alpha1.ImageBasedUpgradeStages.Prep:     "PrepCompleted",
+	ranv1alpha1.ImageBasedUpgradeStages.Upgrade:  "UpgradeCompleted",
+	ranv1alpha1.ImageBasedUpgradeStages.Rollback: "RollbackCompleted",
+	ranv1alpha1.ImageBasedUpgradeStages.Idle:     "Idle",
+}
+
+// imageBasedUpgradeNextStages defines the stages the ImageBasedUpgrade can move to from each stage
+var imageBasedUpgradeNextStages = map[ranv1alpha1.ImageBasedUpgradeStage][]ranv1alpha1.ImageBasedUpgradeStage{
+	ranv1alpha1.ImageBasedUpgradeStages.Idle:     {ranv1alpha1.ImageBasedUpgradeStages.Prep},
+	ranv1alpha1.ImageBasedUpgradeStages.Prep:     {ranv1alpha1.ImageBasedUpgradeStages.Upgrade, ranv1alpha1.ImageBasedUpgradeStages.Idle},
+	ranv1alpha1.ImageBasedUpgradeStages.Upgrade:  {ranv1alpha1.ImageBasedUpgradeStages.Idle, ranv1alpha1.ImageBasedUpgradeStages.Rollback},
+	ranv1alpha1.ImageBasedUpgradeStages.Rollback: {ranv1alpha1.ImageBasedUpgradeStages.Idle},
+}
+
+// ImageBasedUpgradeStageStatus is the status of a stage of the ImageBasedUpgrade synced back from the spoke
+type ImageBasedUpgradeStageStatus struct {
+	Completed bool
+	Failed    bool
+	Message   string
+}
+
+func canFollowImageBasedUpgradeStage(stage, nextStage ranv1alpha1.ImageBasedUpgradeStage) bool {
+	for _, allowed := range imageBasedUpgradeNextStages[stage] {
+		if allowed == nextStage {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateImageBasedUpgradeStages checks that each stage can follow the previous one
+func ValidateImageBasedUpgradeStages(stages []ranv1alpha1.ImageBasedUpgradeStage) error {
+	for i := 1; i < len(stages); i++ {
+		if !canFollowImageBasedUpgradeStage(stages[i-1], stages[i]) {
+			return fmt.Errorf("stage %s can't follow stage %s", stages[i], stages[i-1])
+		}
+	}
+	return nil
+}
+
+func getImageBasedUpgradeManifestWorkName(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) string {
+	return GetSafeResourceName(clusterGroupUpgrade.Namespace+"."+clusterGroupUpgrade.Name+"-ibu", "", clusterGroupUpgrade, MaxObjectNameLength)
+}
+
+// newImageBasedUpgradeManifestWorkSpec returns the manifestwork spec applying the ImageBasedUpgrade with the given stage
+// and syncing back its stage conditions
+func newImageBasedUpgradeManifestWorkSpec(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	stage ranv1alpha1.ImageBasedUpgradeStage) (*mwv1.ManifestWorkSpec, error) {
+	seedImageRef := map[string]interface{}{
+		"image":   clusterGroupUpgrade.Spec.ImageBasedUpgrade.SeedImageRef.Image,
+		"version": clusterGroupUpgrade.Spec.ImageBasedUpgrade.SeedImageRef.Version,
+	}
+	if clusterGroupUpgrade.Spec.ImageBasedUpgrade.SeedImageRef.PullSecretRef != nil {
+		seedImageRef["pullSecretRef"] = map[string]interface{}{
+			"name": clusterGroupUpgrade.Spec.ImageBasedUpgrade.SeedImageRef.PullSecretRef.Name,
+		}
+	}
+	raw, err := json.Marshal(map[string]interface{}{
+		"apiVersion": imageBasedUpgradeGroup + "/" + imageBasedUpgradeVersion,
+		"kind":       imageBasedUpgradeKind,
+		"metadata":   map[string]interface{}{"name": imageBasedUpgradeName},
+		"spec": map[string]interface{}{
+			"stage":        string(stage),
+			"seedImageRef": seedImageRef,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jsonPaths := []mwv1.JsonPath{{Name: imageBasedUpgradeStageFeedback, Path: ".spec.stage"}}
+	for _, stage := range []ranv1alpha1.ImageBasedUpgradeStage{
+		ranv1alpha1.ImageBasedUpgradeStages.Prep, ranv1alpha1.ImageBasedUpgradeStages.Upgrade,
+		ranv1alpha1.ImageBasedUpgradeStages.Rollback, ranv1alpha1.ImageBasedUpgradeStages.Idle} {
+		condition := imageBasedUpgradeStageConditions[stage]
+		for _, field := range []string{"status", "reason", "message"} {
+			jsonPaths = append(jsonPaths, mwv1.JsonPath{
+				Name: condition + "." + field,
+				Path: fmt.Sprintf(`.status.conditions[?(@.type=="%s")].%s`, condition, field),
+			})
+		}
+	}
+
+	return &mwv1.ManifestWorkSpec{
+		Workload: mwv1.ManifestsTemplate{Manifests: []mwv1.Manifest{{RawExtension: runtime.RawExtension{Raw: raw}}}},
+		// The ImageBasedUpgrade is left on the spoke once the rollout completes
+		DeleteOption: &mwv1.DeleteOption{PropagationPolicy: mwv1.DeletePropagationPolicyTypeOrphan},
+		ManifestConfigs: []mwv1.ManifestConfigOption{{
+			ResourceIdentifier: mwv1.ResourceIdentifier{
+				Group:    imageBasedUpgradeGroup,
+				Resource: imageBasedUpgradeResource,
+				Name:     imageBasedUpgradeName,
+			},
+			FeedbackRules: []mwv1.FeedbackRule{{Type: mwv1.JSONPathsType, JsonPaths: jsonPaths}},
+		}},
+	}, nil
+}
+
+// GetImageBasedUpgradeManifestWorkForCluster returns the manifestwork of the ImageBasedUpgrade of the given spoke
+func GetImageBasedUpgradeManifestWorkForCluster(ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	clusterName string) (*mwv1.ManifestWork, error) {
+	mw := &mwv1.ManifestWork{}
+	err := c.Get(ctx, types.NamespacedName{Name: getImageBasedUpgradeManifestWorkName(clusterGroupUpgrade), Namespace: clusterName}, mw)
+	return mw, err
+}
+
+// ApplyImageBasedUpgradeStageForCluster creates or updates the manifestwork applying the given stage to the
+// ImageBasedUpgrade of the spoke
+func ApplyImageBasedUpgradeStageForCluster(ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	clusterName string, stage ranv1alpha1.ImageBasedUpgradeStage) error {
+	spec, err := newImageBasedUpgradeManifestWorkSpec(clusterGroupUpgrade, stage)
+	if err != nil {
+		return err
+	}
+
+	mw, err := GetImageBasedUpgradeManifestWorkForCluster(ctx, c, clusterGroupUpgrade, clusterName)
+	if errors.IsNotFound(err) {
+		mw = &mwv1.ManifestWork{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      getImageBasedUpgradeManifestWorkName(clusterGroupUpgrade),
+				Namespace: clusterName,
+				Labels: map[string]string{
+					"openshift-cluster-group-upgrades/clusterGroupUpgrade":          clusterGroupUpgrade.Name,
+					"openshift-cluster-group-upgrades/clusterGroupUpgradeNamespace": clusterGroupUpgrade.Namespace,
+				},
+			},
+			Spec: *spec,
+		}
+		return c.Create(ctx, mw)
+	}
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(mw.Spec, *spec) {
+		return nil
+	}
+	mw.Spec = *spec
+	return c.Update(ctx, mw)
+}
+
+// GetImageBasedUpgradeStageStatus returns the status of the stage synced back from the spoke. The stage is neither
+// completed nor failed until the spoke reports it applied it.
+func GetImageBasedUpgradeStageStatus(mw *mwv1.ManifestWork, stage ranv1alpha1.ImageBasedUpgradeStage) ImageBasedUpgradeStageStatus {
+	mc := getManifestCondition(mw, 0)
+	if mc == nil || !isManifestConditionReady(mc) {
+		return ImageBasedUpgradeStageStatus{}
+	}
+	getValue := func(name string) string {
+		fieldValue := getFieldValue(mc, name)
+		if fieldValue == nil {
+			return ""
+		}
+		return formatFieldValue(fieldValue)
+	}
+	if getValue(imageBasedUpgradeStageFeedback) != string(stage) {
+		return ImageBasedUpgradeStageStatus{}
+	}
+
+	condition := imageBasedUpgradeStageConditions[stage]
+	status := getValue(condition + ".status")
+	return ImageBasedUpgradeStageStatus{
+		Completed: status == string(v1.ConditionTrue),
+		Failed:    status == string(v1.ConditionFalse) && getValue(condition+".reason") == "Failed",
+		Message:   getValue(condition + ".message"),
+	}
+}
@@ -1,14 +1,30 @@
 package utils
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
 	"strconv"
+	"strings"
+	"text/template"
 
+	"github.com/blang/semver/v4"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/utils/strings/slices"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	mwv1 "open-cluster-management.io/api/work/v1"
 	mwv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -16,18 +32,75 @@ import (
 
 const manifestWorkExpectedValuesAnnotation = "openshift-cluster-group-upgrades/expectedValues"
 
+// manifestWorkKeepInPlaceLabel marks the manifestworks of the stages kept in place once completed
+const manifestWorkKeepInPlaceLabel = "openshift-cluster-group-upgrades/keepInPlace"
+
+// manifestWorkValuesConfigMapAnnotation names the ConfigMap of the cluster namespace holding the values of the cluster
+const manifestWorkValuesConfigMapAnnotation = "openshift-cluster-group-upgrades/valuesConfigMap"
+
+// The placeholders in the string values of the manifests are delimited like the hub templates of the policies
+const (
+	manifestWorkPlaceholderLeftDelim  = "{{cgu"
+	manifestWorkPlaceholderRightDelim = "cgu}}"
+)
+
+// ManifestWorkTemplateError is returned when the placeholders of a manifestwork template can't be filled for a cluster
+type ManifestWorkTemplateError struct {
+	Template string
+	Err      error
+}
+
+func (e *ManifestWorkTemplateError) Error() string {
+	return fmt.Sprintf("failed to fill the placeholders of manifestwork template %s: %v", e.Template, e.Err)
+}
+
+func (e *ManifestWorkTemplateError) Unwrap() error {
+	return e.Err
+}
+
+// manifestWorkClusterValues holds the values of a cluster available to the placeholders of the manifestwork templates
+type manifestWorkClusterValues struct {
+	ClusterName   string
+	labels        map[string]string
+	annotations   map[string]string
+	clusterClaims map[string]string
+	values        map[string]string
+}
+
 // This type is not exposed by mwv1 unfortunately, copied from:
 // https://github.com/open-cluster-management-io/work/blob/81fc808f78ce4dafa9c24f979af4e33078df48b6/pkg/spoke/controllers/statuscontroller/availablestatus_controller.go#L30
 const statusFeedbackConditionType = "StatusFeedbackSynced"
 
-// ManifestWorkExpectedValues defines the expected values for
-// the fields synced back from the spoke through feedback rules.
-type ManifestWorkExpectedValues []struct {
+// Operators comparing the fields synced back from the spoke to the expected values
+const (
+	expectedValueOperatorEquals               = "Equals"
+	expectedValueOperatorNotEquals            = "NotEquals"
+	expectedValueOperatorRegex                = "Regex"
+	expectedValueOperatorGreaterOrEqual       = "GreaterOrEqual"
+	expectedValueOperatorLessOrEqual          = "LessOrEqual"
+	expectedValueOperatorSemverGreaterOrEqual = "SemverGreaterOrEqual"
+	expectedValueOperatorAnyOf                = "AnyOf"
+)
+
+// ManifestWorkExpectedValue defines the expected value for
+// a field synced back from the spoke through a feedback rule.
+type ManifestWorkExpectedValue struct {
 	ManifestIndex int32  `json:"manifestIndex,omitempty"`
 	Name          string `json:"name,omitempty"`
-	Value         string `json:"value,omitempty"`
+	// JSONPath selects the compared value within a JsonRaw field, e.g. .status.phase
+	JSONPath string `json:"jsonPath,omitempty"`
+	// Operator is one of Equals, NotEquals, Regex, GreaterOrEqual, LessOrEqual, SemverGreaterOrEqual
+	// and AnyOf. Equals is used if unset.
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	// Values are the accepted values of the AnyOf operator
+	Values []string `json:"values,omitempty"`
 }
 
+// ManifestWorkExpectedValues defines the expected values for
+// the fields synced back from the spoke through feedback rules.
+type ManifestWorkExpectedValues []ManifestWorkExpectedValue
+
 func getManifestWorkName(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, startIndex int) string {
 	return GetSafeResourceName(clusterGroupUpgrade.Namespace+"."+clusterGroupUpgrade.Spec.ManifestWorkTemplates[startIndex], "", clusterGroupUpgrade, MaxObjectNameLength)
 }
@@ -45,17 +118,225 @@ func IsManifestWorkCompleted(mw *mwv1.ManifestWork) (bool, error) {
 		}
 		for _, expectedValue := range expectedValues {
 			mc := getManifestCondition(mw, expectedValue.ManifestIndex)
-			if mc == nil || !isManifestConditionReady(mc) || expectedValue.Value != getFieldValue(mc, expectedValue.Name) {
+			if mc == nil || !isManifestConditionReady(mc) {
 				return false, nil
 			}
+			fieldValue := getFieldValue(mc, expectedValue.Name)
+			if fieldValue == nil {
+				return false, nil
+			}
+			matched, err := matchExpectedValue(expectedValue, fieldValue)
+			if !matched {
+				return false, err
+			}
 		}
 	}
 	return true, nil
 }
 
+/*
+matchExpectedValue compares the field synced back from the spoke to the expected value with its operator.
+A field that can't be compared yet, e.g. because it isn't a number, isn't JsonRaw for a JSONPath or lacks the
+JSONPath, doesn't match.
+
+	returns: true/false if the field matches the expected value
+	         error if the expected value is invalid
+*/
+func matchExpectedValue(expectedValue ManifestWorkExpectedValue, fieldValue *mwv1.FieldValue) (bool, error) {
+	value, found, err := getComparedValue(expectedValue, fieldValue)
+	if !found || err != nil {
+		return false, err
+	}
+	return compareExpectedValue(expectedValue, value)
+}
+
+/*
+getComparedValue gets the value of the field compared to the expected value, selected by its JSONPath if any.
+
+	returns: the compared value
+	         true/false if the JSONPath selects a value, false when the field isn't a JsonRaw value
+	         error if the JSONPath is invalid
+*/
+func getComparedValue(expectedValue ManifestWorkExpectedValue, fieldValue *mwv1.FieldValue) (string, bool, error) {
+	value := formatFieldValue(fieldValue)
+	if expectedValue.JSONPath == "" {
+		return value, true, nil
+	}
+	if fieldValue.Type != mwv1.JsonRaw {
+		// The feedback rule of the field may not be in effect on the spoke yet
+		return "", false, nil
+	}
+	return getJSONPathValue(expectedValue.JSONPath, value)
+}
+
+// compareExpectedValue compares the value to the expected value with its operator
+func compareExpectedValue(expectedValue ManifestWorkExpectedValue, value string) (bool, error) {
+	switch expectedValue.Operator {
+	case "", expectedValueOperatorEquals:
+		return value == expectedValue.Value, nil
+	case expectedValueOperatorNotEquals:
+		return value != expectedValue.Value, nil
+	case expectedValueOperatorRegex:
+		regex, err := regexp.Compile(expectedValue.Value)
+		if err != nil {
+			return false, err
+		}
+		return regex.MatchString(value), nil
+	case expectedValueOperatorGreaterOrEqual, expectedValueOperatorLessOrEqual:
+		expected, err := strconv.ParseFloat(expectedValue.Value, 64)
+		if err != nil {
+			return false, err
+		}
+		actual, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false, nil
+		}
+		if expectedValue.Operator == expectedValueOperatorGreaterOrEqual {
+			return actual >= expected, nil
+		}
+		return actual <= expected, nil
+	case expectedValueOperatorSemverGreaterOrEqual:
+		expected, err := semver.ParseTolerant(expectedValue.Value)
+		if err != nil {
+			return false, err
+		}
+		actual, err := semver.ParseTolerant(value)
+		if err != nil {
+			return false, nil
+		}
+		return actual.GTE(expected), nil
+	case expectedValueOperatorAnyOf:
+		return slices.Contains(expectedValue.Values, value), nil
+	}
+	return false, fmt.Errorf("unknown operator %s", expectedValue.Operator)
+}
+
+// describeExpectedValue returns the operator and the expected value, e.g. SemverGreaterOrEqual 4.14.8
+func describeExpectedValue(expectedValue ManifestWorkExpectedValue) string {
+	operator := expectedValue.Operator
+	if operator == "" {
+		operator = expectedValueOperatorEquals
+	}
+	if operator == expectedValueOperatorAnyOf {
+		return operator + " " + strings.Join(expectedValue.Values, ",")
+	}
+	return operator + " " + expectedValue.Value
+}
+
+// GetManifestWorkProgress returns the live status of the manifestwork of the template, only its name if the
+// manifestwork isn't created yet
+func GetManifestWorkProgress(templateName string, mw *mwv1.ManifestWork) ranv1alpha1.ManifestWorkProgress {
+	progress := ranv1alpha1.ManifestWorkProgress{Name: templateName}
+	if mw == nil {
+		return progress
+	}
+	if condition := meta.FindStatusCondition(mw.Status.Conditions, mwv1.WorkApplied); condition != nil {
+		progress.Applied = condition.Status
+	}
+	if condition := meta.FindStatusCondition(mw.Status.Conditions, mwv1.WorkAvailable); condition != nil {
+		progress.Available = condition.Status
+	}
+	progress.Completed, _ = IsManifestWorkCompleted(mw)
+
+	expectedValues := ManifestWorkExpectedValues{}
+	if err := json.Unmarshal([]byte(mw.Annotations[manifestWorkExpectedValuesAnnotation]), &expectedValues); err != nil {
+		return progress
+	}
+	for _, expectedValue := range expectedValues {
+		feedbackValue := ranv1alpha1.ManifestWorkFeedbackValue{
+			ManifestIndex: expectedValue.ManifestIndex,
+			Name:          expectedValue.Name,
+			Expected:      describeExpectedValue(expectedValue),
+		}
+		if mc := getManifestCondition(mw, expectedValue.ManifestIndex); mc != nil {
+			if fieldValue := getFieldValue(mc, expectedValue.Name); fieldValue != nil {
+				if value, found, err := getComparedValue(expectedValue, fieldValue); found && err == nil {
+					feedbackValue.Value = value
+					feedbackValue.Matched, _ = compareExpectedValue(expectedValue, value)
+				}
+			}
+		}
+		progress.FeedbackValues = append(progress.FeedbackValues, feedbackValue)
+	}
+	return progress
+}
+
+// parseJSONPath parses the JSONPath of an expected value, given with or without braces like in the feedback rules
+func parseJSONPath(path string) (*jsonpath.JSONPath, error) {
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	parser := jsonpath.New("expectedValue")
+	if err := parser.Parse(path); err != nil {
+		return nil, err
+	}
+	return parser, nil
+}
+
+/*
+getJSONPathValue gets the value selected by the JSONPath in the JSON document.
+
+	returns: the selected value, several ones being separated by spaces
+	         true/false if the JSONPath selects a value
+	         error if the JSONPath is invalid
+*/
+func getJSONPathValue(path, document string) (string, bool, error) {
+	parser, err := parseJSONPath(path)
+	if err != nil {
+		return "", false, err
+	}
+	var data interface{}
+	if err := json.Unmarshal([]byte(document), &data); err != nil {
+		return "", false, nil
+	}
+	var value bytes.Buffer
+	if err := parser.Execute(&value, data); err != nil {
+		// The path is missing from the document
+		return "", false, nil
+	}
+	return value.String(), true, nil
+}
+
+// validateExpectedValue checks that the operator of the expected value is known and that its values can be parsed
+func validateExpectedValue(expectedValue ManifestWorkExpectedValue) error {
+	if expectedValue.JSONPath != "" {
+		if _, err := parseJSONPath(expectedValue.JSONPath); err != nil {
+			return fmt.Errorf("jsonPath %s is not valid: %w", expectedValue.JSONPath, err)
+		}
+	}
+	if len(expectedValue.Values) > 0 && expectedValue.Operator != expectedValueOperatorAnyOf {
+		return fmt.Errorf("values are only supported by the %s operator", expectedValueOperatorAnyOf)
+	}
+
+	var err error
+	switch expectedValue.Operator {
+	case "", expectedValueOperatorEquals, expectedValueOperatorNotEquals:
+	case expectedValueOperatorRegex:
+		_, err = regexp.Compile(expectedValue.Value)
+	case expectedValueOperatorGreaterOrEqual, expectedValueOperatorLessOrEqual:
+		_, err = strconv.ParseFloat(expectedValue.Value, 64)
+	case expectedValueOperatorSemverGreaterOrEqual:
+		_, err = semver.ParseTolerant(expectedValue.Value)
+	case expectedValueOperatorAnyOf:
+		if len(expectedValue.Values) == 0 {
+			return fmt.Errorf("the %s operator requires values", expectedValueOperatorAnyOf)
+		}
+	default:
+		return fmt.Errorf("unknown operator %s", expectedValue.Operator)
+	}
+	if err != nil {
+		return fmt.Errorf("value %s is not valid for the %s operator: %w", expectedValue.Value, expectedValue.Operator, err)
+	}
+	return nil
+}
+
 func isManifestWorkReady(mw *mwv1.ManifestWork) bool {
 	var applied, available bool
 	for _, condition := range mw.Status.Conditions {
+		// Ignore the conditions reported for a previous spec of the manifestwork
+		if condition.ObservedGeneration != mw.Generation {
+			continue
+		}
 		if condition.Type == mwv1.ManifestApplied && condition.Status == v1.ConditionTrue {
 			applied = true
 		}
@@ -75,21 +356,26 @@ func getManifestCondition(mw *mwv1.ManifestWork, index int32) *mwv1.ManifestCond
 	return nil
 }
 
-func getFieldValue(mc *mwv1.ManifestCondition, name string) string {
+func getFieldValue(mc *mwv1.ManifestCondition, name string) *mwv1.FieldValue {
 	for _, fieldValue := range mc.StatusFeedbacks.Values {
 		if fieldValue.Name == name {
-			switch fieldValue.Value.Type {
-			case mwv1.String:
-				return *fieldValue.Value.String
-			case mwv1.Boolean:
-				return strconv.FormatBool(*fieldValue.Value.Boolean)
-			case mwv1.Integer:
-				return strconv.FormatInt(*fieldValue.Value.Integer, 10)
-			case mwv1.JsonRaw:
-				return *fieldValue.Value.JsonRaw
-			}
+			return &fieldValue.Value
 		}
 	}
+	return nil
+}
+
+func formatFieldValue(fieldValue *mwv1.FieldValue) string {
+	switch fieldValue.Type {
+	case mwv1.String:
+		return *fieldValue.String
+	case mwv1.Boolean:
+		return strconv.FormatBool(*fieldValue.Boolean)
+	case mwv1.Integer:
+		return strconv.FormatInt(*fieldValue.Integer, 10)
+	case mwv1.JsonRaw:
+		return *fieldValue.JsonRaw
+	}
 	return ""
 }
 
@@ -109,6 +395,16 @@ func isManifestConditionReady(mc *mwv1.ManifestCondition) bool {
 	return applied && available && synced
 }
 
+// isManifestWorkKeptInPlace checks if the template belongs to a stage kept in place once completed
+func isManifestWorkKeptInPlace(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, templateName string) bool {
+	for _, stage := range clusterGroupUpgrade.GetManifestWorkStages() {
+		if stage.KeepInPlace && slices.Contains(stage.Templates, templateName) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetManifestWorkForCluster returns the manifest work instance for the given spoke
 func GetManifestWorkForCluster(ctx context.Context, client client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
 	startIndex int, clusterName string) (*mwv1.ManifestWork, error) {
@@ -121,10 +417,50 @@ func GetManifestWorkForCluster(ctx context.Context, client client.Client, cluste
 // CreateManifestWorkForCluster creates the manifest work instance for the given spoke
 func CreateManifestWorkForCluster(ctx context.Context, client client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
 	index int, clusterName string) error {
+	mw, err := newManifestWorkForCluster(ctx, client, clusterGroupUpgrade, index, clusterName)
+	if err != nil {
+		return err
+	}
+	return client.Create(ctx, mw)
+}
+
+// UpdateManifestWorkForCluster updates an existing manifest work instance for the given spoke if it differs from its
+// template, for instance when it was kept in place by a previous ClusterGroupUpgrade rolling out an older version of
+// the template. The ClusterGroupUpgrade takes it over.
+func UpdateManifestWorkForCluster(ctx context.Context, client client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	index int, mw *mwv1.ManifestWork) error {
+	desired, err := newManifestWorkForCluster(ctx, client, clusterGroupUpgrade, index, mw.Namespace)
+	if err != nil {
+		return err
+	}
+	if equality.Semantic.DeepEqual(mw.Spec, desired.Spec) && equality.Semantic.DeepEqual(mw.Labels, desired.Labels) &&
+		equality.Semantic.DeepEqual(mw.Annotations, desired.Annotations) {
+		return nil
+	}
+	mw.Labels = desired.Labels
+	mw.Annotations = desired.Annotations
+	mw.Spec = desired.Spec
+	return client.Update(ctx, mw)
+}
+
+// newManifestWorkForCluster builds the manifest work instance of the template for the given spoke
+func newManifestWorkForCluster(ctx context.Context, client client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	index int, clusterName string) (*mwv1.ManifestWork, error) {
 	mwrs := &mwv1alpha1.ManifestWorkReplicaSet{}
 	err := client.Get(ctx, types.NamespacedName{Name: clusterGroupUpgrade.Spec.ManifestWorkTemplates[index], Namespace: clusterGroupUpgrade.Namespace}, mwrs)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	spec := mwrs.Spec.ManifestWorkTemplate.DeepCopy()
+	if hasManifestPlaceholders(spec.Workload.Manifests) {
+		clusterValues, err := getManifestWorkClusterValues(ctx, client, mwrs, clusterName)
+		if err != nil {
+			return nil, err
+		}
+		if err := fillManifestPlaceholders(spec.Workload.Manifests, clusterValues); err != nil {
+			return nil, &ManifestWorkTemplateError{Template: mwrs.Name, Err: err}
+		}
 	}
 
 	name := getManifestWorkName(clusterGroupUpgrade, index)
@@ -140,24 +476,286 @@ func CreateManifestWorkForCluster(ctx context.Context, client client.Client, clu
 				manifestWorkExpectedValuesAnnotation: mwrs.Annotations[manifestWorkExpectedValuesAnnotation],
 			},
 		},
-		Spec: mwrs.Spec.ManifestWorkTemplate,
+		Spec: *spec,
 	}
-	return client.Create(ctx, mw)
+	if isManifestWorkKeptInPlace(clusterGroupUpgrade, mwrs.Name) {
+		mw.Labels[manifestWorkKeepInPlaceLabel] = "true"
+	}
+	return mw, nil
+}
+
+// IsManifestWorkOwnedBy checks if the manifest work was created or taken over by the ClusterGroupUpgrade
+func IsManifestWorkOwnedBy(mw *mwv1.ManifestWork, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) bool {
+	return mw.Labels["openshift-cluster-group-upgrades/clusterGroupUpgrade"] == clusterGroupUpgrade.Name &&
+		mw.Labels["openshift-cluster-group-upgrades/clusterGroupUpgradeNamespace"] == clusterGroupUpgrade.Namespace
+}
+
+// hasManifestPlaceholders checks if the manifests contain placeholders to fill for each cluster
+func hasManifestPlaceholders(manifests []mwv1.Manifest) bool {
+	for _, manifest := range manifests {
+		if bytes.Contains(manifest.Raw, []byte(manifestWorkPlaceholderLeftDelim)) {
+			return true
+		}
+	}
+	return false
+}
+
+// getManifestWorkClusterValues gets the values of the cluster from its ManagedCluster and from the ConfigMap named
+// by the template in the cluster namespace, if any
+func getManifestWorkClusterValues(ctx context.Context, c client.Client, mwrs *mwv1alpha1.ManifestWorkReplicaSet,
+	clusterName string) (*manifestWorkClusterValues, error) {
+	managedCluster := &clusterv1.ManagedCluster{}
+	if err := c.Get(ctx, types.NamespacedName{Name: clusterName}, managedCluster); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, &ManifestWorkTemplateError{Template: mwrs.Name, Err: err}
+		}
+		return nil, err
+	}
+
+	clusterValues := &manifestWorkClusterValues{
+		ClusterName:   clusterName,
+		labels:        managedCluster.Labels,
+		annotations:   managedCluster.Annotations,
+		clusterClaims: make(map[string]string),
+	}
+	for _, clusterClaim := range managedCluster.Status.ClusterClaims {
+		clusterValues.clusterClaims[clusterClaim.Name] = clusterClaim.Value
+	}
+
+	configMapName := mwrs.Annotations[manifestWorkValuesConfigMapAnnotation]
+	if configMapName != "" {
+		configMap := &corev1.ConfigMap{}
+		if err := c.Get(ctx, types.NamespacedName{Name: configMapName, Namespace: clusterName}, configMap); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, &ManifestWorkTemplateError{Template: mwrs.Name, Err: err}
+			}
+			return nil, err
+		}
+		clusterValues.values = configMap.Data
+	}
+	return clusterValues, nil
+}
+
+// getManifestPlaceholderFuncs returns the functions available to the placeholders. Each of them fails if the
+// requested key is not set for the cluster.
+func getManifestPlaceholderFuncs(clusterValues *manifestWorkClusterValues) template.FuncMap {
+	lookup := func(kind string, values map[string]string) func(string) (string, error) {
+		return func(key string) (string, error) {
+			value, ok := values[key]
+			if !ok {
+				return "", fmt.Errorf("%s %s is not set for cluster %s", kind, key, clusterValues.ClusterName)
+			}
+			return value, nil
+		}
+	}
+	return template.FuncMap{
+		"label":        lookup("label", clusterValues.labels),
+		"annotation":   lookup("annotation", clusterValues.annotations),
+		"clusterClaim": lookup("clusterClaim", clusterValues.clusterClaims),
+		"value":        lookup("value", clusterValues.values),
+	}
+}
+
+func parseManifestPlaceholders(text string, clusterValues *manifestWorkClusterValues) (*template.Template, error) {
+	return template.New("manifest").
+		Delims(manifestWorkPlaceholderLeftDelim, manifestWorkPlaceholderRightDelim).
+		Funcs(getManifestPlaceholderFuncs(clusterValues)).
+		Option("missingkey=error").
+		Parse(text)
+}
+
+// fillManifestPlaceholders replaces the placeholders in the string values of the manifests with the values of the cluster
+func fillManifestPlaceholders(manifests []mwv1.Manifest, clusterValues *manifestWorkClusterValues) error {
+	for i := range manifests {
+		if !bytes.Contains(manifests[i].Raw, []byte(manifestWorkPlaceholderLeftDelim)) {
+			continue
+		}
+		var content interface{}
+		if err := json.Unmarshal(manifests[i].Raw, &content); err != nil {
+			return fmt.Errorf("manifest index %d is not valid: %w", i, err)
+		}
+		content, err := fillPlaceholders(content, clusterValues)
+		if err != nil {
+			return fmt.Errorf("manifest index %d: %w", i, err)
+		}
+		raw, err := json.Marshal(content)
+		if err != nil {
+			return err
+		}
+		manifests[i] = mwv1.Manifest{RawExtension: runtime.RawExtension{Raw: raw}}
+	}
+	return nil
+}
+
+func fillPlaceholders(content interface{}, clusterValues *manifestWorkClusterValues) (interface{}, error) {
+	switch value := content.(type) {
+	case string:
+		if !strings.Contains(value, manifestWorkPlaceholderLeftDelim) {
+			return value, nil
+		}
+		tmpl, err := parseManifestPlaceholders(value, clusterValues)
+		if err != nil {
+			return nil, err
+		}
+		var result strings.Builder
+		if err := tmpl.Execute(&result, clusterValues); err != nil {
+			return nil, err
+		}
+		return result.String(), nil
+	case map[string]interface{}:
+		for key, item := range value {
+			filled, err := fillPlaceholders(item, clusterValues)
+			if err != nil {
+				return nil, err
+			}
+			value[key] = filled
+		}
+	case []interface{}:
+		for i, item := range value {
+			filled, err := fillPlaceholders(item, clusterValues)
+			if err != nil {
+				return nil, err
+			}
+			value[i] = filled
+		}
+	}
+	return content, nil
 }
 
-// CleanupManifestWorkForBatch deletes manifestwork instances for all clusters in the given batch
+// validateManifestPlaceholders checks that the placeholders in the string values of the manifest can be parsed
+func validateManifestPlaceholders(content interface{}) error {
+	switch value := content.(type) {
+	case string:
+		if strings.Contains(value, manifestWorkPlaceholderLeftDelim) {
+			_, err := parseManifestPlaceholders(value, &manifestWorkClusterValues{})
+			return err
+		}
+	case map[string]interface{}:
+		for _, item := range value {
+			if err := validateManifestPlaceholders(item); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			if err := validateManifestPlaceholders(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateManifestWorkReplicaSet checks that the manifestwork template has manifests and that its expected values
+// refer to existing manifest ordinals and feedback rule names
+func ValidateManifestWorkReplicaSet(mwrs *mwv1alpha1.ManifestWorkReplicaSet) error {
+	manifests := mwrs.Spec.ManifestWorkTemplate.Workload.Manifests
+	if len(manifests) == 0 {
+		return fmt.Errorf("template has no manifests")
+	}
+
+	for i, manifest := range manifests {
+		if !bytes.Contains(manifest.Raw, []byte(manifestWorkPlaceholderLeftDelim)) {
+			continue
+		}
+		var content interface{}
+		if err := json.Unmarshal(manifest.Raw, &content); err != nil {
+			return fmt.Errorf("manifest index %d is not valid: %w", i, err)
+		}
+		if err := validateManifestPlaceholders(content); err != nil {
+			return fmt.Errorf("manifest index %d has an invalid placeholder: %w", i, err)
+		}
+	}
+
+	expectedValuesString := mwrs.Annotations[manifestWorkExpectedValuesAnnotation]
+	if expectedValuesString == "" {
+		return nil
+	}
+	expectedValues := ManifestWorkExpectedValues{}
+	if err := json.Unmarshal([]byte(expectedValuesString), &expectedValues); err != nil {
+		return fmt.Errorf("annotation %s is not valid: %w", manifestWorkExpectedValuesAnnotation, err)
+	}
+	for _, expectedValue := range expectedValues {
+		if expectedValue.ManifestIndex < 0 || int(expectedValue.ManifestIndex) >= len(manifests) {
+			return fmt.Errorf("annotation %s refers to manifest index %d but the template has %d manifests",
+				manifestWorkExpectedValuesAnnotation, expectedValue.ManifestIndex, len(manifests))
+		}
+		manifest := &unstructured.Unstructured{}
+		if err := manifest.UnmarshalJSON(manifests[expectedValue.ManifestIndex].Raw); err != nil {
+			return fmt.Errorf("manifest index %d is not valid: %w", expectedValue.ManifestIndex, err)
+		}
+		if !hasFeedbackRule(mwrs.Spec.ManifestWorkTemplate.ManifestConfigs, manifest, expectedValue.Name) {
+			return fmt.Errorf("annotation %s refers to %s which is not a feedback rule of manifest index %d",
+				manifestWorkExpectedValuesAnnotation, expectedValue.Name, expectedValue.ManifestIndex)
+		}
+		if err := validateExpectedValue(expectedValue); err != nil {
+			return fmt.Errorf("annotation %s has an invalid expected value for %s: %w",
+				manifestWorkExpectedValuesAnnotation, expectedValue.Name, err)
+		}
+	}
+	return nil
+}
+
+// hasFeedbackRule checks if the manifest has a feedback rule with the given name. Any name is accepted for
+// the well known status feedback rules as their names depend on the resource.
+func hasFeedbackRule(manifestConfigs []mwv1.ManifestConfigOption, manifest *unstructured.Unstructured, name string) bool {
+	group := manifest.GroupVersionKind().Group
+	for _, manifestConfig := range manifestConfigs {
+		identifier := manifestConfig.ResourceIdentifier
+		if identifier.Group != group || identifier.Name != manifest.GetName() || identifier.Namespace != manifest.GetNamespace() {
+			continue
+		}
+		for _, feedbackRule := range manifestConfig.FeedbackRules {
+			if feedbackRule.Type == mwv1.WellKnownStatusType {
+				return true
+			}
+			for _, jsonPath := range feedbackRule.JsonPaths {
+				if jsonPath.Name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// CleanupManifestWorkForBatch deletes manifestwork instances for all clusters in the given batch, except the ones
+// of the stages kept in place
 func CleanupManifestWorkForBatch(ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, batchIndex int) error {
-	if clusterGroupUpgrade.RolloutType() != ranv1alpha1.RolloutTypes.ManifestWork {
+	return deleteManifestWorksForBatch(ctx, c, clusterGroupUpgrade, batchIndex, selection.DoesNotExist)
+}
+
+// DeleteKeptInPlaceManifestWorks deletes the manifestwork instances of the stages kept in place for all clusters of
+// the remediation plan. They outlive the completion of the ClusterGroupUpgrade and are deleted with it.
+func DeleteKeptInPlaceManifestWorks(ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+	for batchIndex := range clusterGroupUpgrade.Status.RemediationPlan {
+		if err := deleteManifestWorksForBatch(ctx, c, clusterGroupUpgrade, batchIndex, selection.Exists); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteManifestWorksForBatch deletes the manifestwork instances of the ClusterGroupUpgrade for all clusters in the
+// given batch, selecting the ones kept in place or not with the keepInPlace label operator
+func deleteManifestWorksForBatch(ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	batchIndex int, keepInPlaceOperator selection.Operator) error {
+	if clusterGroupUpgrade.RolloutType() == ranv1alpha1.RolloutTypes.Policy {
 		return nil
 	}
-	var labels = map[string]string{
+	selector := labels.SelectorFromSet(labels.Set{
 		"openshift-cluster-group-upgrades/clusterGroupUpgrade":          clusterGroupUpgrade.Name,
-		"openshift-cluster-group-upgrades/clusterGroupUpgradeNamespace": clusterGroupUpgrade.Namespace}
+		"openshift-cluster-group-upgrades/clusterGroupUpgradeNamespace": clusterGroupUpgrade.Namespace})
+	keepInPlace, err := labels.NewRequirement(manifestWorkKeepInPlaceLabel, keepInPlaceOperator, nil)
+	if err != nil {
+		return err
+	}
+	selector = selector.Add(*keepInPlace)
 	for _, clusterName := range clusterGroupUpgrade.Status.RemediationPlan[batchIndex] {
 
 		deleteAllOpts := []client.DeleteAllOfOption{
 			client.InNamespace(clusterName),
-			client.MatchingLabels(labels),
+			client.MatchingLabelsSelector{Selector: selector},
 		}
 
 		if err := c.DeleteAllOf(ctx, &mwv1.ManifestWork{}, deleteAllOpts...); client.IgnoreNotFound(err) != nil {
@@ -1,10 +1,19 @@
 package utils
 
 import (
+	"context"
 	"testing"
 
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	mwv1 "open-cluster-management.io/api/work/v1"
+	mwv1alpha1 "open-cluster-management.io/api/work/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var (
@@ -25,6 +34,27 @@ func TestIsManifestWorkCompleted(t *testing.T) {
 			mw:   &mwv1.ManifestWork{},
 			want: false,
 		},
+		{
+			name: "ManifestWork ready for a previous generation",
+			mw: &mwv1.ManifestWork{
+				ObjectMeta: v1.ObjectMeta{Generation: 2},
+				Status: mwv1.ManifestWorkStatus{
+					Conditions: []v1.Condition{
+						{
+							Type:               mwv1.WorkApplied,
+							Status:             v1.ConditionTrue,
+							ObservedGeneration: 1,
+						},
+						{
+							Type:               mwv1.WorkAvailable,
+							Status:             v1.ConditionTrue,
+							ObservedGeneration: 1,
+						},
+					},
+				},
+			},
+			want: false,
+		},
 		{
 			name: "ManifestWork with malform annotation",
 			mw: &mwv1.ManifestWork{
@@ -258,3 +288,390 @@ func TestIsManifestWorkCompleted(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchExpectedValue(t *testing.T) {
+	stringValue := func(value string) *mwv1.FieldValue {
+		return &mwv1.FieldValue{Type: mwv1.String, String: &value}
+	}
+	integerValue := func(value int64) *mwv1.FieldValue {
+		return &mwv1.FieldValue{Type: mwv1.Integer, Integer: &value}
+	}
+	jsonRawValue := func(value string) *mwv1.FieldValue {
+		return &mwv1.FieldValue{Type: mwv1.JsonRaw, JsonRaw: &value}
+	}
+	csv := jsonRawValue(`{"phase": "Succeeded", "version": "4.14.10", "conditions": [{"type": "Ready", "status": "True"}]}`)
+
+	tests := []struct {
+		name          string
+		expectedValue ManifestWorkExpectedValue
+		fieldValue    *mwv1.FieldValue
+		want          bool
+		wantErr       bool
+	}{
+		{
+			name:          "equals by default",
+			expectedValue: ManifestWorkExpectedValue{Value: "Succeeded"},
+			fieldValue:    stringValue("Succeeded"),
+			want:          true,
+		},
+		{
+			name:          "equals",
+			expectedValue: ManifestWorkExpectedValue{Operator: "Equals", Value: "Succeeded"},
+			fieldValue:    stringValue("Installing"),
+			want:          false,
+		},
+		{
+			name:          "not equals",
+			expectedValue: ManifestWorkExpectedValue{Operator: "NotEquals", Value: "Failed"},
+			fieldValue:    stringValue("Succeeded"),
+			want:          true,
+		},
+		{
+			name:          "regex",
+			expectedValue: ManifestWorkExpectedValue{Operator: "Regex", Value: `^4\.14\.\d+$`},
+			fieldValue:    stringValue("4.14.8"),
+			want:          true,
+		},
+		{
+			name:          "invalid regex",
+			expectedValue: ManifestWorkExpectedValue{Operator: "Regex", Value: `(`},
+			fieldValue:    stringValue("4.14.8"),
+			wantErr:       true,
+		},
+		{
+			name:          "greater or equal",
+			expectedValue: ManifestWorkExpectedValue{Operator: "GreaterOrEqual", Value: "3"},
+			fieldValue:    integerValue(3),
+			want:          true,
+		},
+		{
+			name:          "less or equal",
+			expectedValue: ManifestWorkExpectedValue{Operator: "LessOrEqual", Value: "3"},
+			fieldValue:    integerValue(4),
+			want:          false,
+		},
+		{
+			name:          "greater or equal with a non numeric value",
+			expectedValue: ManifestWorkExpectedValue{Operator: "GreaterOrEqual", Value: "3"},
+			fieldValue:    stringValue("unknown"),
+			want:          false,
+		},
+		{
+			name:          "semver greater or equal",
+			expectedValue: ManifestWorkExpectedValue{Operator: "SemverGreaterOrEqual", Value: "4.14.8"},
+			fieldValue:    stringValue("v4.14.10"),
+			want:          true,
+		},
+		{
+			name:          "semver lower",
+			expectedValue: ManifestWorkExpectedValue{Operator: "SemverGreaterOrEqual", Value: "4.14.8"},
+			fieldValue:    stringValue("4.14.2"),
+			want:          false,
+		},
+		{
+			name:          "any of",
+			expectedValue: ManifestWorkExpectedValue{Operator: "AnyOf", Values: []string{"Succeeded", "Replacing"}},
+			fieldValue:    stringValue("Replacing"),
+			want:          true,
+		},
+		{
+			name:          "unknown operator",
+			expectedValue: ManifestWorkExpectedValue{Operator: "Contains", Value: "Succeeded"},
+			fieldValue:    stringValue("Succeeded"),
+			wantErr:       true,
+		},
+		{
+			name:          "jsonPath over JsonRaw",
+			expectedValue: ManifestWorkExpectedValue{JSONPath: ".phase", Value: "Succeeded"},
+			fieldValue:    csv,
+			want:          true,
+		},
+		{
+			name:          "jsonPath with semver",
+			expectedValue: ManifestWorkExpectedValue{JSONPath: "{.version}", Operator: "SemverGreaterOrEqual", Value: "4.14.8"},
+			fieldValue:    csv,
+			want:          true,
+		},
+		{
+			name:          "jsonPath with filter",
+			expectedValue: ManifestWorkExpectedValue{JSONPath: `.conditions[?(@.type=="Ready")].status`, Value: "True"},
+			fieldValue:    csv,
+			want:          true,
+		},
+		{
+			name:          "jsonPath missing from the value",
+			expectedValue: ManifestWorkExpectedValue{JSONPath: ".status.phase", Value: "Succeeded"},
+			fieldValue:    csv,
+			want:          false,
+		},
+		{
+			name:          "jsonPath over a string value",
+			expectedValue: ManifestWorkExpectedValue{JSONPath: ".phase", Value: "Succeeded"},
+			fieldValue:    stringValue("Succeeded"),
+			want:          false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchExpectedValue(tt.expectedValue, tt.fieldValue)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetManifestWorkProgress(t *testing.T) {
+	phase := "Succeeded"
+	csv := `{"version": "4.14.2"}`
+	mw := &mwv1.ManifestWork{
+		ObjectMeta: v1.ObjectMeta{
+			Annotations: map[string]string{
+				manifestWorkExpectedValuesAnnotation: `[{"manifestIndex":0,"name":"phase","value":"Succeeded"},` +
+					`{"manifestIndex":0,"name":"csv","jsonPath":".version","operator":"SemverGreaterOrEqual","value":"4.14.8"},` +
+					`{"manifestIndex":1,"name":"phase","operator":"AnyOf","values":["Succeeded","Replacing"]}]`,
+			},
+		},
+		Status: mwv1.ManifestWorkStatus{
+			Conditions: []v1.Condition{
+				{Type: mwv1.WorkApplied, Status: v1.ConditionTrue},
+				{Type: mwv1.WorkAvailable, Status: v1.ConditionFalse},
+			},
+			ResourceStatus: mwv1.ManifestResourceStatus{
+				Manifests: []mwv1.ManifestCondition{{
+					ResourceMeta: mwv1.ManifestResourceMeta{Ordinal: 0},
+					StatusFeedbacks: mwv1.StatusFeedbackResult{Values: []mwv1.FeedbackValue{
+						{Name: "phase", Value: mwv1.FieldValue{Type: mwv1.String, String: &phase}},
+						{Name: "csv", Value: mwv1.FieldValue{Type: mwv1.JsonRaw, JsonRaw: &csv}},
+					}},
+				}},
+			},
+		},
+	}
+
+	assert.Equal(t, ranv1alpha1.ManifestWorkProgress{Name: "operators"}, GetManifestWorkProgress("operators", nil))
+	assert.Equal(t, ranv1alpha1.ManifestWorkProgress{
+		Name:      "operators",
+		Applied:   v1.ConditionTrue,
+		Available: v1.ConditionFalse,
+		FeedbackValues: []ranv1alpha1.ManifestWorkFeedbackValue{
+			{ManifestIndex: 0, Name: "phase", Value: "Succeeded", Expected: "Equals Succeeded", Matched: true},
+			{ManifestIndex: 0, Name: "csv", Value: "4.14.2", Expected: "SemverGreaterOrEqual 4.14.8"},
+			{ManifestIndex: 1, Name: "phase", Expected: "AnyOf Succeeded,Replacing"},
+		},
+	}, GetManifestWorkProgress("operators", mw))
+}
+
+func TestValidateManifestWorkReplicaSet(t *testing.T) {
+	newTemplate := func(expectedValues string, manifests ...string) *mwv1alpha1.ManifestWorkReplicaSet {
+		mwrs := &mwv1alpha1.ManifestWorkReplicaSet{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        "ibu-prep",
+				Annotations: map[string]string{manifestWorkExpectedValuesAnnotation: expectedValues},
+			},
+		}
+		for _, manifest := range manifests {
+			mwrs.Spec.ManifestWorkTemplate.Workload.Manifests = append(mwrs.Spec.ManifestWorkTemplate.Workload.Manifests,
+				mwv1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(manifest)}})
+		}
+		mwrs.Spec.ManifestWorkTemplate.ManifestConfigs = []mwv1.ManifestConfigOption{
+			{
+				ResourceIdentifier: mwv1.ResourceIdentifier{Group: "lca.openshift.io", Resource: "imagebasedupgrades", Name: "upgrade"},
+				FeedbackRules: []mwv1.FeedbackRule{{
+					Type:      mwv1.JSONPathsType,
+					JsonPaths: []mwv1.JsonPath{{Name: "isPrepCompleted", Path: ".status.conditions[?(@.type==\"PrepCompleted\")].status"}},
+				}},
+			},
+			{
+				ResourceIdentifier: mwv1.ResourceIdentifier{Group: "apps", Resource: "deployments", Name: "app", Namespace: "default"},
+				FeedbackRules:      []mwv1.FeedbackRule{{Type: mwv1.WellKnownStatusType}},
+			},
+		}
+		return mwrs
+	}
+	ibu := `{"apiVersion": "lca.openshift.io/v1alpha1", "kind": "ImageBasedUpgrade", "metadata": {"name": "upgrade"}}`
+	deployment := `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "app", "namespace": "default"}}`
+
+	tests := []struct {
+		name    string
+		mwrs    *mwv1alpha1.ManifestWorkReplicaSet
+		wantErr string
+	}{
+		{
+			name: "valid template",
+			mwrs: newTemplate(`[{"manifestIndex":0,"name":"isPrepCompleted","value":"True"}]`, ibu),
+		},
+		{
+			name: "valid template without expected values",
+			mwrs: newTemplate("", ibu),
+		},
+		{
+			name: "well known status feedback",
+			mwrs: newTemplate(`[{"manifestIndex":1,"name":"ReadyReplicas","value":"1"}]`, ibu, deployment),
+		},
+		{
+			name: "valid placeholders",
+			mwrs: newTemplate("", `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{cgu .ClusterName cgu}}"}, "data": {"ntp": "{{cgu value \"ntp\" cgu}}"}}`),
+		},
+		{
+			name:    "invalid placeholder",
+			mwrs:    newTemplate("", ibu, `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{cgu unknown \"ntp\" cgu}}"}}`),
+			wantErr: "manifest index 1 has an invalid placeholder",
+		},
+		{
+			name: "expected value with an operator",
+			mwrs: newTemplate(`[{"manifestIndex":1,"name":"version","jsonPath":".version","operator":"SemverGreaterOrEqual","value":"4.14.8"}]`,
+				ibu, deployment),
+		},
+		{
+			name:    "expected value with an unknown operator",
+			mwrs:    newTemplate(`[{"manifestIndex":0,"name":"isPrepCompleted","operator":"Contains","value":"True"}]`, ibu),
+			wantErr: "has an invalid expected value for isPrepCompleted: unknown operator Contains",
+		},
+		{
+			name:    "expected value with an invalid semver",
+			mwrs:    newTemplate(`[{"manifestIndex":1,"name":"version","operator":"SemverGreaterOrEqual","value":"latest"}]`, ibu, deployment),
+			wantErr: "value latest is not valid for the SemverGreaterOrEqual operator",
+		},
+		{
+			name:    "expected value with an invalid jsonPath",
+			mwrs:    newTemplate(`[{"manifestIndex":1,"name":"status","jsonPath":".conditions[","value":"True"}]`, ibu, deployment),
+			wantErr: "jsonPath .conditions[ is not valid",
+		},
+		{
+			name:    "any of without values",
+			mwrs:    newTemplate(`[{"manifestIndex":1,"name":"status","operator":"AnyOf","value":"True"}]`, ibu, deployment),
+			wantErr: "the AnyOf operator requires values",
+		},
+		{
+			name:    "template without manifests",
+			mwrs:    newTemplate(""),
+			wantErr: "template has no manifests",
+		},
+		{
+			name:    "malformed expected values",
+			mwrs:    newTemplate(`bad value`, ibu),
+			wantErr: "annotation openshift-cluster-group-upgrades/expectedValues is not valid",
+		},
+		{
+			name:    "expected value with a missing manifest index",
+			mwrs:    newTemplate(`[{"manifestIndex":1,"name":"isPrepCompleted","value":"True"}]`, ibu),
+			wantErr: "refers to manifest index 1 but the template has 1 manifests",
+		},
+		{
+			name:    "expected value with a missing feedback rule",
+			mwrs:    newTemplate(`[{"manifestIndex":0,"name":"isPrepComplete","value":"True"}]`, ibu),
+			wantErr: "refers to isPrepComplete which is not a feedback rule of manifest index 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateManifestWorkReplicaSet(tt.mwrs)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateManifestWorkForCluster(t *testing.T) {
+	testscheme := runtime.NewScheme()
+	assert.NoError(t, ranv1alpha1.AddToScheme(testscheme))
+	assert.NoError(t, mwv1alpha1.AddToScheme(testscheme))
+	assert.NoError(t, mwv1.AddToScheme(testscheme))
+	assert.NoError(t, clusterv1.AddToScheme(testscheme))
+	assert.NoError(t, corev1.AddToScheme(testscheme))
+
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "cgu", Namespace: "default",
+			Annotations: map[string]string{NameSuffixAnnotation: "kuttl"},
+		},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{ManifestWorkTemplates: []string{"site-config"}},
+	}
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "spoke1",
+			Labels:      map[string]string{"site": "paris"},
+			Annotations: map[string]string{"example.com/vlan": "100"},
+		},
+		Status: clusterv1.ManagedClusterStatus{
+			ClusterClaims: []clusterv1.ManagedClusterClaim{{Name: "id.k8s.io", Value: "1234"}},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{Name: "site-values", Namespace: "spoke1"},
+		Data:       map[string]string{"ntp": "ntp.paris.example.com"},
+	}
+	newTemplate := func(manifest string) *mwv1alpha1.ManifestWorkReplicaSet {
+		return &mwv1alpha1.ManifestWorkReplicaSet{
+			ObjectMeta: v1.ObjectMeta{
+				Name: "site-config", Namespace: "default",
+				Annotations: map[string]string{manifestWorkValuesConfigMapAnnotation: "site-values"},
+			},
+			Spec: mwv1alpha1.ManifestWorkReplicaSetSpec{
+				ManifestWorkTemplate: mwv1.ManifestWorkSpec{Workload: mwv1.ManifestsTemplate{Manifests: []mwv1.Manifest{
+					{RawExtension: runtime.RawExtension{Raw: []byte(manifest)}},
+				}}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		manifest     string
+		objects      []client.Object
+		wantManifest string
+		wantErr      string
+	}{
+		{
+			name:         "template without placeholders",
+			manifest:     `{"apiVersion":"v1","data":{"ntp":"ntp.example.com"},"kind":"ConfigMap","metadata":{"name":"site"}}`,
+			wantManifest: `{"apiVersion":"v1","data":{"ntp":"ntp.example.com"},"kind":"ConfigMap","metadata":{"name":"site"}}`,
+		},
+		{
+			name: "placeholders are filled with the values of the cluster",
+			manifest: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{cgu .ClusterName cgu}}-site"}, "data": {` +
+				`"site": "{{cgu label \"site\" cgu}}", "vlan": "{{cgu annotation \"example.com/vlan\" cgu}}",` +
+				`"id": "{{cgu clusterClaim \"id.k8s.io\" cgu}}", "servers": ["{{cgu value \"ntp\" cgu}}"]}}`,
+			objects: []client.Object{managedCluster, configMap},
+			wantManifest: `{"apiVersion":"v1","data":{"id":"1234","servers":["ntp.paris.example.com"],"site":"paris","vlan":"100"},` +
+				`"kind":"ConfigMap","metadata":{"name":"spoke1-site"}}`,
+		},
+		{
+			name:     "missing value",
+			manifest: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{cgu label \"region\" cgu}}"}}`,
+			objects:  []client.Object{managedCluster, configMap},
+			wantErr:  "label region is not set for cluster spoke1",
+		},
+		{
+			name:     "missing values configmap",
+			manifest: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "{{cgu value \"ntp\" cgu}}"}}`,
+			objects:  []client.Object{managedCluster},
+			wantErr:  "configmaps \"site-values\" not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := append([]client.Object{newTemplate(tt.manifest)}, tt.objects...)
+			c := fake.NewClientBuilder().WithScheme(testscheme).WithObjects(objects...).Build()
+
+			err := CreateManifestWorkForCluster(context.TODO(), c, cgu, 0, "spoke1")
+			if tt.wantErr != "" {
+				var templateErr *ManifestWorkTemplateError
+				assert.ErrorAs(t, err, &templateErr)
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			mw, err := GetManifestWorkForCluster(context.TODO(), c, cgu, 0, "spoke1")
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.wantManifest, string(mw.Spec.Workload.Manifests[0].Raw))
+		})
+	}
+}
@@ -20,6 +20,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -40,9 +42,11 @@ var multiCloudLog = ctrl.Log.WithName("multiCloudLog")
 
 // ProcessSubscriptionManagedClusterView processes the content of a view that is configured to watch a Subscription
 // type object and takes the necessary actions to approve the InstallPlan associated with that Subscription.
+// If expectedCSV is set, only an InstallPlan installing that ClusterServiceVersion, or an older one on the way to it,
+// is approved.
 func ProcessSubscriptionManagedClusterView(
 	ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
-	clusterName string, mcv *viewv1beta1.ManagedClusterView) (int, error) {
+	clusterName string, mcv *viewv1beta1.ManagedClusterView, expectedCSV string) (int, error) {
 
 	conditionMCVforSub := meta.FindStatusCondition(mcv.Status.Conditions, viewv1beta1.ConditionViewProcessing)
 	if conditionMCVforSub == nil {
@@ -77,7 +81,7 @@ func ProcessSubscriptionManagedClusterView(
 		}
 		multiCloudLog.Info("Accept InstallPlan", "name", subscription.Status.Install.Name,
 			"namespace", subscription.ObjectMeta.Namespace)
-		installPlanResult, err := EnsureInstallPlanIsApproved(ctx, c, clusterGroupUpgrade, subscription, clusterName)
+		installPlanResult, err := EnsureInstallPlanIsApproved(ctx, c, clusterGroupUpgrade, subscription, clusterName, expectedCSV)
 		if err != nil {
 			return installPlanResult, err
 		}
@@ -87,11 +91,160 @@ func ProcessSubscriptionManagedClusterView(
 	return InstallPlanCannotBeApproved, nil
 }
 
+// clusterVersionStatus holds the fields of the ClusterVersion status used to report the platform upgrade progress
+type clusterVersionStatus struct {
+	Status struct {
+		Desired struct {
+			Version string `json:"version,omitempty"`
+		} `json:"desired,omitempty"`
+		History []struct {
+			State   string `json:"state,omitempty"`
+			Version string `json:"version,omitempty"`
+		} `json:"history,omitempty"`
+		Conditions []metav1.Condition `json:"conditions,omitempty"`
+	} `json:"status,omitempty"`
+}
+
+// clusterVersionCompletedRegex matches the progress reported in the Progressing condition of a ClusterVersion,
+// e.g. "Working towards 4.14.1: 700 of 859 done (81% complete)"
+var clusterVersionCompletedRegex = regexp.MustCompile(`\((\d+)% complete`)
+
+// ProcessClusterVersionManagedClusterView processes the content of a view that is configured to watch a ClusterVersion
+// type object and returns the platform upgrade progress of the cluster, along with whether the upgrade is failing.
+// A nil progress is returned if the view hasn't retrieved the ClusterVersion (yet).
+func ProcessClusterVersionManagedClusterView(mcv *viewv1beta1.ManagedClusterView) (*ranv1alpha1.ClusterVersionProgress, bool, error) {
+	conditionMCVforCV := meta.FindStatusCondition(mcv.Status.Conditions, viewv1beta1.ConditionViewProcessing)
+	if conditionMCVforCV == nil || conditionMCVforCV.Status != metav1.ConditionTrue ||
+		conditionMCVforCV.Reason != viewv1beta1.ReasonGetResource {
+		multiCloudLog.Info("ManagedClusterView was not able to retrieve the requested resource (yet), trying again later",
+			"managedclusterview", mcv.ObjectMeta.Name, "namespace", mcv.ObjectMeta.Namespace)
+		return nil, false, nil
+	}
+
+	clusterVersion := clusterVersionStatus{}
+	if err := json.Unmarshal(mcv.Status.Result.Raw, &clusterVersion); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal the ClusterVersion from ManagedClusterView %s: %w", mcv.ObjectMeta.Name, err)
+	}
+
+	progress := &ranv1alpha1.ClusterVersionProgress{
+		DesiredVersion: clusterVersion.Status.Desired.Version,
+	}
+	// The history is ordered with the newest update first
+	for _, update := range clusterVersion.Status.History {
+		if update.State == "Completed" {
+			progress.CurrentVersion = update.Version
+			break
+		}
+	}
+
+	conditions := clusterVersion.Status.Conditions
+	if progressing := meta.FindStatusCondition(conditions, "Progressing"); progressing != nil {
+		progress.Message = progressing.Message
+		if match := clusterVersionCompletedRegex.FindStringSubmatch(progressing.Message); match != nil {
+			percentage, _ := strconv.Atoi(match[1])
+			progress.CompletedPercentage = &percentage
+		} else if progressing.Status == metav1.ConditionFalse && progress.CurrentVersion != "" &&
+			progress.CurrentVersion == progress.DesiredVersion {
+			percentage := 100
+			progress.CompletedPercentage = &percentage
+		}
+	}
+
+	if releaseAccepted := meta.FindStatusCondition(conditions, "ReleaseAccepted"); releaseAccepted != nil &&
+		releaseAccepted.Status == metav1.ConditionFalse {
+		progress.Failures = append(progress.Failures,
+			fmt.Sprintf("ReleaseAccepted=False: %s", releaseAccepted.Message))
+	}
+	isFailing := false
+	if failing := meta.FindStatusCondition(conditions, "Failing"); failing != nil && failing.Status == metav1.ConditionTrue {
+		isFailing = true
+		progress.Failures = append(progress.Failures, fmt.Sprintf("Failing=True: %s", failing.Message))
+	}
+
+	return progress, isFailing, nil
+}
+
+// isManagedClusterViewResourceRetrieved checks if the view was able to retrieve the requested resource
+func isManagedClusterViewResourceRetrieved(mcv *viewv1beta1.ManagedClusterView) bool {
+	conditionMCV := meta.FindStatusCondition(mcv.Status.Conditions, viewv1beta1.ConditionViewProcessing)
+	return conditionMCV != nil && conditionMCV.Status == metav1.ConditionTrue && conditionMCV.Reason == viewv1beta1.ReasonGetResource
+}
+
+// GetOperatorUpgradeProgress processes the content of a view that is configured to watch a Subscription type object
+// and creates views on its InstallPlan and target ClusterServiceVersion to return the upgrade progress of the operator.
+// A nil progress is returned if the view hasn't retrieved the Subscription (yet).
+func GetOperatorUpgradeProgress(
+	ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	clusterName string, mcv *viewv1beta1.ManagedClusterView) (*ranv1alpha1.OperatorUpgradeProgress, error) {
+
+	if !isManagedClusterViewResourceRetrieved(mcv) {
+		multiCloudLog.Info("ManagedClusterView was not able to retrieve the requested resource (yet), trying again later",
+			"managedclusterview", mcv.ObjectMeta.Name, "namespace", mcv.ObjectMeta.Namespace)
+		return nil, nil
+	}
+
+	subscription := operatorsv1alpha1.Subscription{}
+	if err := json.Unmarshal(mcv.Status.Result.Raw, &subscription); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the Subscription from ManagedClusterView %s: %w", mcv.ObjectMeta.Name, err)
+	}
+
+	progress := &ranv1alpha1.OperatorUpgradeProgress{
+		Subscription: subscription.ObjectMeta.Name,
+		Namespace:    subscription.ObjectMeta.Namespace,
+		InstalledCSV: subscription.Status.InstalledCSV,
+		TargetCSV:    subscription.Status.CurrentCSV,
+	}
+	if progress.InstalledCSV != "" && progress.TargetCSV != "" && progress.TargetCSV != progress.InstalledCSV {
+		progress.CurrentHop = fmt.Sprintf("%s -> %s", progress.InstalledCSV, progress.TargetCSV)
+	}
+
+	if subscription.Status.Install != nil {
+		progress.InstallPlan = subscription.Status.Install.Name
+		mcvForInstallPlan, err := EnsureManagedClusterView(
+			ctx, c, progress.InstallPlan, progress.InstallPlan, clusterName, "InstallPlan", progress.InstallPlan,
+			progress.Namespace, clusterGroupUpgrade.Name, clusterGroupUpgrade.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		if isManagedClusterViewResourceRetrieved(mcvForInstallPlan) {
+			installPlan := operatorsv1alpha1.InstallPlan{}
+			if err := json.Unmarshal(mcvForInstallPlan.Status.Result.Raw, &installPlan); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal the InstallPlan from ManagedClusterView %s: %w", mcvForInstallPlan.ObjectMeta.Name, err)
+			}
+			progress.InstallPlanPhase = string(installPlan.Status.Phase)
+		}
+	}
+
+	if progress.TargetCSV != "" {
+		csvKind := "ClusterServiceVersion"
+		mcvName := GetMultiCloudObjectName(clusterGroupUpgrade, csvKind, progress.TargetCSV)
+		safeName := GetSafeResourceName(mcvName, "", clusterGroupUpgrade, MaxObjectNameLength)
+		mcvForCSV, err := EnsureManagedClusterView(
+			ctx, c, safeName, mcvName, clusterName, csvKind+"."+SubscriptionGroupVersionKind().Group, progress.TargetCSV,
+			progress.Namespace, clusterGroupUpgrade.Name, clusterGroupUpgrade.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		// The target CSV doesn't exist until its InstallPlan is approved
+		if isManagedClusterViewResourceRetrieved(mcvForCSV) {
+			csv := operatorsv1alpha1.ClusterServiceVersion{}
+			if err := json.Unmarshal(mcvForCSV.Status.Result.Raw, &csv); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal the ClusterServiceVersion from ManagedClusterView %s: %w", mcvForCSV.ObjectMeta.Name, err)
+			}
+			progress.CSVPhase = string(csv.Status.Phase)
+			progress.Message = csv.Status.Message
+		}
+	}
+
+	return progress, nil
+}
+
 // EnsureInstallPlanIsApproved creates a view to get all the needed information on an InstallPlan and creates an
-// action to approve that plan, if the plan's approval is set to Manual.
+// action to approve that plan, if the plan's approval is set to Manual. If expectedCSV is set, a plan that isn't a hop
+// of the upgrade chain to that ClusterServiceVersion is not approved.
 var EnsureInstallPlanIsApproved = func(
 	ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
-	subscription operatorsv1alpha1.Subscription, clusterName string) (int, error) {
+	subscription operatorsv1alpha1.Subscription, clusterName, expectedCSV string) (int, error) {
 	// Create a ManagedClusterView for the InstallPlan so that we can access its latest resourceVersion.
 	multiCloudLog.Info("[EnsureInstallPlanIsApproved] Create MCV for InstallPlan", "InstallPlan",
 		subscription.Status.Install.Name, "ns", clusterName)
@@ -137,6 +290,17 @@ var EnsureInstallPlanIsApproved = func(
 			return InstallPlanAlreadyApproved, nil
 		}
 
+		// If the InstallPlan would install another version than the expected one or an intermediate version
+		// on the way to it, return.
+		if expectedCSV != "" {
+			if !isInstallPlanTowardsCSV(installPlan.Spec.ClusterServiceVersionNames, expectedCSV) {
+				multiCloudLog.Info("InstallPlan can't be approved as it doesn't install the expected ClusterServiceVersion",
+					"InstallPlan", installPlan.ObjectMeta.Name, "namespace", installPlan.ObjectMeta.Namespace,
+					"expectedCSV", expectedCSV, "clusterServiceVersionNames", installPlan.Spec.ClusterServiceVersionNames)
+				return InstallPlanUnexpectedCSV, nil
+			}
+		}
+
 		multiCloudLog.Info("Create ManagedClusterAction for InstallPlan", "InstallPlan",
 			installPlan.ObjectMeta.Name, "namespace", installPlan.ObjectMeta.Namespace)
 		// Create or update the managedClusterAction to approve the install plan.
@@ -151,6 +315,95 @@ var EnsureInstallPlanIsApproved = func(
 	return InstallPlanCannotBeApproved, nil
 }
 
+// csvNameRegex splits the name of a ClusterServiceVersion into the operator name, its major, minor and patch
+// versions and its build suffix, e.g. "ptp-operator.v4.14.2" or "ptp-operator.4.9.0-202201210133"
+var csvNameRegex = regexp.MustCompile(`^(.+?)\.v?(\d+)\.(\d+)\.(\d+)(?:[-+](\S+))?$`)
+
+// isInstallPlanTowardsCSV checks if an InstallPlan is a hop of the upgrade chain to the expected ClusterServiceVersion,
+// i.e. it installs either the expected version of the operator or an older one
+func isInstallPlanTowardsCSV(csvNames []string, expectedCSV string) bool {
+	if _, ok := FindStringInSlice(csvNames, expectedCSV); ok {
+		return true
+	}
+	expectedMatch := csvNameRegex.FindStringSubmatch(expectedCSV)
+	if expectedMatch == nil {
+		return false
+	}
+	for _, csvName := range csvNames {
+		match := csvNameRegex.FindStringSubmatch(csvName)
+		if match == nil || match[1] != expectedMatch[1] {
+			continue
+		}
+		if compareCSVVersions(match[2:], expectedMatch[2:]) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCSVOlderThan checks if a ClusterServiceVersion is an older version of the operator than another one. The names
+// that can't be compared, such as the ones of different operators, are considered older unless they are the same.
+func IsCSVOlderThan(csvName, otherCSVName string) bool {
+	if csvName == otherCSVName {
+		return false
+	}
+	match := csvNameRegex.FindStringSubmatch(csvName)
+	otherMatch := csvNameRegex.FindStringSubmatch(otherCSVName)
+	if match == nil || otherMatch == nil || match[1] != otherMatch[1] {
+		return true
+	}
+	return compareCSVVersions(match[2:], otherMatch[2:]) < 0
+}
+
+// compareCSVVersions compares the major, minor and patch versions and then the build suffixes of two
+// ClusterServiceVersions as split by csvNameRegex. Unlike a semver pre-release, a build suffix doesn't make the
+// version older: "4.9.0-202201210133" is a build of 4.9.0, newer than the bare 4.9.0.
+// returns: -1, 0 or 1 as a is older than, the same as or newer than b
+func compareCSVVersions(a, b []string) int {
+	for i := 0; i < 3; i++ {
+		if result := compareCSVVersionParts(a[i], b[i]); result != 0 {
+			return result
+		}
+	}
+	switch {
+	case a[3] == b[3]:
+		return 0
+	case a[3] == "":
+		return -1
+	case b[3] == "":
+		return 1
+	}
+	aParts, bParts := strings.Split(a[3], "."), strings.Split(b[3], ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if result := compareCSVVersionParts(aParts[i], bParts[i]); result != 0 {
+			return result
+		}
+	}
+	switch {
+	case len(aParts) < len(bParts):
+		return -1
+	case len(aParts) > len(bParts):
+		return 1
+	}
+	return 0
+}
+
+// compareCSVVersionParts compares two parts of a version numerically when both are numbers, lexically otherwise
+func compareCSVVersionParts(a, b string) int {
+	aNumber, aErr := strconv.ParseUint(a, 10, 64)
+	bNumber, bErr := strconv.ParseUint(b, 10, 64)
+	if aErr == nil && bErr == nil {
+		switch {
+		case aNumber < bNumber:
+			return -1
+		case aNumber > bNumber:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(a, b)
+}
+
 // EnsureManagedClusterView creates or updates a view.
 func EnsureManagedClusterView(
 	ctx context.Context, c client.Client, safeName, name, namespace, resourceType,
@@ -342,7 +342,7 @@ func TestEnsureInstallPlanIsApproved(t *testing.T) {
 			clusterName: "spoke1",
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				subscription operatorsv1alpha1.Subscription, clusterName string, mcvForInstallPlan *viewv1beta1.ManagedClusterView) {
-				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName)
+				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName, "")
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -393,7 +393,7 @@ func TestEnsureInstallPlanIsApproved(t *testing.T) {
 			clusterName: "spoke1",
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				subscription operatorsv1alpha1.Subscription, clusterName string, mcvForInstallPlan *viewv1beta1.ManagedClusterView) {
-				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName)
+				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName, "")
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -443,7 +443,7 @@ func TestEnsureInstallPlanIsApproved(t *testing.T) {
 			clusterName: "spoke1",
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				subscription operatorsv1alpha1.Subscription, clusterName string, mcvForInstallPlan *viewv1beta1.ManagedClusterView) {
-				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName)
+				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName, "")
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -494,7 +494,7 @@ func TestEnsureInstallPlanIsApproved(t *testing.T) {
 			clusterName: "spoke1",
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				subscription operatorsv1alpha1.Subscription, clusterName string, mcvForInstallPlan *viewv1beta1.ManagedClusterView) {
-				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName)
+				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName, "")
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -551,7 +551,7 @@ func TestEnsureInstallPlanIsApproved(t *testing.T) {
 			clusterName: "spoke1",
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				subscription operatorsv1alpha1.Subscription, clusterName string, mcvForInstallPlan *viewv1beta1.ManagedClusterView) {
-				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName)
+				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName, "")
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -608,7 +608,7 @@ func TestEnsureInstallPlanIsApproved(t *testing.T) {
 			clusterName: "spoke1",
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				subscription operatorsv1alpha1.Subscription, clusterName string, mcvForInstallPlan *viewv1beta1.ManagedClusterView) {
-				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName)
+				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName, "")
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -665,7 +665,7 @@ func TestEnsureInstallPlanIsApproved(t *testing.T) {
 			clusterName: "spoke1",
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				subscription operatorsv1alpha1.Subscription, clusterName string, mcvForInstallPlan *viewv1beta1.ManagedClusterView) {
-				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName)
+				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName, "")
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -687,6 +687,124 @@ func TestEnsureInstallPlanIsApproved(t *testing.T) {
 				assert.Equal(t, mcaForInstallPlan.ObjectMeta.Namespace, clusterName)
 			},
 		},
+		{
+			name: "MCA was created to approve InstallPlan installing the expected CSV",
+			cgu: &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cgu", Namespace: "default",
+				},
+			},
+			subscription: operatorsv1alpha1.Subscription{
+				Status: operatorsv1alpha1.SubscriptionStatus{
+					InstallPlanRef: &corev1.ObjectReference{
+						Kind:      "InstallPlan",
+						Name:      "installPlan-xyz",
+						Namespace: "installPlan-xyz-namespace",
+					},
+					Install: &operatorsv1alpha1.InstallPlanReference{
+						Kind: "InstallPlan",
+						Name: "installPlan-xyz",
+					},
+				},
+			},
+			mcvForInstallPlan: &viewv1beta1.ManagedClusterView{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "installPlan-xyz", Namespace: "spoke1",
+				},
+				Spec: viewv1beta1.ViewSpec{
+					Scope: viewv1beta1.ViewScope{
+						Resource:  "InstallPlan",
+						Name:      "installPlan-xyz",
+						Namespace: "installPlan-xyz-namespace",
+					},
+				},
+				Status: viewv1beta1.ViewStatus{
+					Conditions: []v1.Condition{
+						{
+							Type:   viewv1beta1.ConditionViewProcessing,
+							Reason: viewv1beta1.ReasonGetResource,
+							Status: "True",
+						},
+					},
+					Result: runtime.RawExtension{Raw: []byte(
+						`{"apiVersion": "operators.coreos.com/v1alpha1","kind": "InstallPlan",
+                          "metadata": {"name": "installPlan-xyz","namespace":"installPlan-xyz-namespace",
+						  "resourceVersion": "3850433"}, "spec": {"approval": "Manual","approved": false,
+						  "clusterServiceVersionNames": ["ptp-operator.4.9.0-202201210133"]}}`,
+					)},
+				},
+			},
+			clusterName: "spoke1",
+			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
+				subscription operatorsv1alpha1.Subscription, clusterName string, mcvForInstallPlan *viewv1beta1.ManagedClusterView) {
+				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName, "ptp-operator.4.9.0-202201210133")
+				if err != nil {
+					t.Errorf("Error occurred and it wasn't expected")
+				}
+				assert.Equal(t, result, InstallPlanWasApproved)
+			},
+		},
+		{
+			name: "InstallPlan not installing the expected CSV is not approved",
+			cgu: &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cgu", Namespace: "default",
+				},
+			},
+			subscription: operatorsv1alpha1.Subscription{
+				Status: operatorsv1alpha1.SubscriptionStatus{
+					InstallPlanRef: &corev1.ObjectReference{
+						Kind:      "InstallPlan",
+						Name:      "installPlan-xyz",
+						Namespace: "installPlan-xyz-namespace",
+					},
+					Install: &operatorsv1alpha1.InstallPlanReference{
+						Kind: "InstallPlan",
+						Name: "installPlan-xyz",
+					},
+				},
+			},
+			mcvForInstallPlan: &viewv1beta1.ManagedClusterView{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "installPlan-xyz", Namespace: "spoke1",
+				},
+				Spec: viewv1beta1.ViewSpec{
+					Scope: viewv1beta1.ViewScope{
+						Resource:  "InstallPlan",
+						Name:      "installPlan-xyz",
+						Namespace: "installPlan-xyz-namespace",
+					},
+				},
+				Status: viewv1beta1.ViewStatus{
+					Conditions: []v1.Condition{
+						{
+							Type:   viewv1beta1.ConditionViewProcessing,
+							Reason: viewv1beta1.ReasonGetResource,
+							Status: "True",
+						},
+					},
+					Result: runtime.RawExtension{Raw: []byte(
+						`{"apiVersion": "operators.coreos.com/v1alpha1","kind": "InstallPlan",
+                          "metadata": {"name": "installPlan-xyz","namespace":"installPlan-xyz-namespace",
+						  "resourceVersion": "3850433"}, "spec": {"approval": "Manual","approved": false,
+						  "clusterServiceVersionNames": ["ptp-operator.4.9.0-202201210133"]}}`,
+					)},
+				},
+			},
+			clusterName: "spoke1",
+			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
+				subscription operatorsv1alpha1.Subscription, clusterName string, mcvForInstallPlan *viewv1beta1.ManagedClusterView) {
+				result, err := EnsureInstallPlanIsApproved(context.TODO(), runtimeClient, cgu, subscription, clusterName, "ptp-operator.4.8.0-202112140011")
+				if err != nil {
+					t.Errorf("Error occurred and it wasn't expected")
+				}
+				assert.Equal(t, result, InstallPlanUnexpectedCSV)
+
+				mcaForInstallPlan := &actionv1beta1.ManagedClusterAction{}
+				err = runtimeClient.Get(context.TODO(), types.NamespacedName{Name: "installPlan-xyz", Namespace: clusterName}, mcaForInstallPlan)
+				assert.True(t, errors.IsNotFound(err))
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -744,13 +862,13 @@ func TestProcessSubscriptionManagedClusterView(t *testing.T) {
 			clusterName: "spoke1",
 			mockFunc: func() {
 				EnsureInstallPlanIsApproved = func(ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
-					subscription operatorsv1alpha1.Subscription, clusterName string) (int, error) {
+					subscription operatorsv1alpha1.Subscription, clusterName, expectedCSV string) (int, error) {
 					return InstallPlanCannotBeApproved, nil
 				}
 			},
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				clusterName string, mcvForSubscription *viewv1beta1.ManagedClusterView) {
-				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription)
+				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription, "")
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -788,13 +906,13 @@ func TestProcessSubscriptionManagedClusterView(t *testing.T) {
 			clusterName: "spoke1",
 			mockFunc: func() {
 				EnsureInstallPlanIsApproved = func(ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
-					subscription operatorsv1alpha1.Subscription, clusterName string) (int, error) {
+					subscription operatorsv1alpha1.Subscription, clusterName, expectedCSV string) (int, error) {
 					return InstallPlanCannotBeApproved, nil
 				}
 			},
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				clusterName string, mcvForSubscription *viewv1beta1.ManagedClusterView) {
-				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription)
+				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription, "")
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -840,13 +958,13 @@ func TestProcessSubscriptionManagedClusterView(t *testing.T) {
 			clusterName: "spoke1",
 			mockFunc: func() {
 				EnsureInstallPlanIsApproved = func(ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
-					subscription operatorsv1alpha1.Subscription, clusterName string) (int, error) {
+					subscription operatorsv1alpha1.Subscription, clusterName, expectedCSV string) (int, error) {
 					return InstallPlanCannotBeApproved, nil
 				}
 			},
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				clusterName string, mcvForSubscription *viewv1beta1.ManagedClusterView) {
-				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription)
+				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription, "")
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -890,13 +1008,13 @@ func TestProcessSubscriptionManagedClusterView(t *testing.T) {
 			clusterName: "spoke1",
 			mockFunc: func() {
 				EnsureInstallPlanIsApproved = func(ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
-					subscription operatorsv1alpha1.Subscription, clusterName string) (int, error) {
+					subscription operatorsv1alpha1.Subscription, clusterName, expectedCSV string) (int, error) {
 					return InstallPlanCannotBeApproved, nil
 				}
 			},
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				clusterName string, mcvForSubscription *viewv1beta1.ManagedClusterView) {
-				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription)
+				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription, "")
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -943,13 +1061,13 @@ func TestProcessSubscriptionManagedClusterView(t *testing.T) {
 			clusterName: "spoke1",
 			mockFunc: func() {
 				EnsureInstallPlanIsApproved = func(ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
-					subscription operatorsv1alpha1.Subscription, clusterName string) (int, error) {
+					subscription operatorsv1alpha1.Subscription, clusterName, expectedCSV string) (int, error) {
 					return InstallPlanCannotBeApproved, fmt.Errorf("EnsureInstallPlanIsApproved returned error")
 				}
 			},
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				clusterName string, mcvForSubscription *viewv1beta1.ManagedClusterView) {
-				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription)
+				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription, "")
 				if err == nil {
 					t.Errorf("Error was expected, but it didn't happen")
 				}
@@ -996,13 +1114,13 @@ func TestProcessSubscriptionManagedClusterView(t *testing.T) {
 			clusterName: "spoke1",
 			mockFunc: func() {
 				EnsureInstallPlanIsApproved = func(ctx context.Context, c client.Client, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
-					subscription operatorsv1alpha1.Subscription, clusterName string) (int, error) {
+					subscription operatorsv1alpha1.Subscription, clusterName, expectedCSV string) (int, error) {
 					return InstallPlanWasApproved, nil
 				}
 			},
 			validateFunc: func(t *testing.T, runtimeClient client.Client, cgu *ranv1alpha1.ClusterGroupUpgrade,
 				clusterName string, mcvForSubscription *viewv1beta1.ManagedClusterView) {
-				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription)
+				result, err := ProcessSubscriptionManagedClusterView(context.TODO(), runtimeClient, cgu, clusterName, mcvForSubscription, "")
 				if err != nil {
 					t.Errorf("Error was not expected, but it happened")
 				}
@@ -1029,6 +1147,355 @@ func TestProcessSubscriptionManagedClusterView(t *testing.T) {
 	}
 }
 
+func TestProcessClusterVersionManagedClusterView(t *testing.T) {
+	percentage := func(p int) *int { return &p }
+	retrievedCondition := []metav1.Condition{{
+		Type:   viewv1beta1.ConditionViewProcessing,
+		Status: metav1.ConditionTrue,
+		Reason: viewv1beta1.ReasonGetResource,
+	}}
+
+	testcases := []struct {
+		name            string
+		conditions      []metav1.Condition
+		clusterVersion  string
+		expectedFailing bool
+		expectedResult  *ranv1alpha1.ClusterVersionProgress
+	}{
+		{
+			name: "ManagedClusterView has not retrieved the ClusterVersion",
+		},
+		{
+			name:       "upgrade in progress",
+			conditions: retrievedCondition,
+			clusterVersion: `{"status": {"desired": {"version": "4.14.2"},
+				"history": [{"state": "Partial", "version": "4.14.2"}, {"state": "Completed", "version": "4.14.1"}],
+				"conditions": [
+					{"type": "Progressing", "status": "True", "message": "Working towards 4.14.2: 700 of 859 done (81% complete)"},
+					{"type": "Failing", "status": "False"}]}}`,
+			expectedResult: &ranv1alpha1.ClusterVersionProgress{
+				CurrentVersion:      "4.14.1",
+				DesiredVersion:      "4.14.2",
+				Message:             "Working towards 4.14.2: 700 of 859 done (81% complete)",
+				CompletedPercentage: percentage(81),
+			},
+		},
+		{
+			name:       "upgrade completed",
+			conditions: retrievedCondition,
+			clusterVersion: `{"status": {"desired": {"version": "4.14.2"},
+				"history": [{"state": "Completed", "version": "4.14.2"}, {"state": "Completed", "version": "4.14.1"}],
+				"conditions": [{"type": "Progressing", "status": "False", "message": "Cluster version is 4.14.2"}]}}`,
+			expectedResult: &ranv1alpha1.ClusterVersionProgress{
+				CurrentVersion:      "4.14.2",
+				DesiredVersion:      "4.14.2",
+				Message:             "Cluster version is 4.14.2",
+				CompletedPercentage: percentage(100),
+			},
+		},
+		{
+			name:       "release not accepted",
+			conditions: retrievedCondition,
+			clusterVersion: `{"status": {"desired": {"version": "4.14.1"},
+				"history": [{"state": "Completed", "version": "4.14.1"}],
+				"conditions": [{"type": "ReleaseAccepted", "status": "False", "message": "Retrieving payload failed"}]}}`,
+			expectedResult: &ranv1alpha1.ClusterVersionProgress{
+				CurrentVersion: "4.14.1",
+				DesiredVersion: "4.14.1",
+				Failures:       []string{"ReleaseAccepted=False: Retrieving payload failed"},
+			},
+		},
+		{
+			name:       "upgrade failing",
+			conditions: retrievedCondition,
+			clusterVersion: `{"status": {"desired": {"version": "4.14.2"},
+				"history": [{"state": "Partial", "version": "4.14.2"}, {"state": "Completed", "version": "4.14.1"}],
+				"conditions": [
+					{"type": "Progressing", "status": "True", "message": "Unable to apply 4.14.2: an unknown error has occurred"},
+					{"type": "Failing", "status": "True", "message": "Cluster operator etcd is degraded"}]}}`,
+			expectedFailing: true,
+			expectedResult: &ranv1alpha1.ClusterVersionProgress{
+				CurrentVersion: "4.14.1",
+				DesiredVersion: "4.14.2",
+				Message:        "Unable to apply 4.14.2: an unknown error has occurred",
+				Failures:       []string{"Failing=True: Cluster operator etcd is degraded"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mcv := &viewv1beta1.ManagedClusterView{
+				ObjectMeta: v1.ObjectMeta{Name: "cgu-default-clusterversion-version", Namespace: "spoke1"},
+				Status: viewv1beta1.ViewStatus{
+					Conditions: tc.conditions,
+					Result:     runtime.RawExtension{Raw: []byte(tc.clusterVersion)},
+				},
+			}
+			result, isFailing, err := ProcessClusterVersionManagedClusterView(mcv)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFailing, isFailing)
+			assert.Equal(t, tc.expectedResult, result)
+		})
+	}
+}
+
+func TestGetOperatorUpgradeProgress(t *testing.T) {
+	retrievedCondition := []metav1.Condition{{
+		Type:   viewv1beta1.ConditionViewProcessing,
+		Status: metav1.ConditionTrue,
+		Reason: viewv1beta1.ReasonGetResource,
+	}}
+	subscriptionView := func(conditions []metav1.Condition) *viewv1beta1.ManagedClusterView {
+		return &viewv1beta1.ManagedClusterView{
+			ObjectMeta: v1.ObjectMeta{Name: "cgu-default-subscription-ptp", Namespace: "spoke1"},
+			Status: viewv1beta1.ViewStatus{
+				Conditions: conditions,
+				Result: runtime.RawExtension{Raw: []byte(`{"metadata": {"name": "ptp", "namespace": "openshift-ptp"},
+					"status": {"currentCSV": "ptp-operator.v4.14.2", "installedCSV": "ptp-operator.v4.14.1",
+					"installplan": {"name": "install-abcde"}}}`)},
+			},
+		}
+	}
+
+	testcases := []struct {
+		name           string
+		mcv            *viewv1beta1.ManagedClusterView
+		objects        func(cgu *ranv1alpha1.ClusterGroupUpgrade) []client.Object
+		expectedResult *ranv1alpha1.OperatorUpgradeProgress
+		expectedErr    string
+	}{
+		{
+			name:    "ManagedClusterView has not retrieved the Subscription",
+			mcv:     subscriptionView(nil),
+			objects: func(cgu *ranv1alpha1.ClusterGroupUpgrade) []client.Object { return nil },
+		},
+		{
+			name:    "views on the InstallPlan and the CSV are created",
+			mcv:     subscriptionView(retrievedCondition),
+			objects: func(cgu *ranv1alpha1.ClusterGroupUpgrade) []client.Object { return nil },
+			expectedResult: &ranv1alpha1.OperatorUpgradeProgress{
+				Subscription: "ptp",
+				Namespace:    "openshift-ptp",
+				InstalledCSV: "ptp-operator.v4.14.1",
+				TargetCSV:    "ptp-operator.v4.14.2",
+				InstallPlan:  "install-abcde",
+				CurrentHop:   "ptp-operator.v4.14.1 -> ptp-operator.v4.14.2",
+			},
+		},
+		{
+			name: "phases are reported from the views",
+			mcv:  subscriptionView(retrievedCondition),
+			objects: func(cgu *ranv1alpha1.ClusterGroupUpgrade) []client.Object {
+				csvViewName := GetSafeResourceName(
+					GetMultiCloudObjectName(cgu, "ClusterServiceVersion", "ptp-operator.v4.14.2"), "", cgu, MaxObjectNameLength)
+				return []client.Object{
+					&viewv1beta1.ManagedClusterView{
+						ObjectMeta: v1.ObjectMeta{Name: "install-abcde", Namespace: "spoke1"},
+						Status: viewv1beta1.ViewStatus{
+							Conditions: retrievedCondition,
+							Result:     runtime.RawExtension{Raw: []byte(`{"status": {"phase": "Complete"}}`)},
+						},
+					},
+					&viewv1beta1.ManagedClusterView{
+						ObjectMeta: v1.ObjectMeta{Name: csvViewName, Namespace: "spoke1"},
+						Status: viewv1beta1.ViewStatus{
+							Conditions: retrievedCondition,
+							Result: runtime.RawExtension{Raw: []byte(
+								`{"status": {"phase": "Failed", "message": "install strategy failed"}}`)},
+						},
+					},
+				}
+			},
+			expectedResult: &ranv1alpha1.OperatorUpgradeProgress{
+				Subscription:     "ptp",
+				Namespace:        "openshift-ptp",
+				InstalledCSV:     "ptp-operator.v4.14.1",
+				TargetCSV:        "ptp-operator.v4.14.2",
+				CSVPhase:         "Failed",
+				InstallPlan:      "install-abcde",
+				InstallPlanPhase: "Complete",
+				Message:          "install strategy failed",
+				CurrentHop:       "ptp-operator.v4.14.1 -> ptp-operator.v4.14.2",
+			},
+		},
+		{
+			name: "malformed view",
+			mcv:  subscriptionView(retrievedCondition),
+			objects: func(cgu *ranv1alpha1.ClusterGroupUpgrade) []client.Object {
+				csvViewName := GetSafeResourceName(
+					GetMultiCloudObjectName(cgu, "ClusterServiceVersion", "ptp-operator.v4.14.2"), "", cgu, MaxObjectNameLength)
+				return []client.Object{
+					&viewv1beta1.ManagedClusterView{
+						ObjectMeta: v1.ObjectMeta{Name: csvViewName, Namespace: "spoke1"},
+						Status: viewv1beta1.ViewStatus{
+							Conditions: retrievedCondition,
+							Result:     runtime.RawExtension{Raw: []byte(`{"status": {"phase": 1}}`)},
+						},
+					},
+				}
+			},
+			expectedErr: "failed to unmarshal the ClusterServiceVersion",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: v1.ObjectMeta{
+					Name: "cgu", Namespace: "default",
+					Annotations: map[string]string{NameSuffixAnnotation: "kuttl"},
+				},
+			}
+			fakeClient, _ := getFakeClientFromObjects(tc.objects(cgu)...)
+			result, err := GetOperatorUpgradeProgress(context.TODO(), fakeClient, cgu, "spoke1", tc.mcv)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedResult, result)
+			if tc.expectedResult == nil {
+				return
+			}
+
+			mcv := &viewv1beta1.ManagedClusterView{}
+			err = fakeClient.Get(context.TODO(), types.NamespacedName{Name: "install-abcde", Namespace: "spoke1"}, mcv)
+			assert.NoError(t, err)
+			assert.Equal(t, "openshift-ptp", mcv.Spec.Scope.Namespace)
+			err = fakeClient.Get(context.TODO(), types.NamespacedName{
+				Name: GetSafeResourceName(
+					GetMultiCloudObjectName(cgu, "ClusterServiceVersion", "ptp-operator.v4.14.2"), "", cgu, MaxObjectNameLength),
+				Namespace: "spoke1"}, mcv)
+			assert.NoError(t, err)
+			assert.Equal(t, "ClusterServiceVersion.operators.coreos.com", mcv.Spec.Scope.Resource)
+			assert.Equal(t, "ptp-operator.v4.14.2", mcv.Spec.Scope.Name)
+		})
+	}
+}
+
+func TestIsInstallPlanTowardsCSV(t *testing.T) {
+	testcases := []struct {
+		name        string
+		csvNames    []string
+		expectedCSV string
+		expected    bool
+	}{
+		{
+			name:        "installs the expected CSV",
+			csvNames:    []string{"ptp-operator.v4.14.2"},
+			expectedCSV: "ptp-operator.v4.14.2",
+			expected:    true,
+		},
+		{
+			name:        "intermediate hop to the expected CSV",
+			csvNames:    []string{"ptp-operator.4.14.0-202310201027"},
+			expectedCSV: "ptp-operator.v4.14.2",
+			expected:    true,
+		},
+		{
+			name:        "jumps past the expected CSV",
+			csvNames:    []string{"ptp-operator.v4.15.0"},
+			expectedCSV: "ptp-operator.v4.14.2",
+			expected:    false,
+		},
+		{
+			name:        "installs another operator",
+			csvNames:    []string{"sriov-network-operator.v4.14.0"},
+			expectedCSV: "ptp-operator.v4.14.2",
+			expected:    false,
+		},
+		{
+			name:        "older build of the expected version",
+			csvNames:    []string{"ptp-operator.4.14.0-202310201027"},
+			expectedCSV: "ptp-operator.4.14.0-202311151204",
+			expected:    true,
+		},
+		{
+			name:        "newer build of the expected version",
+			csvNames:    []string{"ptp-operator.4.14.0-202312011530"},
+			expectedCSV: "ptp-operator.4.14.0-202311151204",
+			expected:    false,
+		},
+		{
+			name:        "build of the expected bare version",
+			csvNames:    []string{"ptp-operator.4.14.2-202312011530"},
+			expectedCSV: "ptp-operator.v4.14.2",
+			expected:    false,
+		},
+		{
+			name:        "bare version of the expected build",
+			csvNames:    []string{"ptp-operator.v4.14.0"},
+			expectedCSV: "ptp-operator.4.14.0-202311151204",
+			expected:    true,
+		},
+		{
+			name:        "expected CSV has no version",
+			csvNames:    []string{"ptp-operator.v4.14.0"},
+			expectedCSV: "ptp-operator",
+			expected:    false,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isInstallPlanTowardsCSV(tc.csvNames, tc.expectedCSV))
+		})
+	}
+}
+
+func TestIsCSVOlderThan(t *testing.T) {
+	testcases := []struct {
+		name      string
+		csvName   string
+		otherName string
+		expected  bool
+	}{
+		{
+			name:      "same CSV",
+			csvName:   "ptp-operator.v4.14.2",
+			otherName: "ptp-operator.v4.14.2",
+			expected:  false,
+		},
+		{
+			name:      "older CSV",
+			csvName:   "ptp-operator.v4.14.1",
+			otherName: "ptp-operator.v4.14.2",
+			expected:  true,
+		},
+		{
+			name:      "newer CSV",
+			csvName:   "ptp-operator.v4.15.0",
+			otherName: "ptp-operator.v4.14.2",
+			expected:  false,
+		},
+		{
+			name:      "newer build",
+			csvName:   "ptp-operator.4.14.2-202312011530",
+			otherName: "ptp-operator.v4.14.2",
+			expected:  false,
+		},
+		{
+			name:      "no CSV installed",
+			csvName:   "",
+			otherName: "ptp-operator.v4.14.2",
+			expected:  true,
+		},
+		{
+			name:      "another operator",
+			csvName:   "sriov-network-operator.v4.15.0",
+			otherName: "ptp-operator.v4.14.2",
+			expected:  true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, IsCSVOlderThan(tc.csvName, tc.otherName))
+		})
+	}
+}
+
 func TestMultiCloudUtilGetMultiCloudObjectName(t *testing.T) {
 	testcase := struct {
 		cgu            ranv1alpha1.ClusterGroupUpgrade
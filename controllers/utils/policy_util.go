@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"regexp"
 	"strconv"
 	"strings"
@@ -11,9 +12,11 @@ import (
 
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	policiesv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -103,7 +106,7 @@ func DeletePlacementBindings(ctx context.Context, c client.Client, ns string, la
 	return nil
 }
 
-// DeletePlacementRules deletes PlacementRules
+// DeletePlacementRules deletes PlacementRules and Placements
 func DeletePlacementRules(ctx context.Context, c client.Client, ns string, labels map[string]string) error {
 	deleteAllOpts := []client.DeleteAllOfOption{
 		client.InNamespace(ns),
@@ -111,17 +114,104 @@ func DeletePlacementRules(ctx context.Context, c client.Client, ns string, label
 	}
 
 	placementRule := &unstructured.Unstructured{}
-	placementRule.SetGroupVersionKind(schema.GroupVersionKind{
-		Group:   "apps.open-cluster-management.io",
-		Kind:    "PlacementRule",
-		Version: "v1",
+	placementRule.SetGroupVersionKind(PlacementRuleGroupVersionKind())
+	if err := c.DeleteAllOf(ctx, placementRule, deleteAllOpts...); client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return DeletePlacements(ctx, c, ns, labels)
+}
+
+// DeletePlacements removes the ManagedCluster labels selected by the Placements and deletes the Placements
+func DeletePlacements(ctx context.Context, c client.Client, ns string, labels map[string]string) error {
+	placements, err := ListPlacements(ctx, c, ns, labels)
+	if err != nil {
+		return err
+	}
+	for _, placement := range placements.Items {
+		if err := RemovePlacementClusterLabel(ctx, c, placement.GetNamespace(), placement.GetName()); err != nil {
+			return err
+		}
+	}
+
+	deleteAllOpts := []client.DeleteAllOfOption{
+		client.InNamespace(ns),
+		client.MatchingLabels(labels),
+	}
+	placement := &unstructured.Unstructured{}
+	placement.SetGroupVersionKind(PlacementGroupVersionKind())
+	if err := c.DeleteAllOf(ctx, placement, deleteAllOpts...); client.IgnoreNotFound(err) != nil && !meta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
+
+// ListPlacements lists the Placements matching the labels, the list is empty if the Placement API is not served
+func ListPlacements(ctx context.Context, c client.Client, ns string, labels map[string]string) (*unstructured.UnstructuredList, error) {
+	listOpts := []client.ListOption{
+		client.InNamespace(ns),
+		client.MatchingLabels(labels),
+	}
+	placementsList := &unstructured.UnstructuredList{}
+	placementsList.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   PlacementGroupVersionKind().Group,
+		Kind:    PlacementGroupVersionKind().Kind + "List",
+		Version: PlacementGroupVersionKind().Version,
 	})
-	if err := c.DeleteAllOf(ctx, placementRule, deleteAllOpts...); client.IgnoreNotFound(err) != nil {
+	if err := c.List(ctx, placementsList, listOpts...); err != nil && !meta.IsNoMatchError(err) {
+		return nil, err
+	}
+	return placementsList, nil
+}
+
+// GetPlacementClusterLabel returns the ManagedCluster label selected by a batch Placement. The label is
+// derived from a hash of the Placement namespace and name to fit in the label name length limit.
+func GetPlacementClusterLabel(namespace, name string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(PrefixNameWithNamespace(namespace, name)))
+	return fmt.Sprintf("%s%08x", PlacementClusterLabelPrefix, hash.Sum32())
+}
+
+// RemovePlacementClusterLabel removes the label selected by a batch Placement from all the ManagedClusters
+func RemovePlacementClusterLabel(ctx context.Context, c client.Client, namespace, name string) error {
+	label := GetPlacementClusterLabel(namespace, name)
+	clusters := &clusterv1.ManagedClusterList{}
+	if err := c.List(ctx, clusters, client.HasLabels{label}); err != nil {
 		return err
 	}
+	for i := range clusters.Items {
+		cluster := &clusters.Items[i]
+		patch := client.MergeFrom(cluster.DeepCopy())
+		delete(cluster.Labels, label)
+		if err := c.Patch(ctx, cluster, patch); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to remove label %s from cluster %s: %v", label, cluster.Name, err)
+		}
+	}
 	return nil
 }
 
+// UsePlacementAPI resolves the placement API flag. In auto mode, the Placement API is used if the
+// hub doesn't serve the PlacementRule API.
+func UsePlacementAPI(mapper meta.RESTMapper, placementAPI string) (bool, error) {
+	switch placementAPI {
+	case PlacementAPIPlacement:
+		return true, nil
+	case PlacementAPIPlacementRule:
+		return false, nil
+	case PlacementAPIAuto:
+		gvk := PlacementRuleGroupVersionKind()
+		_, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err == nil {
+			return false, nil
+		}
+		if meta.IsNoMatchError(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return false, fmt.Errorf("invalid placement API %s, must be one of %s, %s, %s",
+		placementAPI, PlacementAPIAuto, PlacementAPIPlacementRule, PlacementAPIPlacement)
+}
+
 // GetResourceName constructs composite names for policy objects
 func GetResourceName(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, initialString string) string {
 	return strings.ToLower(clusterGroupUpgrade.Name + "-" + initialString)
@@ -231,6 +321,24 @@ func ShouldSoak(policy *unstructured.Unstructured, firstCompliantAt metav1.Time)
 	return true, nil
 }
 
+// IsRemediationTimedOut returns whether a cluster that started remediating the policy at policyStartedAt
+// has exceeded the remediation timeout of the policy
+func IsRemediationTimedOut(policy *unstructured.Unstructured, policyStartedAt metav1.Time) (bool, error) {
+	timeout, ok := policy.GetAnnotations()[RemediationTimeoutAnnotation]
+	if !ok {
+		return false, nil
+	}
+	timeoutSeconds, err := strconv.Atoi(timeout)
+	if err != nil || timeoutSeconds <= 0 {
+		return false, errors.New("remediation timeout annotation value " + timeout + " is invalid, value should be an integer greater than 0")
+	}
+
+	if policyStartedAt.IsZero() {
+		return false, nil
+	}
+	return time.Since(policyStartedAt.Time) > time.Duration(timeoutSeconds)*time.Second, nil
+}
+
 // UpdateManagedPolicyNamespaceList updates policyNs with the corresponding namespaces of a managed policy
 // as contained in the policyNameArr parameter.
 func UpdateManagedPolicyNamespaceList(policyNs map[string][]string, policyNameArr []string) {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -57,6 +58,40 @@ func TestShouldSoak(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestIsRemediationTimedOut(t *testing.T) {
+	// no annotation
+	res, err := IsRemediationTimedOut(&unstructured.Unstructured{}, v1.NewTime(time.Now().Add(-time.Hour)))
+	assert.Equal(t, false, res)
+	assert.NoError(t, err)
+
+	// annotation present and timeout is not over
+	policy := &unstructured.Unstructured{}
+	policy.SetAnnotations(map[string]string{
+		RemediationTimeoutAnnotation: "600",
+	})
+	res, err = IsRemediationTimedOut(policy, v1.Now())
+	assert.Equal(t, false, res)
+	assert.NoError(t, err)
+
+	// annotation present, policyStartedAt is zero
+	res, err = IsRemediationTimedOut(policy, v1.Time{})
+	assert.Equal(t, false, res)
+	assert.NoError(t, err)
+
+	// annotation present and timeout is over
+	policyStartedAt := time.Now().Add(time.Duration(-601) * time.Second)
+	res, err = IsRemediationTimedOut(policy, v1.NewTime(policyStartedAt))
+	assert.Equal(t, true, res)
+	assert.NoError(t, err)
+
+	// annotation present, timeout is invalid
+	policy.SetAnnotations(map[string]string{
+		RemediationTimeoutAnnotation: "0",
+	})
+	_, err = IsRemediationTimedOut(policy, v1.Now())
+	assert.Error(t, err)
+}
+
 func TestUpdateManagedPolicyNamespaceList(t *testing.T) {
 	testcases := []struct {
 		policiesNs     map[string][]string
@@ -294,3 +329,40 @@ func TestStripObjectTemplatesRaw(t *testing.T) {
 		})
 	}
 }
+
+func TestGetPlacementClusterLabel(t *testing.T) {
+	label := GetPlacementClusterLabel("default", "cgu-policy1-placement-kuttl")
+	assert.True(t, strings.HasPrefix(label, PlacementClusterLabelPrefix))
+	assert.LessOrEqual(t, len(strings.SplitN(label, "/", 2)[1]), 63)
+	assert.Equal(t, label, GetPlacementClusterLabel("default", "cgu-policy1-placement-kuttl"))
+	assert.NotEqual(t, label, GetPlacementClusterLabel("other", "cgu-policy1-placement-kuttl"))
+}
+
+func TestUsePlacementAPI(t *testing.T) {
+	withPlacementRule := meta.NewDefaultRESTMapper(nil)
+	withPlacementRule.Add(PlacementRuleGroupVersionKind(), meta.RESTScopeNamespace)
+	withoutPlacementRule := meta.NewDefaultRESTMapper(nil)
+
+	testcases := []struct {
+		placementAPI string
+		mapper       meta.RESTMapper
+		expected     bool
+		expectErr    bool
+	}{
+		{placementAPI: PlacementAPIPlacement, mapper: withPlacementRule, expected: true},
+		{placementAPI: PlacementAPIPlacementRule, mapper: withoutPlacementRule, expected: false},
+		{placementAPI: PlacementAPIAuto, mapper: withPlacementRule, expected: false},
+		{placementAPI: PlacementAPIAuto, mapper: withoutPlacementRule, expected: true},
+		{placementAPI: "PlacementDecision", mapper: withPlacementRule, expectErr: true},
+	}
+
+	for _, tc := range testcases {
+		usePlacementAPI, err := UsePlacementAPI(tc.mapper, tc.placementAPI)
+		if tc.expectErr {
+			assert.Error(t, err, tc.placementAPI)
+			continue
+		}
+		assert.NoError(t, err, tc.placementAPI)
+		assert.Equal(t, tc.expected, usePlacementAPI, tc.placementAPI)
+	}
+}
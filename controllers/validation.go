@@ -3,11 +3,13 @@ package controllers
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	mwv1 "open-cluster-management.io/api/work/v1"
 )
 
 type ocpVersionInfo struct {
@@ -19,85 +21,89 @@ type ocpVersionInfo struct {
 }
 
 func extractOCPVersionInfoFromPolicies(policies []*unstructured.Unstructured) (ocpVersionInfo, error) {
+	objects, err := stripPolicies(policies)
+	if err != nil {
+		return ocpVersionInfo{}, err
+	}
+	return extractOCPVersionInfoFromObjects(objects)
+}
+
+// extractOCPVersionInfoFromObjects validates the ClusterVersion objects and keeps track of their upstream, channel,
+// version and image
+func extractOCPVersionInfoFromObjects(objects []map[string]interface{}) (ocpVersionInfo, error) {
 
 	result := ocpVersionInfo{}
 
 	// validate ClusterVersionGroupVersionKind and keep track to upstream, channel, version, image
-	for _, policy := range policies {
-		objects, err := stripPolicy(policy.Object)
-		if err != nil {
-			return result, err
-		}
-		for _, object := range objects {
-			kind := object["kind"]
-			switch kind {
-			case utils.ClusterVersionGroupVersionKind().Kind:
-				_, foundSpec := object["spec"]
-				if !foundSpec || object["spec"] == nil {
-					continue
+	for _, object := range objects {
+		kind := object["kind"]
+		switch kind {
+		case utils.ClusterVersionGroupVersionKind().Kind:
+			_, foundSpec := object["spec"]
+			if !foundSpec || object["spec"] == nil {
+				continue
+			}
+
+			if object["spec"].(map[string]interface{})["upstream"] != nil {
+				nextUpstream := object["spec"].(map[string]interface{})["upstream"].(string)
+
+				if nextUpstream == utils.Placeholder {
+					return result, errors.New("templating cluster version fields not supported")
+				}
+
+				if result.upstream == "" {
+					result.upstream = nextUpstream
+				} else if result.upstream != nextUpstream {
+					return result, errors.New("platform image defined more then once with conflicting upstream values")
 				}
+			}
 
-				if object["spec"].(map[string]interface{})["upstream"] != nil {
-					nextUpstream := object["spec"].(map[string]interface{})["upstream"].(string)
+			if object["spec"].(map[string]interface{})["channel"] != nil {
+				nextChannel := object["spec"].(map[string]interface{})["channel"].(string)
 
-					if nextUpstream == utils.Placeholder {
-						return result, errors.New("templating cluster version fields not supported")
-					}
+				if nextChannel == utils.Placeholder {
+					return result, errors.New("templating cluster version fields not supported")
+				}
 
-					if result.upstream == "" {
-						result.upstream = nextUpstream
-					} else if result.upstream != nextUpstream {
-						return result, errors.New("platform image defined more then once with conflicting upstream values")
-					}
+				if result.channel == "" {
+					result.channel = nextChannel
+				} else if result.channel != nextChannel {
+					return result, errors.New("platform image defined more then once with conflicting channel values")
 				}
+			}
 
-				if object["spec"].(map[string]interface{})["channel"] != nil {
-					nextChannel := object["spec"].(map[string]interface{})["channel"].(string)
+			if object["spec"].(map[string]interface{})["desiredUpdate"] != nil {
+				if object["spec"].(map[string]interface{})["desiredUpdate"].(map[string]interface{})["version"] != nil {
+					nextVersion := object["spec"].(map[string]interface{})["desiredUpdate"].(map[string]interface{})["version"].(string)
 
-					if nextChannel == utils.Placeholder {
+					if nextVersion == utils.Placeholder {
 						return result, errors.New("templating cluster version fields not supported")
 					}
 
-					if result.channel == "" {
-						result.channel = nextChannel
-					} else if result.channel != nextChannel {
-						return result, errors.New("platform image defined more then once with conflicting channel values")
+					if result.version == "" {
+						result.version = nextVersion
+					} else if result.version != nextVersion {
+						return result, errors.New("platform image defined more then once with conflicting version values")
 					}
 				}
+				if object["spec"].(map[string]interface{})["desiredUpdate"].(map[string]interface{})["image"] != nil {
+					nextImage := object["spec"].(map[string]interface{})["desiredUpdate"].(map[string]interface{})["image"].(string)
 
-				if object["spec"].(map[string]interface{})["desiredUpdate"] != nil {
-					if object["spec"].(map[string]interface{})["desiredUpdate"].(map[string]interface{})["version"] != nil {
-						nextVersion := object["spec"].(map[string]interface{})["desiredUpdate"].(map[string]interface{})["version"].(string)
-
-						if nextVersion == utils.Placeholder {
-							return result, errors.New("templating cluster version fields not supported")
-						}
-
-						if result.version == "" {
-							result.version = nextVersion
-						} else if result.version != nextVersion {
-							return result, errors.New("platform image defined more then once with conflicting version values")
-						}
+					if nextImage == utils.Placeholder {
+						return result, errors.New("templating cluster version fields not supported")
 					}
-					if object["spec"].(map[string]interface{})["desiredUpdate"].(map[string]interface{})["image"] != nil {
-						nextImage := object["spec"].(map[string]interface{})["desiredUpdate"].(map[string]interface{})["image"].(string)
-
-						if nextImage == utils.Placeholder {
-							return result, errors.New("templating cluster version fields not supported")
-						}
-
-						if result.image == "" {
-							result.image = nextImage
-						} else if result.image != nextImage {
-							return result, errors.New("platform image defined more then once with conflicting image values")
-						}
+
+					if result.image == "" {
+						result.image = nextImage
+					} else if result.image != nextImage {
+						return result, errors.New("platform image defined more then once with conflicting image values")
 					}
 				}
-
-				result.clusterVersionCRFound = true
-			default:
-				continue
 			}
+
+			result.clusterVersionCRFound = true
+		default:
+			continue
 		}
 	}
 	return result, nil
@@ -106,8 +112,18 @@ func extractOCPVersionInfoFromPolicies(policies []*unstructured.Unstructured) (o
 // extractOCPImageFromPolicies validates that there's ClusterVersion policy, validates the content of ClusterVersion and extracts Image if needed
 func (r *ClusterGroupUpgradeReconciler) extractOCPImageFromPolicies(
 	policies []*unstructured.Unstructured) (string, error) {
+	objects, err := stripPolicies(policies)
+	if err != nil {
+		return "", err
+	}
+	return r.extractOCPImageFromObjects(objects)
+}
 
-	versionInfo, err := extractOCPVersionInfoFromPolicies(policies)
+// extractOCPImageFromObjects validates the content of the ClusterVersion objects and extracts Image if needed
+func (r *ClusterGroupUpgradeReconciler) extractOCPImageFromObjects(
+	objects []map[string]interface{}) (string, error) {
+
+	versionInfo, err := extractOCPVersionInfoFromObjects(objects)
 
 	if err != nil {
 		return "", err
@@ -137,6 +153,26 @@ func (r *ClusterGroupUpgradeReconciler) validateOpenshiftUpgradeVersion(
 	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, policies []*unstructured.Unstructured) error {
 
 	versionInfo, err := extractOCPVersionInfoFromPolicies(policies)
+	return r.validateOCPVersionInfo(clusterGroupUpgrade, versionInfo, "policy", err)
+}
+
+// validateManifestWorkOpenshiftUpgradeVersion applies the ClusterVersion checks of validateOpenshiftUpgradeVersion
+// to the manifests of the manifestwork templates
+func (r *ClusterGroupUpgradeReconciler) validateManifestWorkOpenshiftUpgradeVersion(
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, manifests []mwv1.Manifest) error {
+
+	var versionInfo ocpVersionInfo
+	objects, err := getManifestObjects(manifests)
+	if err == nil {
+		versionInfo, err = extractOCPVersionInfoFromObjects(objects)
+	}
+	return r.validateOCPVersionInfo(clusterGroupUpgrade, versionInfo, "manifestwork template", err)
+}
+
+// validateOCPVersionInfo checks that the platform image can be resolved from the ClusterVersion found in the given
+// source, and sets the Validated condition to False with reason InvalidPlatformImage otherwise
+func (r *ClusterGroupUpgradeReconciler) validateOCPVersionInfo(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	versionInfo ocpVersionInfo, source string, err error) error {
 
 	if err == nil {
 		if !versionInfo.clusterVersionCRFound || versionInfo.image != "" {
@@ -150,7 +186,7 @@ func (r *ClusterGroupUpgradeReconciler) validateOpenshiftUpgradeVersion(
 
 		// Check for all the required parameters needed to make the update graph HTTP call and retrieve the image
 		if versionInfoContainsEmptyString {
-			err = errors.New("policy with ClusterVersion must have upstream, channel, and version when image is not provided")
+			err = fmt.Errorf("%s with ClusterVersion must have upstream, channel, and version when image is not provided", source)
 		} else if versionInfoContainsTemplate || versionInfoContainsPlaceholder {
 			if clusterGroupUpgrade.Spec.PreCaching {
 				// return error if the fields contain templates
@@ -183,32 +219,24 @@ func indexOf(element ranv1alpha1.ManagedPolicyForUpgrade, data []ranv1alpha1.Man
 	return -1, errors.New("element not found in data")
 }
 
+// validatePoliciesDependenciesOrder checks that the managed policies come after the policies they depend on. The
+// extraDependencies of the policy templates are only considered with the Dependencies policy ordering.
 func (r *ClusterGroupUpgradeReconciler) validatePoliciesDependenciesOrder(
 	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, managedPoliciesForUpgrade []*unstructured.Unstructured) error {
+	includeExtraDependencies := clusterGroupUpgrade.Spec.PolicyOrdering == ranv1alpha1.PolicyOrdering.Dependencies
 	for _, managedPolicy := range managedPoliciesForUpgrade {
 		managedPolicyIndex, _ := indexOf(
 			ranv1alpha1.ManagedPolicyForUpgrade{Name: managedPolicy.GetName(), Namespace: managedPolicy.GetNamespace()},
 			clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade)
-		specObject := managedPolicy.Object["spec"].(map[string]interface{})
-		dependencies := specObject["dependencies"]
-		if dependencies == nil {
-			continue
-		}
-		dependenciesArr := dependencies.([]interface{})
-
-		for _, d := range dependenciesArr {
-			name := d.(map[string]interface{})["name"]
-			namespace := d.(map[string]interface{})["namespace"]
-			dependecyIndex, err := indexOf(
-				ranv1alpha1.ManagedPolicyForUpgrade{Name: name.(string), Namespace: namespace.(string)},
-				clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade)
+		for _, dependency := range getPolicyDependencies(managedPolicy, includeExtraDependencies) {
+			dependecyIndex, err := indexOf(dependency, clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade)
 			if err == nil && dependecyIndex > managedPolicyIndex {
 				utils.SetStatusCondition(
 					&clusterGroupUpgrade.Status.Conditions,
 					utils.ConditionTypes.Validated,
 					utils.ConditionReasons.UnresolvableDenpendency,
 					metav1.ConditionFalse,
-					fmt.Sprintf("Managed Policy %s depends on %s, which is to be remediated later", managedPolicy.GetName(), name),
+					fmt.Sprintf("Managed Policy %s depends on %s, which is to be remediated later", managedPolicy.GetName(), dependency.Name),
 				)
 				return errors.New("invalid dependency order")
 			}
@@ -216,3 +244,158 @@ func (r *ClusterGroupUpgradeReconciler) validatePoliciesDependenciesOrder(
 	}
 	return nil
 }
+
+// getPolicyDependencies returns the policies a policy depends on through its spec.dependencies and, if
+// includeExtraDependencies is set, the extraDependencies of its templates. Dependencies on other kinds of objects
+// are ignored.
+func getPolicyDependencies(policy *unstructured.Unstructured, includeExtraDependencies bool) []ranv1alpha1.ManagedPolicyForUpgrade {
+	var dependencies []interface{}
+	if specDependencies, found, _ := unstructured.NestedSlice(policy.Object, "spec", "dependencies"); found {
+		dependencies = append(dependencies, specDependencies...)
+	}
+	var templates []interface{}
+	if includeExtraDependencies {
+		templates, _, _ = unstructured.NestedSlice(policy.Object, "spec", "policy-templates")
+	}
+	for _, template := range templates {
+		templateMap, ok := template.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if extraDependencies, found, _ := unstructured.NestedSlice(templateMap, "extraDependencies"); found {
+			dependencies = append(dependencies, extraDependencies...)
+		}
+	}
+
+	var result []ranv1alpha1.ManagedPolicyForUpgrade
+	for _, dependency := range dependencies {
+		dependencyMap, ok := dependency.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if kind, ok := dependencyMap["kind"].(string); ok && kind != "" && kind != "Policy" {
+			continue
+		}
+		name, _ := dependencyMap["name"].(string)
+		namespace, _ := dependencyMap["namespace"].(string)
+		if namespace == "" {
+			namespace = policy.GetNamespace()
+		}
+		result = append(result, ranv1alpha1.ManagedPolicyForUpgrade{Name: name, Namespace: namespace})
+	}
+	return result
+}
+
+/*
+sortPoliciesByDependencies sorts the managed policies for upgrade so that each policy comes after the managed
+policies it depends on. Among the policies whose dependencies are satisfied, the earliest in the current order
+comes first. Dependencies on policies that are not managed for upgrade are ignored.
+
+	returns: the present policies in the new order of Status.ManagedPoliciesForUpgrade
+	         error if the dependencies have a cycle, the Validated condition holds the cycle path
+*/
+func (r *ClusterGroupUpgradeReconciler) sortPoliciesByDependencies(
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, managedPoliciesForUpgrade []*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+
+	policies := clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade
+	// dependencies[i] holds the indexes of the policies the policy i depends on
+	dependencies := make([][]int, len(policies))
+	// dependents[i] holds the indexes of the policies depending on the policy i
+	dependents := make([][]int, len(policies))
+	isDependency := make(map[[2]int]bool)
+	for _, managedPolicy := range managedPoliciesForUpgrade {
+		policyIndex, err := indexOf(
+			ranv1alpha1.ManagedPolicyForUpgrade{Name: managedPolicy.GetName(), Namespace: managedPolicy.GetNamespace()}, policies)
+		if err != nil {
+			continue
+		}
+		for _, dependency := range getPolicyDependencies(managedPolicy, true) {
+			dependencyIndex, err := indexOf(dependency, policies)
+			if err != nil {
+				continue
+			}
+			if isDependency[[2]int{policyIndex, dependencyIndex}] {
+				continue
+			}
+			isDependency[[2]int{policyIndex, dependencyIndex}] = true
+			dependencies[policyIndex] = append(dependencies[policyIndex], dependencyIndex)
+			dependents[dependencyIndex] = append(dependents[dependencyIndex], policyIndex)
+		}
+	}
+
+	order := make([]int, 0, len(policies))
+	pending := make([]int, len(policies))
+	for i := range policies {
+		pending[i] = len(dependencies[i])
+	}
+	sorted := make([]bool, len(policies))
+	for len(order) < len(policies) {
+		next := -1
+		for i := range policies {
+			if !sorted[i] && pending[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			cycle := findDependencyCycle(dependencies, sorted)
+			var cycleNames []string
+			for _, i := range cycle {
+				cycleNames = append(cycleNames, policies[i].Name)
+			}
+			utils.SetStatusCondition(
+				&clusterGroupUpgrade.Status.Conditions,
+				utils.ConditionTypes.Validated,
+				utils.ConditionReasons.UnresolvableDenpendency,
+				metav1.ConditionFalse,
+				fmt.Sprintf("Managed Policies have a dependency cycle: %s", strings.Join(cycleNames, " -> ")),
+			)
+			return managedPoliciesForUpgrade, errors.New("dependency cycle")
+		}
+		sorted[next] = true
+		order = append(order, next)
+		for _, dependent := range dependents[next] {
+			pending[dependent]--
+		}
+	}
+
+	sortedPolicies := make([]ranv1alpha1.ManagedPolicyForUpgrade, 0, len(policies))
+	sortedManagedPolicies := make([]*unstructured.Unstructured, 0, len(managedPoliciesForUpgrade))
+	for _, i := range order {
+		sortedPolicies = append(sortedPolicies, policies[i])
+		for _, managedPolicy := range managedPoliciesForUpgrade {
+			if managedPolicy.GetName() == policies[i].Name && managedPolicy.GetNamespace() == policies[i].Namespace {
+				sortedManagedPolicies = append(sortedManagedPolicies, managedPolicy)
+				break
+			}
+		}
+	}
+	clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade = sortedPolicies
+	return sortedManagedPolicies, nil
+}
+
+// findDependencyCycle returns a dependency cycle among the policies that are not sorted, starting and ending
+// with the same policy. Each unsorted policy depends on another unsorted policy, so following the dependencies
+// from any of them eventually revisits a policy.
+func findDependencyCycle(dependencies [][]int, sorted []bool) []int {
+	start := 0
+	for start < len(sorted) && sorted[start] {
+		start++
+	}
+
+	visitedAt := make(map[int]int)
+	var path []int
+	for current := start; ; {
+		if at, found := visitedAt[current]; found {
+			return append(path[at:], current)
+		}
+		visitedAt[current] = len(path)
+		path = append(path, current)
+		for _, dependency := range dependencies[current] {
+			if !sorted[dependency] {
+				current = dependency
+				break
+			}
+		}
+	}
+}
@@ -7,13 +7,16 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	mwv1 "open-cluster-management.io/api/work/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -37,12 +40,24 @@ spec:
   - name: a
     namespace: ns
   templates: ""
+`
+	const policyWithExtraDependency = `---
+kind: Policy
+metadata:
+  name: c
+  namespace: ns
+spec:
+  policy-templates:
+  - extraDependencies:
+    - name: a
+      namespace: ns
 `
 	testcases := []struct {
 		name                      string
 		policies                  []*unstructured.Unstructured
 		managedPolicies           []string
 		managedPoliciesForUpgrade []ranv1alpha1.ManagedPolicyForUpgrade
+		policyOrdering            string
 		wantErr                   assert.ErrorAssertionFunc
 	}{
 		{
@@ -66,6 +81,21 @@ spec:
 			managedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{{Name: "b", Namespace: "ns"}, {Name: "a", Namespace: "ns"}},
 			wantErr:                   assert.Error,
 		},
+		{
+			name:                      "template extraDependencies are ignored with the Spec ordering",
+			policies:                  []*unstructured.Unstructured{mustConvertYamlStrToUnstructured(noDependency), mustConvertYamlStrToUnstructured(policyWithExtraDependency)},
+			managedPolicies:           []string{"c", "a"},
+			managedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{{Name: "c", Namespace: "ns"}, {Name: "a", Namespace: "ns"}},
+			wantErr:                   assert.NoError,
+		},
+		{
+			name:                      "template extraDependencies are checked with the Dependencies ordering",
+			policies:                  []*unstructured.Unstructured{mustConvertYamlStrToUnstructured(noDependency), mustConvertYamlStrToUnstructured(policyWithExtraDependency)},
+			managedPolicies:           []string{"c", "a"},
+			managedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{{Name: "c", Namespace: "ns"}, {Name: "a", Namespace: "ns"}},
+			policyOrdering:            ranv1alpha1.PolicyOrdering.Dependencies,
+			wantErr:                   assert.Error,
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -77,6 +107,7 @@ spec:
 
 			var cgu ranv1alpha1.ClusterGroupUpgrade
 			cgu.Spec.ManagedPolicies = tc.managedPolicies
+			cgu.Spec.PolicyOrdering = tc.policyOrdering
 			cgu.Status.ManagedPoliciesForUpgrade = tc.managedPoliciesForUpgrade
 			err := r.validatePoliciesDependenciesOrder(&cgu, tc.policies)
 			tc.wantErr(t, err)
@@ -84,6 +115,103 @@ spec:
 	}
 }
 
+func TestValidation_sortPoliciesByDependencies(t *testing.T) {
+	newPolicy := func(name string, dependencies ...string) *unstructured.Unstructured {
+		policy := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+		policy.SetName(name)
+		policy.SetNamespace("ns")
+		var specDependencies []interface{}
+		for _, dependency := range dependencies {
+			specDependencies = append(specDependencies, map[string]interface{}{
+				"apiVersion": "policy.open-cluster-management.io/v1", "kind": "Policy", "name": dependency, "compliance": "Compliant"})
+		}
+		if len(specDependencies) > 0 {
+			policy.Object["spec"].(map[string]interface{})["dependencies"] = specDependencies
+		}
+		return policy
+	}
+	withExtraDependency := func(policy *unstructured.Unstructured, kind, name string) *unstructured.Unstructured {
+		policy.Object["spec"].(map[string]interface{})["policy-templates"] = []interface{}{
+			map[string]interface{}{
+				"objectDefinition":  map[string]interface{}{"kind": "ConfigurationPolicy"},
+				"extraDependencies": []interface{}{map[string]interface{}{"kind": kind, "name": name, "namespace": "ns"}},
+			},
+		}
+		return policy
+	}
+
+	testcases := []struct {
+		name          string
+		policies      []*unstructured.Unstructured
+		expected      []string
+		expectedCycle string
+	}{
+		{
+			name:     "no dependencies keeps the order",
+			policies: []*unstructured.Unstructured{newPolicy("c"), newPolicy("a"), newPolicy("b")},
+			expected: []string{"c", "a", "b"},
+		},
+		{
+			name:     "dependencies come first",
+			policies: []*unstructured.Unstructured{newPolicy("c", "b"), newPolicy("b", "a"), newPolicy("a"), newPolicy("d")},
+			expected: []string{"a", "b", "c", "d"},
+		},
+		{
+			name:     "ties keep the order",
+			policies: []*unstructured.Unstructured{newPolicy("d", "a"), newPolicy("c"), newPolicy("a"), newPolicy("b", "a")},
+			expected: []string{"c", "a", "d", "b"},
+		},
+		{
+			name: "extra dependencies",
+			policies: []*unstructured.Unstructured{
+				withExtraDependency(newPolicy("b"), "Policy", "a"),
+				withExtraDependency(newPolicy("a"), "ConfigurationPolicy", "b"),
+			},
+			expected: []string{"a", "b"},
+		},
+		{
+			name:     "dependencies on unmanaged policies are ignored",
+			policies: []*unstructured.Unstructured{newPolicy("b", "unmanaged"), newPolicy("a")},
+			expected: []string{"b", "a"},
+		},
+		{
+			name:          "cycle",
+			policies:      []*unstructured.Unstructured{newPolicy("d"), newPolicy("a", "b"), newPolicy("b", "c"), newPolicy("c", "a")},
+			expectedCycle: "Managed Policies have a dependency cycle: a -> b -> c -> a",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{}
+			for _, policy := range tc.policies {
+				cgu.Status.ManagedPoliciesForUpgrade = append(cgu.Status.ManagedPoliciesForUpgrade,
+					ranv1alpha1.ManagedPolicyForUpgrade{Name: policy.GetName(), Namespace: policy.GetNamespace()})
+			}
+
+			sorted, err := r.sortPoliciesByDependencies(cgu, tc.policies)
+			if tc.expectedCycle != "" {
+				assert.Error(t, err)
+				condition := meta.FindStatusCondition(cgu.Status.Conditions, string(utils.ConditionTypes.Validated))
+				assert.Equal(t, string(utils.ConditionReasons.UnresolvableDenpendency), condition.Reason)
+				assert.Equal(t, tc.expectedCycle, condition.Message)
+				return
+			}
+
+			assert.NoError(t, err)
+			var sortedNames, statusNames []string
+			for i, policy := range sorted {
+				sortedNames = append(sortedNames, policy.GetName())
+				statusNames = append(statusNames, cgu.Status.ManagedPoliciesForUpgrade[i].Name)
+			}
+			assert.Equal(t, tc.expected, sortedNames)
+			assert.Equal(t, tc.expected, statusNames)
+			assert.NoError(t, r.validatePoliciesDependenciesOrder(cgu, sorted))
+		})
+	}
+}
+
 const policy = `---
 kind: Policy
 spec:
@@ -422,6 +550,85 @@ func TestClusterGroupUpgradeReconciler_validateOpenshiftUpgradeVersion(t *testin
 
 }
 
+func TestClusterGroupUpgradeReconciler_validateManifestWorkOpenshiftUpgradeVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"version":1,"nodes":[{"version":"4.14.2","payload":"quay.io/openshift-release-dev/ocp-release:4.14.2"}]}`))
+	}))
+	defer server.Close()
+
+	newClusterVersion := func(spec string) mwv1.Manifest {
+		return mwv1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(
+			`{"apiVersion": "config.openshift.io/v1", "kind": "ClusterVersion", "metadata": {"name": "version"}, "spec": ` + spec + `}`)}}
+	}
+	configMap := mwv1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(
+		`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm", "namespace": "default"}}`)}}
+
+	tests := []struct {
+		name       string
+		preCaching bool
+		manifests  []mwv1.Manifest
+		wantErr    string
+	}{
+		{
+			name:      "without ClusterVersion",
+			manifests: []mwv1.Manifest{configMap},
+		},
+		{
+			name:      "with Image",
+			manifests: []mwv1.Manifest{newClusterVersion(`{"desiredUpdate": {"image": "quay.io/openshift-release-dev/ocp-release:4.14.2"}}`)},
+		},
+		{
+			name: "with Version found in the update graph",
+			manifests: []mwv1.Manifest{newClusterVersion(
+				`{"upstream": "` + server.URL + `", "channel": "stable-4.14", "desiredUpdate": {"version": "4.14.2"}}`)},
+		},
+		{
+			name: "with Version missing from the update graph",
+			manifests: []mwv1.Manifest{newClusterVersion(
+				`{"upstream": "` + server.URL + `", "channel": "stable-4.14", "desiredUpdate": {"version": "4.14.3"}}`)},
+			wantErr: "unable to find version 4.14.3",
+		},
+		{
+			name:      "with Version and without upstream",
+			manifests: []mwv1.Manifest{newClusterVersion(`{"channel": "stable-4.14", "desiredUpdate": {"version": "4.14.2"}}`)},
+			wantErr:   "manifestwork template with ClusterVersion must have upstream, channel, and version when image is not provided",
+		},
+		{
+			name: "with conflicting versions",
+			manifests: []mwv1.Manifest{
+				newClusterVersion(`{"desiredUpdate": {"version": "4.14.2", "image": "quay.io/openshift-release-dev/ocp-release:4.14.2"}}`),
+				newClusterVersion(`{"desiredUpdate": {"version": "4.14.3", "image": "quay.io/openshift-release-dev/ocp-release:4.14.3"}}`),
+			},
+			wantErr: "platform image defined more then once with conflicting version values",
+		},
+		{
+			name:       "with placeholders and precaching",
+			preCaching: true,
+			manifests: []mwv1.Manifest{newClusterVersion(
+				`{"upstream": "` + server.URL + `", "channel": "stable-4.14", "desiredUpdate": {"version": "{{cgu label \"version\" cgu}}"}}`)},
+			wantErr: "templatized ClusterVersion fields not supported with precaching",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{Spec: ranv1alpha1.ClusterGroupUpgradeSpec{PreCaching: tt.preCaching}}
+
+			err := r.validateManifestWorkOpenshiftUpgradeVersion(cgu, tt.manifests)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Nil(t, meta.FindStatusCondition(cgu.Status.Conditions, string(utils.ConditionTypes.Validated)))
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+			condition := meta.FindStatusCondition(cgu.Status.Conditions, string(utils.ConditionTypes.Validated))
+			assert.NotNil(t, condition)
+			assert.Equal(t, string(utils.ConditionReasons.InvalidPlatformImage), condition.Reason)
+		})
+	}
+}
+
 func TestClusterGroupUpgradeReconciler_extractPrecachingSpecFromPolicies(t *testing.T) {
 
 	const policyWithOneOperator = `---
@@ -944,6 +1151,75 @@ spec:
 	}
 }
 
+func TestClusterGroupUpgradeReconciler_extractPrecachingSpecFromManifests(t *testing.T) {
+	newManifest := func(manifest string) mwv1.Manifest {
+		return mwv1.Manifest{RawExtension: runtime.RawExtension{Raw: []byte(manifest)}}
+	}
+	clusterVersion := newManifest(`{"apiVersion": "config.openshift.io/v1", "kind": "ClusterVersion", "metadata": {"name": "version"},
+		"spec": {"channel": "stable-4.14", "desiredUpdate": {"version": "4.14.2", "image": "quay.io/openshift-release-dev/ocp-release:4.14.2"}}}`)
+	subscription := newManifest(`{"apiVersion": "operators.coreos.com/v1alpha1", "kind": "Subscription",
+		"metadata": {"name": "ptp-operator-subscription", "namespace": "openshift-ptp"}, "spec": {"name": "ptp-operator", "channel": "stable"}}`)
+	catalogSource := newManifest(`{"apiVersion": "operators.coreos.com/v1alpha1", "kind": "CatalogSource",
+		"metadata": {"name": "redhat-operators", "namespace": "openshift-marketplace"}, "spec": {"image": "registry.example.com/redhat-operators:v4.14"}}`)
+	configMap := newManifest(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm", "namespace": "default"}}`)
+	imageDigestMirrorSet := newManifest(`{"apiVersion": "config.openshift.io/v1", "kind": "ImageDigestMirrorSet", "metadata": {"name": "release"},
+		"spec": {"imageDigestMirrors": [{"source": "quay.io/openshift-release-dev/ocp-release", "mirrors": ["mirror.example.com/ocp/release"]},
+		{"source": "quay.io/openshift-release-dev/ocp-v4.0-art-dev"}]}}`)
+	imageContentSourcePolicy := newManifest(`{"apiVersion": "operator.openshift.io/v1alpha1", "kind": "ImageContentSourcePolicy", "metadata": {"name": "operators"},
+		"spec": {"repositoryDigestMirrors": [{"source": "registry.redhat.io", "mirrors": ["mirror.example.com/redhat", "mirror2.example.com/redhat"]}]}}`)
+
+	tests := []struct {
+		name      string
+		manifests []mwv1.Manifest
+		want      ranv1alpha1.PrecachingSpec
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "With cluster version, subscription and catalog source",
+			manifests: []mwv1.Manifest{configMap, clusterVersion, subscription, catalogSource},
+			want: ranv1alpha1.PrecachingSpec{
+				PlatformImage:                "quay.io/openshift-release-dev/ocp-release:4.14.2",
+				OperatorsIndexes:             []string{"registry.example.com/redhat-operators:v4.14"},
+				OperatorsPackagesAndChannels: []string{"ptp-operator:stable"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "With registry mirrors",
+			manifests: []mwv1.Manifest{clusterVersion, imageDigestMirrorSet, imageContentSourcePolicy},
+			want: ranv1alpha1.PrecachingSpec{
+				PlatformImage: "quay.io/openshift-release-dev/ocp-release:4.14.2",
+				RegistryMirrors: []ranv1alpha1.RegistryMirror{
+					{Source: "quay.io/openshift-release-dev/ocp-release", Mirrors: []string{"mirror.example.com/ocp/release"}},
+					{Source: "registry.redhat.io", Mirrors: []string{"mirror.example.com/redhat", "mirror2.example.com/redhat"}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "Without software to precache",
+			manifests: []mwv1.Manifest{configMap},
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "With invalid manifest",
+			manifests: []mwv1.Manifest{newManifest(`not json`)},
+			wantErr:   assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+
+			got, err := r.extractPrecachingSpecFromManifests(tt.manifests)
+			if !tt.wantErr(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 // convertYamlStrToUnstructured helper func to convert a CR in Yaml string to Unstructured
 func mustConvertYamlStrToUnstructured(cr string) *unstructured.Unstructured {
 	jCr, err := yaml.ToJSON([]byte(cr))
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2024 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	cloneName       string
+	cloneFailedOnly bool
+	cloneEnable     bool
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <name>",
+	Short: "Create a new ClusterGroupUpgrade from the spec of an existing one",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clientset, ns, err := newClientset()
+		if err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		cgu, err := clientset.RanV1alpha1().ClusterGroupUpgrades(ns).Get(ctx, args[0], metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		name := cloneName
+		if name == "" {
+			name = cgu.Name + "-clone"
+		}
+		clone, err := cloneClusterGroupUpgrade(cgu, name, cloneFailedOnly, cloneEnable)
+		if err != nil {
+			return err
+		}
+		if _, err := clientset.RanV1alpha1().ClusterGroupUpgrades(ns).Create(ctx, clone, metav1.CreateOptions{}); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "clustergroupupgrade %s created with %d clusters\n", name, len(clone.Spec.Clusters))
+		return nil
+	},
+}
+
+func init() {
+	cloneCmd.Flags().StringVar(&cloneName, "name", "", "Name of the new ClusterGroupUpgrade (default <name>-clone)")
+	cloneCmd.Flags().BoolVar(&cloneFailedOnly, "failed-only", false, "Only include the clusters that timed out")
+	cloneCmd.Flags().BoolVar(&cloneEnable, "enable", false, "Create the new ClusterGroupUpgrade enabled")
+	rootCmd.AddCommand(cloneCmd)
+}
+
+// cloneClusterGroupUpgrade copies the spec of a ClusterGroupUpgrade into a new one. With failedOnly, the
+// clusters are replaced by the list of clusters that timed out and the cluster selectors are dropped.
+func cloneClusterGroupUpgrade(cgu *ranv1alpha1.ClusterGroupUpgrade, name string, failedOnly, enable bool) (*ranv1alpha1.ClusterGroupUpgrade, error) {
+	clone := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cgu.Namespace,
+		},
+		Spec: *cgu.Spec.DeepCopy(),
+	}
+	clone.Spec.Enable = &enable
+
+	if !failedOnly {
+		return clone, nil
+	}
+
+	failed := make(map[string]bool)
+	var clusters []string
+	for _, state := range cgu.Status.Clusters {
+		if (state.State == stateTimedout || state.State == statePolicyTimedout) && !failed[state.Name] {
+			failed[state.Name] = true
+			clusters = append(clusters, state.Name)
+		}
+	}
+	if len(clusters) == 0 {
+		return nil, fmt.Errorf("clustergroupupgrade %s has no timed out clusters", cgu.Name)
+	}
+
+	clone.Spec.Clusters = clusters
+	clone.Spec.ClusterSelector = nil
+	clone.Spec.ClusterLabelSelectors = nil
+	if clone.Spec.RemediationStrategy != nil {
+		var canaries []string
+		for _, canary := range clone.Spec.RemediationStrategy.Canaries {
+			if failed[canary] {
+				canaries = append(canaries, canary)
+			}
+		}
+		clone.Spec.RemediationStrategy.Canaries = canaries
+	}
+	return clone, nil
+}
@@ -0,0 +1,205 @@
+/*
+ * Copyright 2024 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/pkg/generated/clientset/versioned"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/pkg/generated/clientset/versioned/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestClusterGroupUpgrade() *ranv1alpha1.ClusterGroupUpgrade {
+	enable := true
+	policyIndex := 1
+	return &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			Enable:          &enable,
+			Clusters:        []string{"spoke1", "spoke2", "spoke3", "spoke4", "spoke5"},
+			ManagedPolicies: []string{"policy1", "policy2"},
+			RemediationStrategy: &ranv1alpha1.RemediationStrategySpec{
+				Canaries:       []string{"spoke1"},
+				MaxConcurrency: 2,
+			},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []metav1.Condition{
+				{Type: "Progressing", Status: metav1.ConditionTrue, Reason: "InProgress", Message: "Remediating non-compliant policies"},
+			},
+			RemediationPlan: [][]string{{"spoke1"}, {"spoke2", "spoke3"}},
+			ManagedPoliciesForUpgrade: []ranv1alpha1.ManagedPolicyForUpgrade{
+				{Name: "policy1", Namespace: "default"}, {Name: "policy2", Namespace: "default"}},
+			Clusters: []ranv1alpha1.ClusterState{
+				{Name: "spoke4", State: stateComplete},
+				{Name: "spoke1", State: stateTimedout, CurrentPolicy: &ranv1alpha1.PolicyStatus{Name: "policy2", Status: "NonCompliant"}},
+			},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatch: 2,
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+					"spoke2": {State: ranv1alpha1.InProgress, PolicyIndex: &policyIndex},
+					"spoke3": {State: ranv1alpha1.Completed},
+				},
+			},
+		},
+	}
+}
+
+var _ = Describe("kubectl-cgu", func() {
+	var clientset *fake.Clientset
+
+	BeforeEach(func() {
+		clientset = fake.NewSimpleClientset(newTestClusterGroupUpgrade())
+		newClientset = func() (versioned.Interface, string, error) {
+			return clientset, "default", nil
+		}
+	})
+
+	run := func(args ...string) (string, error) {
+		out := &bytes.Buffer{}
+		rootCmd.SetOut(out)
+		rootCmd.SetArgs(args)
+		err := rootCmd.Execute()
+		return out.String(), err
+	}
+
+	Describe("getClustersProgress", func() {
+		It("merges the plan, the current batch and the final states", func() {
+			Expect(getClustersProgress(newTestClusterGroupUpgrade())).To(Equal([]clusterProgress{
+				{Name: "spoke1", Batch: 1, State: stateTimedout, Current: "policy2"},
+				{Name: "spoke2", Batch: 2, State: ranv1alpha1.InProgress, Current: "policy2"},
+				{Name: "spoke3", Batch: 2, State: ranv1alpha1.Completed},
+				{Name: "spoke4", State: stateComplete},
+			}))
+		})
+	})
+
+	Describe("status", func() {
+		It("prints the batch and cluster progress", func() {
+			out, err := run("status", "cgu")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("Batch:          2/2"))
+			Expect(out).To(MatchRegexp(`spoke2\s+2\s+InProgress\s+policy2`))
+			Expect(out).To(MatchRegexp(`spoke4\s+-\s+complete\s+-`))
+		})
+	})
+
+	Describe("plan", func() {
+		It("prints the batches", func() {
+			out, err := run("plan", "cgu")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(MatchRegexp(`1\s+true\s+spoke1`))
+			Expect(out).To(MatchRegexp(`2\s+false\s+spoke2,spoke3`))
+		})
+	})
+
+	Describe("pause and resume", func() {
+		It("toggles spec.enable of an in progress upgrade", func() {
+			_, err := run("pause", "cgu")
+			Expect(err).ToNot(HaveOccurred())
+			cgu, _ := clientset.RanV1alpha1().ClusterGroupUpgrades("default").Get(context.TODO(), "cgu", metav1.GetOptions{})
+			Expect(*cgu.Spec.Enable).To(BeFalse())
+
+			_, err = run("resume", "cgu")
+			Expect(err).ToNot(HaveOccurred())
+			cgu, _ = clientset.RanV1alpha1().ClusterGroupUpgrades("default").Get(context.TODO(), "cgu", metav1.GetOptions{})
+			Expect(*cgu.Spec.Enable).To(BeTrue())
+		})
+
+		It("refuses to resume an upgrade that is not paused", func() {
+			_, err := run("resume", "cgu")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("refuses to pause an upgrade that is not in progress", func() {
+			cgu := newTestClusterGroupUpgrade()
+			cgu.Status.Conditions = []metav1.Condition{
+				{Type: "Progressing", Status: metav1.ConditionFalse, Reason: "Completed", Message: "All clusters are compliant with all the managed policies"},
+			}
+			clientset = fake.NewSimpleClientset(cgu)
+			_, err := run("pause", "cgu")
+			Expect(err).To(MatchError(ContainSubstring("is not in progress")))
+		})
+	})
+
+	Describe("approve", func() {
+		It("refuses an upgrade that is not waiting to be enabled", func() {
+			_, err := run("approve", "cgu")
+			Expect(err).To(MatchError(ContainSubstring("is not waiting to be enabled")))
+		})
+	})
+
+	Describe("clone", func() {
+		It("creates a new upgrade with the timed out clusters only", func() {
+			out, err := run("clone", "cgu", "--failed-only", "--name", "cgu-retry")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(out).To(ContainSubstring("clustergroupupgrade cgu-retry created with 1 clusters"))
+
+			clone, err := clientset.RanV1alpha1().ClusterGroupUpgrades("default").Get(context.TODO(), "cgu-retry", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clone.Spec.Clusters).To(Equal([]string{"spoke1"}))
+			Expect(clone.Spec.RemediationStrategy.Canaries).To(Equal([]string{"spoke1"}))
+			Expect(clone.Spec.ManagedPolicies).To(Equal([]string{"policy1", "policy2"}))
+			Expect(*clone.Spec.Enable).To(BeFalse())
+		})
+
+		It("includes the clusters that exceeded the remediation timeout of a policy", func() {
+			cgu := newTestClusterGroupUpgrade()
+			cgu.Status.Clusters = append(cgu.Status.Clusters, ranv1alpha1.ClusterState{Name: "spoke5", State: statePolicyTimedout})
+			clone, err := cloneClusterGroupUpgrade(cgu, "cgu-retry", true, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clone.Spec.Clusters).To(Equal([]string{"spoke1", "spoke5"}))
+		})
+
+		It("fails when no cluster timed out", func() {
+			cgu := newTestClusterGroupUpgrade()
+			cgu.Status.Clusters = nil
+			_, err := cloneClusterGroupUpgrade(cgu, "cgu-retry", true, false)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("explain", func() {
+		DescribeTable("explains the cluster",
+			func(modify func(*ranv1alpha1.ClusterGroupUpgrade), cluster, expected string) {
+				cgu := newTestClusterGroupUpgrade()
+				if modify != nil {
+					modify(cgu)
+				}
+				out := &bytes.Buffer{}
+				explainCluster(out, cgu, cluster)
+				Expect(out.String()).To(ContainSubstring(expected))
+			},
+			Entry("canary that timed out", nil, "spoke1", "spoke1 is in batch 1 of 2 as a canary\nThe remediation timed out on policy2"),
+			Entry("in progress", nil, "spoke2", "The remediation is in progress on policy2"),
+			Entry("compliant before the upgrade", nil, "spoke4", "it did not need remediation"),
+			Entry("compliant and not reported", nil, "spoke5", "it was compliant with all the managed policies"),
+			Entry("precaching failed", func(cgu *ranv1alpha1.ClusterGroupUpgrade) {
+				cgu.Status.Precaching = &ranv1alpha1.PrecachingStatus{Status: map[string]string{"spoke5": "PrecacheTimeout"}}
+			}, "spoke5", "pre-caching did not succeed (state: PrecacheTimeout)"),
+			Entry("not selected", func(cgu *ranv1alpha1.ClusterGroupUpgrade) {
+				cgu.Spec.ClusterLabelSelectors = []metav1.LabelSelector{{MatchLabels: map[string]string{"upgrade": "true"}}}
+			}, "spoke6", "does not match the cluster selectors: upgrade=true"),
+		)
+	})
+})
@@ -0,0 +1,124 @@
+/*
+ * Copyright 2024 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var enableCmd = &cobra.Command{
+	Use:   "enable <name>",
+	Short: "Set spec.enable to true so the upgrade starts",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := setEnable(cmd.Context(), args[0], true); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "clustergroupupgrade %s enabled\n", args[0])
+		return nil
+	},
+}
+
+var approveCmd = &cobra.Command{
+	Use:   "approve <name>",
+	Short: "Show the remediation plan of a ClusterGroupUpgrade waiting to be enabled and start it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cgu, err := getClusterGroupUpgrade(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		progressing := meta.FindStatusCondition(cgu.Status.Conditions, "Progressing")
+		if progressing == nil || progressing.Reason != "NotEnabled" {
+			reason, _ := getCurrentState(cgu)
+			return fmt.Errorf("clustergroupupgrade %s is not waiting to be enabled (state: %s)", args[0], reason)
+		}
+		printPlan(cmd.OutOrStdout(), cgu)
+		if err := setEnable(cmd.Context(), args[0], true); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "clustergroupupgrade %s approved\n", args[0])
+		return nil
+	},
+}
+
+var pauseCmd = &cobra.Command{
+	Use:   "pause <name>",
+	Short: "Hold an in progress ClusterGroupUpgrade on its current batch and policies",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cgu, err := getClusterGroupUpgrade(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		if !isInProgress(cgu) {
+			reason, _ := getCurrentState(cgu)
+			return fmt.Errorf("clustergroupupgrade %s is not in progress (state: %s)", args[0], reason)
+		}
+		if err := setEnable(cmd.Context(), args[0], false); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "clustergroupupgrade %s paused\n", args[0])
+		return nil
+	},
+}
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume <name>",
+	Short: "Resume a paused ClusterGroupUpgrade",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cgu, err := getClusterGroupUpgrade(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		if !isInProgress(cgu) || cgu.Spec.Enable == nil || *cgu.Spec.Enable {
+			return fmt.Errorf("clustergroupupgrade %s is not paused", args[0])
+		}
+		if err := setEnable(cmd.Context(), args[0], true); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "clustergroupupgrade %s resumed\n", args[0])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(enableCmd)
+	rootCmd.AddCommand(approveCmd)
+	rootCmd.AddCommand(pauseCmd)
+	rootCmd.AddCommand(resumeCmd)
+}
+
+func setEnable(ctx context.Context, name string, enable bool) error {
+	clientset, ns, err := newClientset()
+	if err != nil {
+		return err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	patch := []byte(fmt.Sprintf(`{"spec":{"enable":%t}}`, enable))
+	_, err = clientset.RanV1alpha1().ClusterGroupUpgrades(ns).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
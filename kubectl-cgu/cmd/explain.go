@@ -0,0 +1,127 @@
+/*
+ * Copyright 2024 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain <name> <cluster>",
+	Short: "Explain why a cluster is or isn't in the remediation plan of a ClusterGroupUpgrade",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cgu, err := getClusterGroupUpgrade(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		explainCluster(cmd.OutOrStdout(), cgu, args[1])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+}
+
+func explainCluster(out io.Writer, cgu *ranv1alpha1.ClusterGroupUpgrade, cluster string) {
+	for _, progress := range getClustersProgress(cgu) {
+		if progress.Name != cluster {
+			continue
+		}
+		if progress.Batch == 0 {
+			fmt.Fprintf(out, "%s is not in the remediation plan: it did not need remediation (state: %s)\n", cluster, progress.State)
+			return
+		}
+		fmt.Fprintf(out, "%s is in batch %d of %d", cluster, progress.Batch, len(cgu.Status.RemediationPlan))
+		if cgu.Spec.RemediationStrategy != nil {
+			if _, found := findString(cgu.Spec.RemediationStrategy.Canaries, cluster); found {
+				fmt.Fprint(out, " as a canary")
+			}
+		}
+		fmt.Fprintln(out)
+		switch progress.State {
+		case stateComplete:
+			fmt.Fprintf(out, "The remediation is complete\n")
+		case stateTimedout:
+			fmt.Fprintf(out, "The remediation timed out on %s\n", progress.Current)
+		case statePolicyTimedout:
+			fmt.Fprintf(out, "The remediation exceeded the remediation timeout of %s\n", progress.Current)
+		case ranv1alpha1.InProgress:
+			fmt.Fprintf(out, "The remediation is in progress on %s\n", progress.Current)
+		case ranv1alpha1.NotStarted:
+			fmt.Fprintf(out, "The remediation has not started, the current batch is %d\n", cgu.Status.Status.CurrentBatch)
+		default:
+			fmt.Fprintf(out, "The remediation state is %s\n", progress.State)
+		}
+		return
+	}
+
+	clustersSelected := meta.FindStatusCondition(cgu.Status.Conditions, "ClustersSelected")
+	if clustersSelected != nil && clustersSelected.Status == metav1.ConditionFalse && strings.Contains(clustersSelected.Message, cluster) {
+		fmt.Fprintf(out, "%s is not in the remediation plan: %s\n", cluster, clustersSelected.Message)
+		return
+	}
+	if cgu.Status.Precaching != nil {
+		if state, ok := cgu.Status.Precaching.Status[cluster]; ok && state != "Succeeded" {
+			fmt.Fprintf(out, "%s is not in the remediation plan: pre-caching did not succeed (state: %s)\n", cluster, state)
+			return
+		}
+	}
+	if cgu.Status.Backup != nil {
+		if state, ok := cgu.Status.Backup.Status[cluster]; ok && state != "Succeeded" {
+			fmt.Fprintf(out, "%s is not in the remediation plan: backup did not succeed (state: %s)\n", cluster, state)
+			return
+		}
+	}
+
+	if _, found := findString(cgu.Spec.Clusters, cluster); found {
+		if len(cgu.Status.RemediationPlan) == 0 && meta.FindStatusCondition(cgu.Status.Conditions, "Validated") == nil {
+			fmt.Fprintf(out, "%s is listed in spec.clusters but the remediation plan has not been built yet\n", cluster)
+			return
+		}
+		fmt.Fprintf(out, "%s is listed in spec.clusters but is not in the remediation plan: it was compliant with all the managed policies when the plan was built\n", cluster)
+		return
+	}
+
+	fmt.Fprintf(out, "%s is not listed in spec.clusters", cluster)
+	if len(cgu.Spec.ClusterSelector) > 0 || len(cgu.Spec.ClusterLabelSelectors) > 0 {
+		var selectors []string
+		selectors = append(selectors, cgu.Spec.ClusterSelector...)
+		for i := range cgu.Spec.ClusterLabelSelectors {
+			selectors = append(selectors, metav1.FormatLabelSelector(&cgu.Spec.ClusterLabelSelectors[i]))
+		}
+		fmt.Fprintf(out, " and does not match the cluster selectors: %s", strings.Join(selectors, "; "))
+	}
+	fmt.Fprintln(out)
+}
+
+func findString(a []string, s string) (int, bool) {
+	for i, e := range a {
+		if e == s {
+			return i, true
+		}
+	}
+	return -1, false
+}
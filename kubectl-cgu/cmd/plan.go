@@ -0,0 +1,96 @@
+/*
+ * Copyright 2024 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan <name>",
+	Short: "Show the remediation plan of a ClusterGroupUpgrade",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cgu, err := getClusterGroupUpgrade(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		printPlan(cmd.OutOrStdout(), cgu)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+}
+
+func printPlan(out io.Writer, cgu *ranv1alpha1.ClusterGroupUpgrade) {
+	if len(cgu.Status.RemediationPlan) == 0 {
+		fmt.Fprintln(out, "No remediation plan")
+		return
+	}
+
+	canaries := make(map[string]bool)
+	if cgu.Spec.RemediationStrategy != nil {
+		for _, canary := range cgu.Spec.RemediationStrategy.Canaries {
+			canaries[canary] = true
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BATCH\tCANARY\tCLUSTERS")
+	for i, batch := range cgu.Status.RemediationPlan {
+		canary := len(batch) == 1 && canaries[batch[0]]
+		fmt.Fprintf(w, "%d\t%t\t%s\n", i+1, canary, strings.Join(batch, ","))
+	}
+	w.Flush()
+
+	switch cgu.RolloutType() {
+	case ranv1alpha1.RolloutTypes.ManifestWork:
+		fmt.Fprintf(out, "\nManifestWork templates: %s\n", strings.Join(cgu.Spec.ManifestWorkTemplates, ","))
+		if len(cgu.Spec.ManifestWorkStages) > 0 {
+			var stages []string
+			for _, stage := range cgu.Spec.ManifestWorkStages {
+				stages = append(stages, "["+strings.Join(stage.Templates, ",")+"]")
+			}
+			fmt.Fprintf(out, "ManifestWork stages: %s\n", strings.Join(stages, " "))
+		}
+	case ranv1alpha1.RolloutTypes.ImageBasedUpgrade:
+		var stages []string
+		for _, stage := range cgu.GetImageBasedUpgradeStages() {
+			stages = append(stages, string(stage))
+		}
+		fmt.Fprintf(out, "\nImageBasedUpgrade seed image: %s (%s)\n", cgu.Spec.ImageBasedUpgrade.SeedImageRef.Image,
+			cgu.Spec.ImageBasedUpgrade.SeedImageRef.Version)
+		fmt.Fprintf(out, "ImageBasedUpgrade stages: %s\n", strings.Join(stages, ","))
+	default:
+		var policies []string
+		for _, policy := range cgu.Status.ManagedPoliciesForUpgrade {
+			policies = append(policies, policy.Namespace+"/"+policy.Name)
+		}
+		fmt.Fprintf(out, "\nPolicies: %s\n", strings.Join(policies, ","))
+		if len(cgu.Status.ManagedPoliciesCompliantBeforeUpgrade) > 0 {
+			fmt.Fprintf(out, "Policies compliant before upgrade: %s\n", strings.Join(cgu.Status.ManagedPoliciesCompliantBeforeUpgrade, ","))
+		}
+	}
+}
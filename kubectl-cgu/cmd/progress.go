@@ -0,0 +1,116 @@
+/*
+ * Copyright 2024 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+)
+
+// Cluster states reported in addition to the ClusterRemediationProgress states
+const (
+	stateComplete       = "complete"
+	stateTimedout       = "timedout"
+	statePolicyTimedout = "policytimedout"
+	stateUnknown        = "Unknown"
+)
+
+// clusterProgress is the progress of a single cluster of a ClusterGroupUpgrade
+type clusterProgress struct {
+	Name  string
+	Batch int
+	State string
+	// Current is the policy or manifestwork template the cluster is on, if any
+	Current string
+}
+
+// getCurrentState returns the reason and message of the latest condition, as shown by `oc get cgu`
+func getCurrentState(cgu *ranv1alpha1.ClusterGroupUpgrade) (string, string) {
+	if len(cgu.Status.Conditions) == 0 {
+		return "", ""
+	}
+	condition := cgu.Status.Conditions[len(cgu.Status.Conditions)-1]
+	return condition.Reason, condition.Message
+}
+
+// isInProgress returns true if the ClusterGroupUpgrade has started remediating and has not completed
+func isInProgress(cgu *ranv1alpha1.ClusterGroupUpgrade) bool {
+	return meta.IsStatusConditionTrue(cgu.Status.Conditions, "Progressing")
+}
+
+func getCurrentTarget(cgu *ranv1alpha1.ClusterGroupUpgrade, progress *ranv1alpha1.ClusterRemediationProgress) string {
+	switch {
+	case progress.PolicyIndex != nil && *progress.PolicyIndex < len(cgu.Status.ManagedPoliciesForUpgrade):
+		return cgu.Status.ManagedPoliciesForUpgrade[*progress.PolicyIndex].Name
+	case progress.ManifestWorkIndex != nil && *progress.ManifestWorkIndex < len(cgu.GetManifestWorkStages()):
+		return strings.Join(cgu.GetManifestWorkStages()[*progress.ManifestWorkIndex].Templates, ",")
+	case progress.ImageBasedUpgrade != nil:
+		return string(progress.ImageBasedUpgrade.Stage)
+	}
+	return ""
+}
+
+func getFinalTarget(state ranv1alpha1.ClusterState) string {
+	switch {
+	case state.CurrentPolicy != nil:
+		return state.CurrentPolicy.Name
+	case state.CurrentManifestWork != nil:
+		return state.CurrentManifestWork.Name
+	}
+	return ""
+}
+
+// getClustersProgress merges the remediation plan, the current batch progress and the final cluster
+// states into a per-cluster view. Clusters that did not need remediation are reported with batch 0.
+func getClustersProgress(cgu *ranv1alpha1.ClusterGroupUpgrade) []clusterProgress {
+	finalStates := make(map[string]ranv1alpha1.ClusterState)
+	for _, state := range cgu.Status.Clusters {
+		finalStates[state.Name] = state
+	}
+
+	var result []clusterProgress
+	inPlan := make(map[string]bool)
+	for i, batch := range cgu.Status.RemediationPlan {
+		batchNumber := i + 1
+		for _, cluster := range batch {
+			inPlan[cluster] = true
+			entry := clusterProgress{Name: cluster, Batch: batchNumber, State: ranv1alpha1.NotStarted}
+			if state, ok := finalStates[cluster]; ok {
+				entry.State = state.State
+				entry.Current = getFinalTarget(state)
+			} else if progress, ok := cgu.Status.Status.CurrentBatchRemediationProgress[cluster]; ok &&
+				batchNumber == cgu.Status.Status.CurrentBatch && progress != nil {
+				entry.State = progress.State
+				entry.Current = getCurrentTarget(cgu, progress)
+			} else if !cgu.Status.Status.CompletedAt.IsZero() ||
+				(cgu.Status.Status.CurrentBatch != 0 && batchNumber < cgu.Status.Status.CurrentBatch) {
+				entry.State = stateUnknown
+			}
+			result = append(result, entry)
+		}
+	}
+
+	for _, state := range cgu.Status.Clusters {
+		if !inPlan[state.Name] {
+			inPlan[state.Name] = true
+			result = append(result, clusterProgress{Name: state.Name, State: state.State, Current: getFinalTarget(state)})
+		}
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2024 Red Hat, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var statusCmd = &cobra.Command{
+	Use:   "status <name>",
+	Short: "Show the batch and cluster progress of a ClusterGroupUpgrade",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cgu, err := getClusterGroupUpgrade(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		printStatus(cmd.OutOrStdout(), cgu)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+}
+
+func getClusterGroupUpgrade(ctx context.Context, name string) (*ranv1alpha1.ClusterGroupUpgrade, error) {
+	clientset, ns, err := newClientset()
+	if err != nil {
+		return nil, err
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return clientset.RanV1alpha1().ClusterGroupUpgrades(ns).Get(ctx, name, metav1.GetOptions{})
+}
+
+func formatTime(t metav1.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func printStatus(out io.Writer, cgu *ranv1alpha1.ClusterGroupUpgrade) {
+	reason, message := getCurrentState(cgu)
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%s/%s\n", cgu.Namespace, cgu.Name)
+	fmt.Fprintf(w, "State:\t%s\n", reason)
+	fmt.Fprintf(w, "Details:\t%s\n", message)
+	fmt.Fprintf(w, "Batch:\t%d/%d\n", cgu.Status.Status.CurrentBatch, len(cgu.Status.RemediationPlan))
+	fmt.Fprintf(w, "Started:\t%s\n", formatTime(cgu.Status.Status.StartedAt))
+	fmt.Fprintf(w, "Batch started:\t%s\n", formatTime(cgu.Status.Status.CurrentBatchStartedAt))
+	fmt.Fprintf(w, "Completed:\t%s\n", formatTime(cgu.Status.Status.CompletedAt))
+	w.Flush()
+
+	clusters := getClustersProgress(cgu)
+	if len(clusters) == 0 {
+		return
+	}
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CLUSTER\tBATCH\tSTATE\tCURRENT")
+	for _, cluster := range clusters {
+		batch := "-"
+		if cluster.Batch != 0 {
+			batch = strconv.Itoa(cluster.Batch)
+		}
+		current := cluster.Current
+		if current == "" {
+			current = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", cluster.Name, batch, cluster.State, current)
+	}
+	w.Flush()
+}
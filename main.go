@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
 	policiesv1 "open-cluster-management.io/governance-policy-propagator/api/v1"
 
@@ -72,6 +73,7 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var enableHTTP2 bool
+	var placementAPI string
 
 	flag.BoolVar(&enableHTTP2, "enable-http2", enableHTTP2, "If HTTP/2 should be enabled for the webhook server.")
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
@@ -79,6 +81,9 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&placementAPI, "placement-api", utils.PlacementAPIAuto,
+		"The API used to place the policies on the clusters of a batch, one of auto, PlacementRule or Placement. "+
+			"auto uses Placement when the hub doesn't serve PlacementRule.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -112,10 +117,18 @@ func main() {
 		os.Exit(1)
 	}
 
+	usePlacementAPI, err := utils.UsePlacementAPI(mgr.GetRESTMapper(), placementAPI)
+	if err != nil {
+		setupLog.Error(err, "unable to determine the placement API")
+		os.Exit(1)
+	}
+	setupLog.Info("batch placement API", "usePlacementAPI", usePlacementAPI)
+
 	if err = (&controllers.ClusterGroupUpgradeReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("ClusterGroupUpgrade"),
-		Scheme: mgr.GetScheme(),
+		Client:          mgr.GetClient(),
+		Log:             ctrl.Log.WithName("controllers").WithName("ClusterGroupUpgrade"),
+		Scheme:          mgr.GetScheme(),
+		UsePlacementAPI: usePlacementAPI,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterGroupUpgrade")
 		os.Exit(1)
@@ -131,6 +144,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&controllers.CguTTLReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("CguTTL"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CguTTL")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -34,6 +34,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ClusterGroupUpgradeList{},
 		&PreCachingConfig{},
 		&PreCachingConfigList{},
+		&UpgradeReport{},
+		&UpgradeReportList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
@@ -18,6 +18,8 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	mwv1 "open-cluster-management.io/api/work/v1"
 )
 
@@ -32,6 +34,11 @@ type RemediationStrategySpec struct {
 	MaxConcurrency int `json:"maxConcurrency"`
 	//+kubebuilder:default=240
 	Timeout int `json:"timeout,omitempty"`
+	// BatchSoakSeconds is how long to wait after a batch completes before starting the next one. During the wait,
+	// the clusters of the batch must stay compliant with all the managed policies or the upgrade fails.
+	// The soak period counts against the timeout.
+	//+kubebuilder:validation:Minimum=0
+	BatchSoakSeconds int `json:"batchSoakSeconds,omitempty"`
 }
 
 // NamespacedCR defines the name and namespace of a custom resource
@@ -100,6 +107,15 @@ var BatchTimeoutAction = struct {
 	Abort:    "Abort",
 }
 
+// PolicyOrdering selections
+var PolicyOrdering = struct {
+	Spec         string
+	Dependencies string
+}{
+	Spec:         "Spec",
+	Dependencies: "Dependencies",
+}
+
 // OperatorUpgradeSpec defines the configuration of an operator upgrade
 type OperatorUpgradeSpec struct {
 	Channel   string `json:"channel,omitempty"`
@@ -129,6 +145,13 @@ type ClusterGroupUpgradeSpec struct {
 	// pre-caching configurations.
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PreCachingConfigRef",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	PreCachingConfigRef PreCachingConfigCR `json:"preCachingConfigRef,omitempty"`
+	// This field caps how many clusters pre-cache at once, as a number or a percentage of the clusters.
+	// The other clusters wait in the NotStarted state until a cluster completes pre-caching. It must
+	// amount to at least one cluster. All the clusters pre-cache at once if it is unset.
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:XIntOrString
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PreCachingConcurrency",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	PreCachingConcurrency *intstr.IntOrString `json:"preCachingConcurrency,omitempty"`
 	// This field determines when the upgrade starts. While false, the upgrade doesn't start. The policies,
 	// placement rules and placement bindings are created, but clusters are not added to the placement rule.
 	// Once set to true, the clusters start being upgraded, one batch at a time.
@@ -176,9 +199,35 @@ type ClusterGroupUpgradeSpec struct {
 	//+kubebuilder:validation:Optional
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Managed Policies",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	ManagedPolicies []string `json:"managedPolicies,omitempty"`
+	// This field holds the names of PolicySets whose member policies are managed after the managedPolicies.
+	// The members are ordered by their ran.openshift.io/ztp-deploy-wave annotation, lowest first. Members
+	// without a wave come last. Ties keep the order of the policy sets and of the policies within them.
+	//+kubebuilder:validation:Optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Managed Policy Sets",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	ManagedPolicySets []string `json:"managedPolicySets,omitempty"`
+	// The Policy Ordering controls the order in which the managed policies are remediated. The default value is `Spec`.
+	// The possible values are:
+	//   - Spec: the order of the managed policies, a policy depending on a later policy fails the validation
+	//   - Dependencies: the managed policies are sorted to follow their dependencies and extraDependencies,
+	//     keeping the order of the managed policies otherwise
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Enum=Spec;Dependencies
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Policy Ordering",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	PolicyOrdering string `json:"policyOrdering,omitempty"`
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Manifest Work Templates",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	//+kubebuilder:validation:Optional
 	ManifestWorkTemplates []string `json:"manifestWorkTemplates"`
+	// The Manifest Work Stages group the manifestWorkTemplates into stages rolled out one after another. The
+	// templates of a stage are applied together and each template must belong to exactly one stage. If unset,
+	// each template is a stage of its own, in the order of the manifestWorkTemplates.
+	//+kubebuilder:validation:Optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Manifest Work Stages",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	ManifestWorkStages []ManifestWorkStage `json:"manifestWorkStages,omitempty"`
+	// The Image Based Upgrade rolls out the stages of the lifecycle-agent ImageBasedUpgrade on the clusters through
+	// manifestworks. It can't be combined with managed policies or manifestwork templates.
+	//+kubebuilder:validation:Optional
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Based Upgrade",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	ImageBasedUpgrade *ImageBasedUpgradeSpec `json:"imageBasedUpgrade,omitempty"`
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Blocking CRs",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	BlockingCRs []BlockingCR `json:"blockingCRs,omitempty"`
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Actions",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
@@ -189,6 +238,66 @@ type ClusterGroupUpgradeSpec struct {
 	//   - Abort
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="BatchTimeoutAction",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	BatchTimeoutAction string `json:"batchTimeoutAction,omitempty"`
+	// This field limits the lifetime of a ClusterGroupUpgrade that has completed. Once the number of seconds
+	// since completion exceeds it, the ClusterGroupUpgrade is deleted. If unset, the operator-wide default
+	// is used; if neither is set, the ClusterGroupUpgrade is kept.
+	//+kubebuilder:validation:Minimum=0
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TTL Seconds After Finished",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// ManifestWorkStage defines manifestwork templates applied together
+type ManifestWorkStage struct {
+	// Templates are the names of the manifestWorkTemplates of the stage
+	//+kubebuilder:validation:MinItems=1
+	Templates []string `json:"templates"`
+	// KeepInPlace leaves the manifestworks of the stage in place once completed. They are not deleted when the
+	// next stage starts nor when the ClusterGroupUpgrade cleans up its manifestworks.
+	//+kubebuilder:validation:Optional
+	KeepInPlace bool `json:"keepInPlace,omitempty"`
+}
+
+// ImageBasedUpgradeStage is a stage of the lifecycle-agent ImageBasedUpgrade
+// +kubebuilder:validation:Enum=Prep;Upgrade;Rollback;Idle
+type ImageBasedUpgradeStage string
+
+// ImageBasedUpgradeStages define the stages of the ImageBasedUpgrade
+var ImageBasedUpgradeStages = struct {
+	Prep     ImageBasedUpgradeStage
+	Upgrade  ImageBasedUpgradeStage
+	Rollback ImageBasedUpgradeStage
+	Idle     ImageBasedUpgradeStage
+}{
+	Prep:     "Prep",
+	Upgrade:  "Upgrade",
+	Rollback: "Rollback",
+	Idle:     "Idle",
+}
+
+// ImageBasedUpgradeSpec defines the ImageBasedUpgrade rolled out on the clusters
+type ImageBasedUpgradeSpec struct {
+	// SeedImageRef is the seed image the clusters upgrade to
+	SeedImageRef SeedImageRef `json:"seedImageRef"`
+	// Stages are the stages applied in order to the clusters of a batch. A batch moves to the next stage once
+	// all its clusters completed the current one. Defaults to Prep, Upgrade and Idle.
+	//+kubebuilder:validation:Optional
+	Stages []ImageBasedUpgradeStage `json:"stages,omitempty"`
+}
+
+// SeedImageRef defines the seed image of the ImageBasedUpgrade
+type SeedImageRef struct {
+	//+kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+	//+kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+	// PullSecretRef is the secret on the clusters used to pull the seed image
+	//+kubebuilder:validation:Optional
+	PullSecretRef *PullSecretRef `json:"pullSecretRef,omitempty"`
+}
+
+// PullSecretRef defines a reference to a pull secret
+type PullSecretRef struct {
+	Name string `json:"name"`
 }
 
 // RolloutType is a string representing the rollout type
@@ -196,28 +305,147 @@ type RolloutType string
 
 // RolloutTypes define the supported rollout types
 var RolloutTypes = struct {
-	ManifestWork RolloutType
-	Policy       RolloutType
+	ManifestWork      RolloutType
+	Policy            RolloutType
+	ImageBasedUpgrade RolloutType
 }{
-	ManifestWork: "ManifestWork",
-	Policy:       "Policy",
+	ManifestWork:      "ManifestWork",
+	Policy:            "Policy",
+	ImageBasedUpgrade: "ImageBasedUpgrade",
 }
 
 // RolloutType returns the rollout type based on the spec content
 func (cgu ClusterGroupUpgrade) RolloutType() RolloutType {
+	if cgu.Spec.ImageBasedUpgrade != nil {
+		return RolloutTypes.ImageBasedUpgrade
+	}
 	if len(cgu.Spec.ManifestWorkTemplates) > 0 {
 		return RolloutTypes.ManifestWork
 	}
 	return RolloutTypes.Policy
 }
 
+// GetImageBasedUpgradeStages returns the stages of the ImageBasedUpgrade rollout, Prep, Upgrade and Idle if none are defined
+func (cgu ClusterGroupUpgrade) GetImageBasedUpgradeStages() []ImageBasedUpgradeStage {
+	if cgu.Spec.ImageBasedUpgrade == nil {
+		return nil
+	}
+	if len(cgu.Spec.ImageBasedUpgrade.Stages) > 0 {
+		return cgu.Spec.ImageBasedUpgrade.Stages
+	}
+	return []ImageBasedUpgradeStage{ImageBasedUpgradeStages.Prep, ImageBasedUpgradeStages.Upgrade, ImageBasedUpgradeStages.Idle}
+}
+
+// GetManifestWorkStages returns the stages of the manifestwork rollout, one per template if none are defined
+func (cgu ClusterGroupUpgrade) GetManifestWorkStages() []ManifestWorkStage {
+	if len(cgu.Spec.ManifestWorkStages) > 0 {
+		return cgu.Spec.ManifestWorkStages
+	}
+	stages := make([]ManifestWorkStage, 0, len(cgu.Spec.ManifestWorkTemplates))
+	for _, template := range cgu.Spec.ManifestWorkTemplates {
+		stages = append(stages, ManifestWorkStage{Templates: []string{template}})
+	}
+	return stages
+}
+
 // ClusterRemediationProgress stores the remediation progress of a cluster
 type ClusterRemediationProgress struct {
-	// State should be one of the following: NotStarted, InProgress, Completed
-	State             string      `json:"state,omitempty"`
-	ManifestWorkIndex *int        `json:"manifestWorkIndex,omitempty"`
-	PolicyIndex       *int        `json:"policyIndex,omitempty"`
-	FirstCompliantAt  metav1.Time `json:"firstComplaintAt,omitempty"`
+	// State should be one of the following: NotStarted, InProgress, Completed, TimedOut, Failed
+	State string `json:"state,omitempty"`
+	// ManifestWorkIndex is the index of the manifestwork stage the cluster is rolling out
+	ManifestWorkIndex *int `json:"manifestWorkIndex,omitempty"`
+	PolicyIndex       *int `json:"policyIndex,omitempty"`
+	// ImageBasedUpgradeIndex is the index of the ImageBasedUpgrade stage the cluster is rolling out
+	ImageBasedUpgradeIndex *int        `json:"imageBasedUpgradeIndex,omitempty"`
+	FirstCompliantAt       metav1.Time `json:"firstComplaintAt,omitempty"`
+	// PolicyStartedAt is when the cluster started remediating the policy at PolicyIndex
+	PolicyStartedAt metav1.Time `json:"policyStartedAt,omitempty"`
+	// ClusterVersion is the platform upgrade progress of the cluster, reported while remediating a policy
+	// containing a ClusterVersion
+	ClusterVersion *ClusterVersionProgress `json:"clusterVersion,omitempty"`
+	// Operators is the upgrade progress of the operators installed through the Subscriptions of the policy
+	// the cluster is remediating
+	Operators []OperatorUpgradeProgress `json:"operators,omitempty"`
+	// ManifestWorks is the live status of the manifestworks of the stage at ManifestWorkIndex
+	ManifestWorks []ManifestWorkProgress `json:"manifestWorks,omitempty"`
+	// ImageBasedUpgrade is the progress of the ImageBasedUpgrade of the cluster
+	ImageBasedUpgrade *ImageBasedUpgradeProgress `json:"imageBasedUpgrade,omitempty"`
+}
+
+// ImageBasedUpgradeProgress stores the progress of the ImageBasedUpgrade of a cluster
+type ImageBasedUpgradeProgress struct {
+	// Stage is the stage applied to the ImageBasedUpgrade of the cluster
+	Stage ImageBasedUpgradeStage `json:"stage,omitempty"`
+	// Completed is true once the cluster completed the stage
+	Completed bool `json:"completed,omitempty"`
+	// Message is the message of the condition of the stage
+	Message string `json:"message,omitempty"`
+	// RollbackReason is the failure of the Upgrade stage which triggered the automatic rollback of the cluster
+	RollbackReason string `json:"rollbackReason,omitempty"`
+}
+
+// ManifestWorkProgress stores the live status of the manifestwork of a template on a cluster
+type ManifestWorkProgress struct {
+	// Name is the name of the manifestwork template
+	Name string `json:"name"`
+	// Applied and Available are the statuses of the conditions of the manifestwork, empty until it is created
+	Applied   metav1.ConditionStatus `json:"applied,omitempty"`
+	Available metav1.ConditionStatus `json:"available,omitempty"`
+	Completed bool                   `json:"completed,omitempty"`
+	// FeedbackValues compares the fields synced back from the spoke with the expected values of the template
+	FeedbackValues []ManifestWorkFeedbackValue `json:"feedbackValues,omitempty"`
+}
+
+// ManifestWorkFeedbackValue stores a field synced back from the spoke and its expected value
+type ManifestWorkFeedbackValue struct {
+	ManifestIndex int32  `json:"manifestIndex"`
+	Name          string `json:"name"`
+	// Value is the compared value, empty until it is synced back from the spoke
+	Value string `json:"value,omitempty"`
+	// Expected is the operator and the expected value, e.g. SemverGreaterOrEqual 4.14.8
+	Expected string `json:"expected"`
+	Matched  bool   `json:"matched"`
+}
+
+// OperatorUpgradeProgress stores the upgrade progress of an operator installed through a Subscription
+type OperatorUpgradeProgress struct {
+	Subscription string `json:"subscription"`
+	Namespace    string `json:"namespace,omitempty"`
+	// InstalledCSV is the ClusterServiceVersion currently installed by the Subscription
+	InstalledCSV string `json:"installedCSV,omitempty"`
+	// TargetCSV is the ClusterServiceVersion the Subscription is progressing to
+	TargetCSV string `json:"targetCSV,omitempty"`
+	// CSVPhase is the phase of the target ClusterServiceVersion, e.g. Installing, Succeeded or Failed
+	CSVPhase    string `json:"csvPhase,omitempty"`
+	InstallPlan string `json:"installPlan,omitempty"`
+	// CSVFailedSince is the time the target ClusterServiceVersion was first seen Failed
+	CSVFailedSince *metav1.Time `json:"csvFailedSince,omitempty"`
+	// InstallPlanPhase is the phase of the InstallPlan, e.g. RequiresApproval, Installing, Complete or Failed
+	InstallPlanPhase string `json:"installPlanPhase,omitempty"`
+	// Message is the message of the target ClusterServiceVersion
+	Message string `json:"message,omitempty"`
+	// ExpectedCSV is the ClusterServiceVersion the InstallPlans are expected to install before being approved
+	ExpectedCSV string `json:"expectedCSV,omitempty"`
+	// InstallPlanRefusal explains why the InstallPlan was refused approval
+	InstallPlanRefusal string `json:"installPlanRefusal,omitempty"`
+	// ApprovedInstallPlans is the chain of InstallPlans approved to upgrade the operator, one per hop
+	ApprovedInstallPlans []string `json:"approvedInstallPlans,omitempty"`
+	// Hops is the number of hops of the upgrade chain so far
+	Hops int `json:"hops,omitempty"`
+	// CurrentHop is the hop of the upgrade chain in progress, from the installed to the target ClusterServiceVersion
+	CurrentHop string `json:"currentHop,omitempty"`
+}
+
+// ClusterVersionProgress stores the platform upgrade progress of a cluster as reported by its ClusterVersion
+type ClusterVersionProgress struct {
+	CurrentVersion string `json:"currentVersion,omitempty"`
+	DesiredVersion string `json:"desiredVersion,omitempty"`
+	// Message is the message of the Progressing condition of the ClusterVersion
+	Message string `json:"message,omitempty"`
+	// CompletedPercentage is the percentage of the ClusterOperators done updating
+	CompletedPercentage *int `json:"completedPercentage,omitempty"`
+	// Failures lists the failure conditions reported by the ClusterVersion, such as ReleaseAccepted=False
+	Failures []string `json:"failures,omitempty"`
 }
 
 // ClusterRemediationProgress possible states
@@ -225,6 +453,10 @@ const (
 	NotStarted = "NotStarted"
 	InProgress = "InProgress"
 	Completed  = "Completed"
+	// TimedOut means the cluster exceeded the remediation timeout of its current policy
+	TimedOut = "TimedOut"
+	// Failed means the upgrade of the cluster reported a failure
+	Failed = "Failed"
 )
 
 // UpgradeStatus defines the observed state of the upgrade
@@ -233,6 +465,8 @@ type UpgradeStatus struct {
 	CompletedAt           metav1.Time `json:"completedAt,omitempty"`
 	CurrentBatch          int         `json:"currentBatch,omitempty"`
 	CurrentBatchStartedAt metav1.Time `json:"currentBatchStartedAt,omitempty"`
+	// CurrentBatchCompletedAt is when the current batch completed and its soak period started
+	CurrentBatchCompletedAt metav1.Time `json:"currentBatchCompletedAt,omitempty"`
 
 	CurrentBatchRemediationProgress map[string]*ClusterRemediationProgress `json:"currentBatchRemediationProgress,omitempty"`
 }
@@ -261,6 +495,12 @@ type ClusterState struct {
 	State               string              `json:"state"`
 	CurrentPolicy       *PolicyStatus       `json:"currentPolicy,omitempty"`
 	CurrentManifestWork *ManifestWorkStatus `json:"currentManifestWork,omitempty"`
+	// Message explains why the cluster reached its final state
+	Message string `json:"message,omitempty"`
+	// StartedAt is the time the batch containing the cluster started remediating
+	StartedAt metav1.Time `json:"startedAt,omitempty"`
+	// CompletedAt is the time the cluster reached its final state
+	CompletedAt metav1.Time `json:"completedAt,omitempty"`
 }
 
 // PrecachingSpec defines the pre-caching software spec derived from policies
@@ -271,12 +511,53 @@ type PrecachingSpec struct {
 	ExcludePrecachePatterns      []string `json:"excludePrecachePatterns,omitempty"`
 	SpaceRequired                string   `json:"spaceRequired,omitempty"`
 	AdditionalImages             []string `json:"additionalImages,omitempty"`
+	Retries                      int      `json:"retries,omitempty"`
+	RetryBackoffSeconds          int      `json:"retryBackoffSeconds,omitempty"`
+	// RegistryMirrors are the mirrors of the ImageDigestMirrorSets and ImageContentSourcePolicies
+	// the images are pulled from
+	RegistryMirrors []RegistryMirror `json:"registryMirrors,omitempty"`
+}
+
+// RegistryMirror defines the mirrors of a source repository
+type RegistryMirror struct {
+	Source  string   `json:"source"`
+	Mirrors []string `json:"mirrors,omitempty"`
+}
+
+// PrecachingAttempts records the pre-caching attempts of a cluster
+type PrecachingAttempts struct {
+	// Count is the number of pre-caching attempts started on the cluster
+	Count int `json:"count,omitempty"`
+	// LastFailure is the reason the last attempt failed
+	LastFailure  string      `json:"lastFailure,omitempty"`
+	LastFailedAt metav1.Time `json:"lastFailedAt,omitempty"`
+	// NextAttemptAt is when the failed pre-caching is retried
+	NextAttemptAt metav1.Time `json:"nextAttemptAt,omitempty"`
+}
+
+// PrecachingProgress is the progress reported by the pre-caching job of a cluster
+type PrecachingProgress struct {
+	// Phase is one of release, olm (extracting the images to pre-cache), platform, additional
+	// (pulling the images), completed
+	Phase        string `json:"phase,omitempty"`
+	ImagesTotal  int    `json:"imagesTotal,omitempty"`
+	ImagesPulled int    `json:"imagesPulled,omitempty"`
+	ImagesFailed int    `json:"imagesFailed,omitempty"`
+	BytesPulled  int64  `json:"bytesPulled,omitempty"`
+	// FailedImages are the images which could not be pulled
+	FailedImages []string `json:"failedImages,omitempty"`
+	// Message is the failure reported by the pre-caching job
+	Message string `json:"message,omitempty"`
 }
 
 // PrecachingStatus defines the observed pre-caching status
 type PrecachingStatus struct {
 	Spec   *PrecachingSpec   `json:"spec,omitempty"`
 	Status map[string]string `json:"status,omitempty"`
+	// Attempts records the pre-caching attempts of each cluster
+	Attempts map[string]PrecachingAttempts `json:"attempts,omitempty"`
+	// Progress is the progress reported by the pre-caching job of each cluster
+	Progress map[string]PrecachingProgress `json:"progress,omitempty"`
 	//+kubebuilder:deprecatedversion:warning="PrecachingStatus.Clusters is deprecated"
 	Clusters []string `json:"clusters,omitempty"`
 }
@@ -297,6 +578,8 @@ type ClusterGroupUpgradeStatus struct {
 	PlacementBindings []string `json:"placementBindings,omitempty"`
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Placement Rules"
 	PlacementRules []string `json:"placementRules,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Placements"
+	Placements []string `json:"placements,omitempty"`
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Copied Policies"
 	// Deprecated
 	CopiedPolicies []string `json:"copiedPolicies,omitempty"`
@@ -326,6 +609,9 @@ type ClusterGroupUpgradeStatus struct {
 	Backup *BackupStatus `json:"backup,omitempty"`
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Computed Maximum Concurrency"
 	ComputedMaxConcurrency int `json:"computedMaxConcurrency,omitempty"`
+	// Name of the UpgradeReport written once the upgrade completed.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Upgrade Report"
+	UpgradeReport string `json:"upgradeReport,omitempty"`
 }
 
 // +genclient
@@ -380,6 +666,12 @@ type PreCachingConfigSpec struct {
 	ExcludePrecachePatterns []string `json:"excludePrecachePatterns,omitempty"`
 	// List of additional image pull specs for the pre-caching job
 	AdditionalImages []string `json:"additionalImages,omitempty"`
+	// Number of times a failed or timed out pre-caching is retried on a cluster
+	//+kubebuilder:validation:Minimum=0
+	Retries int `json:"retries,omitempty"`
+	// Delay before the first retry, doubled at each following retry. Defaults to 60 seconds.
+	//+kubebuilder:validation:Minimum=0
+	RetryBackoffSeconds int `json:"retryBackoffSeconds,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -400,3 +692,60 @@ type PreCachingConfigList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []PreCachingConfig `json:"items"`
 }
+
+// ClusterGroupUpgradeReference identifies the ClusterGroupUpgrade an UpgradeReport was written for
+type ClusterGroupUpgradeReference struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	UID       types.UID `json:"uid,omitempty"`
+}
+
+// UpgradeReportSpec holds the outcome of a completed ClusterGroupUpgrade
+type UpgradeReportSpec struct {
+	// ClusterGroupUpgrade the report was written for
+	ClusterGroupUpgrade ClusterGroupUpgradeReference `json:"clusterGroupUpgrade"`
+	// ClusterGroupUpgradeSpec is the spec of the ClusterGroupUpgrade that triggered the upgrade
+	ClusterGroupUpgradeSpec ClusterGroupUpgradeSpec `json:"clusterGroupUpgradeSpec"`
+	StartedAt               metav1.Time             `json:"startedAt,omitempty"`
+	CompletedAt             metav1.Time             `json:"completedAt,omitempty"`
+	// RemediationPlan is the final list of batches
+	RemediationPlan [][]string `json:"remediationPlan,omitempty"`
+	// Clusters contains the final state and timings of each remediated cluster
+	Clusters []ClusterState `json:"clusters,omitempty"`
+	// ManagedPolicies contains the managed policies that were enforced
+	ManagedPolicies []ManagedPolicyForUpgrade `json:"managedPolicies,omitempty"`
+	// ManagedPoliciesCompliantBeforeUpgrade contains the managed policies that did not need remediation
+	ManagedPoliciesCompliantBeforeUpgrade []string `json:"managedPoliciesCompliantBeforeUpgrade,omitempty"`
+	// ManifestWorkTemplates contains the ManifestWorkReplicaSet templates that were rolled out
+	ManifestWorkTemplates []string           `json:"manifestWorkTemplates,omitempty"`
+	Precaching            *PrecachingStatus  `json:"precaching,omitempty"`
+	Backup                *BackupStatus      `json:"backup,omitempty"`
+	Conditions            []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=upgradereports
+//+kubebuilder:printcolumn:name="ClusterGroupUpgrade",type="string",JSONPath=".spec.clusterGroupUpgrade.name"
+//+kubebuilder:printcolumn:name="Completed",type="date",JSONPath=".spec.completedAt"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// UpgradeReport is the Schema for the upgradereports API. It is written once when a
+// ClusterGroupUpgrade completes and is not owned by it, so it outlives the ClusterGroupUpgrade.
+// +operator-sdk:csv:customresourcedefinitions:displayName="Upgrade Report"
+type UpgradeReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="the report is immutable"
+	Spec UpgradeReportSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// UpgradeReportList contains a list of UpgradeReport
+type UpgradeReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UpgradeReport `json:"items"`
+}
@@ -23,6 +23,7 @@ package v1alpha1
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -249,10 +250,30 @@ func (in *ClusterGroupUpgradeList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupUpgradeReference) DeepCopyInto(out *ClusterGroupUpgradeReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupUpgradeReference.
+func (in *ClusterGroupUpgradeReference) DeepCopy() *ClusterGroupUpgradeReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupUpgradeReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterGroupUpgradeSpec) DeepCopyInto(out *ClusterGroupUpgradeSpec) {
 	*out = *in
 	out.PreCachingConfigRef = in.PreCachingConfigRef
+	if in.PreCachingConcurrency != nil {
+		in, out := &in.PreCachingConcurrency, &out.PreCachingConcurrency
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 	if in.Enable != nil {
 		in, out := &in.Enable, &out.Enable
 		*out = new(bool)
@@ -285,17 +306,39 @@ func (in *ClusterGroupUpgradeSpec) DeepCopyInto(out *ClusterGroupUpgradeSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ManagedPolicySets != nil {
+		in, out := &in.ManagedPolicySets, &out.ManagedPolicySets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ManifestWorkTemplates != nil {
 		in, out := &in.ManifestWorkTemplates, &out.ManifestWorkTemplates
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ManifestWorkStages != nil {
+		in, out := &in.ManifestWorkStages, &out.ManifestWorkStages
+		*out = make([]ManifestWorkStage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImageBasedUpgrade != nil {
+		in, out := &in.ImageBasedUpgrade, &out.ImageBasedUpgrade
+		*out = new(ImageBasedUpgradeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BlockingCRs != nil {
 		in, out := &in.BlockingCRs, &out.BlockingCRs
 		*out = make([]BlockingCR, len(*in))
 		copy(*out, *in)
 	}
 	in.Actions.DeepCopyInto(&out.Actions)
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupUpgradeSpec.
@@ -321,6 +364,11 @@ func (in *ClusterGroupUpgradeStatus) DeepCopyInto(out *ClusterGroupUpgradeStatus
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Placements != nil {
+		in, out := &in.Placements, &out.Placements
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.CopiedPolicies != nil {
 		in, out := &in.CopiedPolicies, &out.CopiedPolicies
 		*out = make([]string, len(*in))
@@ -418,7 +466,37 @@ func (in *ClusterRemediationProgress) DeepCopyInto(out *ClusterRemediationProgre
 		*out = new(int)
 		**out = **in
 	}
+	if in.ImageBasedUpgradeIndex != nil {
+		in, out := &in.ImageBasedUpgradeIndex, &out.ImageBasedUpgradeIndex
+		*out = new(int)
+		**out = **in
+	}
 	in.FirstCompliantAt.DeepCopyInto(&out.FirstCompliantAt)
+	in.PolicyStartedAt.DeepCopyInto(&out.PolicyStartedAt)
+	if in.ClusterVersion != nil {
+		in, out := &in.ClusterVersion, &out.ClusterVersion
+		*out = new(ClusterVersionProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Operators != nil {
+		in, out := &in.Operators, &out.Operators
+		*out = make([]OperatorUpgradeProgress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManifestWorks != nil {
+		in, out := &in.ManifestWorks, &out.ManifestWorks
+		*out = make([]ManifestWorkProgress, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImageBasedUpgrade != nil {
+		in, out := &in.ImageBasedUpgrade, &out.ImageBasedUpgrade
+		*out = new(ImageBasedUpgradeProgress)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRemediationProgress.
@@ -444,6 +522,8 @@ func (in *ClusterState) DeepCopyInto(out *ClusterState) {
 		*out = new(ManifestWorkStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	in.CompletedAt.DeepCopyInto(&out.CompletedAt)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterState.
@@ -456,6 +536,67 @@ func (in *ClusterState) DeepCopy() *ClusterState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVersionProgress) DeepCopyInto(out *ClusterVersionProgress) {
+	*out = *in
+	if in.CompletedPercentage != nil {
+		in, out := &in.CompletedPercentage, &out.CompletedPercentage
+		*out = new(int)
+		**out = **in
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVersionProgress.
+func (in *ClusterVersionProgress) DeepCopy() *ClusterVersionProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVersionProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageBasedUpgradeProgress) DeepCopyInto(out *ImageBasedUpgradeProgress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageBasedUpgradeProgress.
+func (in *ImageBasedUpgradeProgress) DeepCopy() *ImageBasedUpgradeProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageBasedUpgradeProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageBasedUpgradeSpec) DeepCopyInto(out *ImageBasedUpgradeSpec) {
+	*out = *in
+	in.SeedImageRef.DeepCopyInto(&out.SeedImageRef)
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]ImageBasedUpgradeStage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageBasedUpgradeSpec.
+func (in *ImageBasedUpgradeSpec) DeepCopy() *ImageBasedUpgradeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageBasedUpgradeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedPolicyForUpgrade) DeepCopyInto(out *ManagedPolicyForUpgrade) {
 	*out = *in
@@ -471,6 +612,61 @@ func (in *ManagedPolicyForUpgrade) DeepCopy() *ManagedPolicyForUpgrade {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestWorkFeedbackValue) DeepCopyInto(out *ManifestWorkFeedbackValue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestWorkFeedbackValue.
+func (in *ManifestWorkFeedbackValue) DeepCopy() *ManifestWorkFeedbackValue {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestWorkFeedbackValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestWorkProgress) DeepCopyInto(out *ManifestWorkProgress) {
+	*out = *in
+	if in.FeedbackValues != nil {
+		in, out := &in.FeedbackValues, &out.FeedbackValues
+		*out = make([]ManifestWorkFeedbackValue, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestWorkProgress.
+func (in *ManifestWorkProgress) DeepCopy() *ManifestWorkProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestWorkProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestWorkStage) DeepCopyInto(out *ManifestWorkStage) {
+	*out = *in
+	if in.Templates != nil {
+		in, out := &in.Templates, &out.Templates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestWorkStage.
+func (in *ManifestWorkStage) DeepCopy() *ManifestWorkStage {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestWorkStage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManifestWorkStatus) DeepCopyInto(out *ManifestWorkStatus) {
 	*out = *in
@@ -502,6 +698,30 @@ func (in *NamespacedCR) DeepCopy() *NamespacedCR {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorUpgradeProgress) DeepCopyInto(out *OperatorUpgradeProgress) {
+	*out = *in
+	if in.CSVFailedSince != nil {
+		in, out := &in.CSVFailedSince, &out.CSVFailedSince
+		*out = (*in).DeepCopy()
+	}
+	if in.ApprovedInstallPlans != nil {
+		in, out := &in.ApprovedInstallPlans, &out.ApprovedInstallPlans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorUpgradeProgress.
+func (in *OperatorUpgradeProgress) DeepCopy() *OperatorUpgradeProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorUpgradeProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperatorUpgradeSpec) DeepCopyInto(out *OperatorUpgradeSpec) {
 	*out = *in
@@ -656,6 +876,43 @@ func (in *PreCachingConfigSpec) DeepCopy() *PreCachingConfigSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrecachingAttempts) DeepCopyInto(out *PrecachingAttempts) {
+	*out = *in
+	in.LastFailedAt.DeepCopyInto(&out.LastFailedAt)
+	in.NextAttemptAt.DeepCopyInto(&out.NextAttemptAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrecachingAttempts.
+func (in *PrecachingAttempts) DeepCopy() *PrecachingAttempts {
+	if in == nil {
+		return nil
+	}
+	out := new(PrecachingAttempts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrecachingProgress) DeepCopyInto(out *PrecachingProgress) {
+	*out = *in
+	if in.FailedImages != nil {
+		in, out := &in.FailedImages, &out.FailedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrecachingProgress.
+func (in *PrecachingProgress) DeepCopy() *PrecachingProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(PrecachingProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrecachingSpec) DeepCopyInto(out *PrecachingSpec) {
 	*out = *in
@@ -679,6 +936,13 @@ func (in *PrecachingSpec) DeepCopyInto(out *PrecachingSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RegistryMirrors != nil {
+		in, out := &in.RegistryMirrors, &out.RegistryMirrors
+		*out = make([]RegistryMirror, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrecachingSpec.
@@ -706,6 +970,20 @@ func (in *PrecachingStatus) DeepCopyInto(out *PrecachingStatus) {
 			(*out)[key] = val
 		}
 	}
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make(map[string]PrecachingAttempts, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = make(map[string]PrecachingProgress, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
 	if in.Clusters != nil {
 		in, out := &in.Clusters, &out.Clusters
 		*out = make([]string, len(*in))
@@ -723,6 +1001,41 @@ func (in *PrecachingStatus) DeepCopy() *PrecachingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullSecretRef) DeepCopyInto(out *PullSecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullSecretRef.
+func (in *PullSecretRef) DeepCopy() *PullSecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(PullSecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryMirror) DeepCopyInto(out *RegistryMirror) {
+	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirror.
+func (in *RegistryMirror) DeepCopy() *RegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemediationStrategySpec) DeepCopyInto(out *RemediationStrategySpec) {
 	*out = *in
@@ -743,12 +1056,160 @@ func (in *RemediationStrategySpec) DeepCopy() *RemediationStrategySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SeedImageRef) DeepCopyInto(out *SeedImageRef) {
+	*out = *in
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(PullSecretRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SeedImageRef.
+func (in *SeedImageRef) DeepCopy() *SeedImageRef {
+	if in == nil {
+		return nil
+	}
+	out := new(SeedImageRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeReport) DeepCopyInto(out *UpgradeReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeReport.
+func (in *UpgradeReport) DeepCopy() *UpgradeReport {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UpgradeReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeReportList) DeepCopyInto(out *UpgradeReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]UpgradeReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeReportList.
+func (in *UpgradeReportList) DeepCopy() *UpgradeReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UpgradeReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeReportSpec) DeepCopyInto(out *UpgradeReportSpec) {
+	*out = *in
+	out.ClusterGroupUpgrade = in.ClusterGroupUpgrade
+	in.ClusterGroupUpgradeSpec.DeepCopyInto(&out.ClusterGroupUpgradeSpec)
+	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	in.CompletedAt.DeepCopyInto(&out.CompletedAt)
+	if in.RemediationPlan != nil {
+		in, out := &in.RemediationPlan, &out.RemediationPlan
+		*out = make([][]string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ManagedPolicies != nil {
+		in, out := &in.ManagedPolicies, &out.ManagedPolicies
+		*out = make([]ManagedPolicyForUpgrade, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedPoliciesCompliantBeforeUpgrade != nil {
+		in, out := &in.ManagedPoliciesCompliantBeforeUpgrade, &out.ManagedPoliciesCompliantBeforeUpgrade
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManifestWorkTemplates != nil {
+		in, out := &in.ManifestWorkTemplates, &out.ManifestWorkTemplates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Precaching != nil {
+		in, out := &in.Precaching, &out.Precaching
+		*out = new(PrecachingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(BackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeReportSpec.
+func (in *UpgradeReportSpec) DeepCopy() *UpgradeReportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeReportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
 	*out = *in
 	in.StartedAt.DeepCopyInto(&out.StartedAt)
 	in.CompletedAt.DeepCopyInto(&out.CompletedAt)
 	in.CurrentBatchStartedAt.DeepCopyInto(&out.CurrentBatchStartedAt)
+	in.CurrentBatchCompletedAt.DeepCopyInto(&out.CurrentBatchCompletedAt)
 	if in.CurrentBatchRemediationProgress != nil {
 		in, out := &in.CurrentBatchRemediationProgress, &out.CurrentBatchRemediationProgress
 		*out = make(map[string]*ClusterRemediationProgress, len(*in))
@@ -0,0 +1,60 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	types "k8s.io/apimachinery/pkg/types"
+)
+
+// ClusterGroupUpgradeReferenceApplyConfiguration represents an declarative configuration of the ClusterGroupUpgradeReference type for use
+// with apply.
+type ClusterGroupUpgradeReferenceApplyConfiguration struct {
+	Name      *string    `json:"name,omitempty"`
+	Namespace *string    `json:"namespace,omitempty"`
+	UID       *types.UID `json:"uid,omitempty"`
+}
+
+// ClusterGroupUpgradeReferenceApplyConfiguration constructs an declarative configuration of the ClusterGroupUpgradeReference type for use with
+// apply.
+func ClusterGroupUpgradeReference() *ClusterGroupUpgradeReferenceApplyConfiguration {
+	return &ClusterGroupUpgradeReferenceApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ClusterGroupUpgradeReferenceApplyConfiguration) WithName(value string) *ClusterGroupUpgradeReferenceApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *ClusterGroupUpgradeReferenceApplyConfiguration) WithNamespace(value string) *ClusterGroupUpgradeReferenceApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithUID sets the UID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UID field is set to the value of the last call.
+func (b *ClusterGroupUpgradeReferenceApplyConfiguration) WithUID(value types.UID) *ClusterGroupUpgradeReferenceApplyConfiguration {
+	b.UID = &value
+	return b
+}
@@ -19,24 +19,31 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // ClusterGroupUpgradeSpecApplyConfiguration represents an declarative configuration of the ClusterGroupUpgradeSpec type for use
 // with apply.
 type ClusterGroupUpgradeSpecApplyConfiguration struct {
-	Backup                *bool                                      `json:"backup,omitempty"`
-	PreCaching            *bool                                      `json:"preCaching,omitempty"`
-	PreCachingConfigRef   *PreCachingConfigCRApplyConfiguration      `json:"preCachingConfigRef,omitempty"`
-	Enable                *bool                                      `json:"enable,omitempty"`
-	Clusters              []string                                   `json:"clusters,omitempty"`
-	ClusterSelector       []string                                   `json:"clusterSelector,omitempty"`
-	ClusterLabelSelectors []v1.LabelSelector                         `json:"clusterLabelSelectors,omitempty"`
-	RemediationStrategy   *RemediationStrategySpecApplyConfiguration `json:"remediationStrategy,omitempty"`
-	ManagedPolicies       []string                                   `json:"managedPolicies,omitempty"`
-	ManifestWorkTemplates []string                                   `json:"manifestWorkTemplates,omitempty"`
-	BlockingCRs           []BlockingCRApplyConfiguration             `json:"blockingCRs,omitempty"`
-	Actions               *ActionsApplyConfiguration                 `json:"actions,omitempty"`
-	BatchTimeoutAction    *string                                    `json:"batchTimeoutAction,omitempty"`
+	Backup                  *bool                                      `json:"backup,omitempty"`
+	PreCaching              *bool                                      `json:"preCaching,omitempty"`
+	PreCachingConfigRef     *PreCachingConfigCRApplyConfiguration      `json:"preCachingConfigRef,omitempty"`
+	PreCachingConcurrency   *intstr.IntOrString                        `json:"preCachingConcurrency,omitempty"`
+	Enable                  *bool                                      `json:"enable,omitempty"`
+	Clusters                []string                                   `json:"clusters,omitempty"`
+	ClusterSelector         []string                                   `json:"clusterSelector,omitempty"`
+	ClusterLabelSelectors   []v1.LabelSelector                         `json:"clusterLabelSelectors,omitempty"`
+	RemediationStrategy     *RemediationStrategySpecApplyConfiguration `json:"remediationStrategy,omitempty"`
+	ManagedPolicies         []string                                   `json:"managedPolicies,omitempty"`
+	ManagedPolicySets       []string                                   `json:"managedPolicySets,omitempty"`
+	PolicyOrdering          *string                                    `json:"policyOrdering,omitempty"`
+	ManifestWorkTemplates   []string                                   `json:"manifestWorkTemplates,omitempty"`
+	ManifestWorkStages      []ManifestWorkStageApplyConfiguration      `json:"manifestWorkStages,omitempty"`
+	ImageBasedUpgrade       *ImageBasedUpgradeSpecApplyConfiguration   `json:"imageBasedUpgrade,omitempty"`
+	BlockingCRs             []BlockingCRApplyConfiguration             `json:"blockingCRs,omitempty"`
+	Actions                 *ActionsApplyConfiguration                 `json:"actions,omitempty"`
+	BatchTimeoutAction      *string                                    `json:"batchTimeoutAction,omitempty"`
+	TTLSecondsAfterFinished *int32                                     `json:"ttlSecondsAfterFinished,omitempty"`
 }
 
 // ClusterGroupUpgradeSpecApplyConfiguration constructs an declarative configuration of the ClusterGroupUpgradeSpec type for use with
@@ -69,6 +76,14 @@ func (b *ClusterGroupUpgradeSpecApplyConfiguration) WithPreCachingConfigRef(valu
 	return b
 }
 
+// WithPreCachingConcurrency sets the PreCachingConcurrency field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PreCachingConcurrency field is set to the value of the last call.
+func (b *ClusterGroupUpgradeSpecApplyConfiguration) WithPreCachingConcurrency(value intstr.IntOrString) *ClusterGroupUpgradeSpecApplyConfiguration {
+	b.PreCachingConcurrency = &value
+	return b
+}
+
 // WithEnable sets the Enable field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Enable field is set to the value of the last call.
@@ -125,6 +140,24 @@ func (b *ClusterGroupUpgradeSpecApplyConfiguration) WithManagedPolicies(values .
 	return b
 }
 
+// WithManagedPolicySets adds the given value to the ManagedPolicySets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ManagedPolicySets field.
+func (b *ClusterGroupUpgradeSpecApplyConfiguration) WithManagedPolicySets(values ...string) *ClusterGroupUpgradeSpecApplyConfiguration {
+	for i := range values {
+		b.ManagedPolicySets = append(b.ManagedPolicySets, values[i])
+	}
+	return b
+}
+
+// WithPolicyOrdering sets the PolicyOrdering field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PolicyOrdering field is set to the value of the last call.
+func (b *ClusterGroupUpgradeSpecApplyConfiguration) WithPolicyOrdering(value string) *ClusterGroupUpgradeSpecApplyConfiguration {
+	b.PolicyOrdering = &value
+	return b
+}
+
 // WithManifestWorkTemplates adds the given value to the ManifestWorkTemplates field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the ManifestWorkTemplates field.
@@ -135,6 +168,27 @@ func (b *ClusterGroupUpgradeSpecApplyConfiguration) WithManifestWorkTemplates(va
 	return b
 }
 
+// WithManifestWorkStages adds the given value to the ManifestWorkStages field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ManifestWorkStages field.
+func (b *ClusterGroupUpgradeSpecApplyConfiguration) WithManifestWorkStages(values ...*ManifestWorkStageApplyConfiguration) *ClusterGroupUpgradeSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithManifestWorkStages")
+		}
+		b.ManifestWorkStages = append(b.ManifestWorkStages, *values[i])
+	}
+	return b
+}
+
+// WithImageBasedUpgrade sets the ImageBasedUpgrade field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImageBasedUpgrade field is set to the value of the last call.
+func (b *ClusterGroupUpgradeSpecApplyConfiguration) WithImageBasedUpgrade(value *ImageBasedUpgradeSpecApplyConfiguration) *ClusterGroupUpgradeSpecApplyConfiguration {
+	b.ImageBasedUpgrade = value
+	return b
+}
+
 // WithBlockingCRs adds the given value to the BlockingCRs field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the BlockingCRs field.
@@ -163,3 +217,11 @@ func (b *ClusterGroupUpgradeSpecApplyConfiguration) WithBatchTimeoutAction(value
 	b.BatchTimeoutAction = &value
 	return b
 }
+
+// WithTTLSecondsAfterFinished sets the TTLSecondsAfterFinished field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TTLSecondsAfterFinished field is set to the value of the last call.
+func (b *ClusterGroupUpgradeSpecApplyConfiguration) WithTTLSecondsAfterFinished(value int32) *ClusterGroupUpgradeSpecApplyConfiguration {
+	b.TTLSecondsAfterFinished = &value
+	return b
+}
@@ -26,6 +26,7 @@ import (
 type ClusterGroupUpgradeStatusApplyConfiguration struct {
 	PlacementBindings                     []string                                    `json:"placementBindings,omitempty"`
 	PlacementRules                        []string                                    `json:"placementRules,omitempty"`
+	Placements                            []string                                    `json:"placements,omitempty"`
 	CopiedPolicies                        []string                                    `json:"copiedPolicies,omitempty"`
 	Conditions                            []v1.Condition                              `json:"conditions,omitempty"`
 	RemediationPlan                       [][]string                                  `json:"remediationPlan,omitempty"`
@@ -39,6 +40,7 @@ type ClusterGroupUpgradeStatusApplyConfiguration struct {
 	Precaching                            *PrecachingStatusApplyConfiguration         `json:"precaching,omitempty"`
 	Backup                                *BackupStatusApplyConfiguration             `json:"backup,omitempty"`
 	ComputedMaxConcurrency                *int                                        `json:"computedMaxConcurrency,omitempty"`
+	UpgradeReport                         *string                                     `json:"upgradeReport,omitempty"`
 }
 
 // ClusterGroupUpgradeStatusApplyConfiguration constructs an declarative configuration of the ClusterGroupUpgradeStatus type for use with
@@ -67,6 +69,16 @@ func (b *ClusterGroupUpgradeStatusApplyConfiguration) WithPlacementRules(values
 	return b
 }
 
+// WithPlacements adds the given value to the Placements field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Placements field.
+func (b *ClusterGroupUpgradeStatusApplyConfiguration) WithPlacements(values ...string) *ClusterGroupUpgradeStatusApplyConfiguration {
+	for i := range values {
+		b.Placements = append(b.Placements, values[i])
+	}
+	return b
+}
+
 // WithCopiedPolicies adds the given value to the CopiedPolicies field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the CopiedPolicies field.
@@ -206,3 +218,11 @@ func (b *ClusterGroupUpgradeStatusApplyConfiguration) WithComputedMaxConcurrency
 	b.ComputedMaxConcurrency = &value
 	return b
 }
+
+// WithUpgradeReport sets the UpgradeReport field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpgradeReport field is set to the value of the last call.
+func (b *ClusterGroupUpgradeStatusApplyConfiguration) WithUpgradeReport(value string) *ClusterGroupUpgradeStatusApplyConfiguration {
+	b.UpgradeReport = &value
+	return b
+}
@@ -24,10 +24,16 @@ import (
 // ClusterRemediationProgressApplyConfiguration represents an declarative configuration of the ClusterRemediationProgress type for use
 // with apply.
 type ClusterRemediationProgressApplyConfiguration struct {
-	State             *string  `json:"state,omitempty"`
-	ManifestWorkIndex *int     `json:"manifestWorkIndex,omitempty"`
-	PolicyIndex       *int     `json:"policyIndex,omitempty"`
-	FirstCompliantAt  *v1.Time `json:"firstComplaintAt,omitempty"`
+	State                  *string                                      `json:"state,omitempty"`
+	ManifestWorkIndex      *int                                         `json:"manifestWorkIndex,omitempty"`
+	PolicyIndex            *int                                         `json:"policyIndex,omitempty"`
+	ImageBasedUpgradeIndex *int                                         `json:"imageBasedUpgradeIndex,omitempty"`
+	FirstCompliantAt       *v1.Time                                     `json:"firstComplaintAt,omitempty"`
+	PolicyStartedAt        *v1.Time                                     `json:"policyStartedAt,omitempty"`
+	ClusterVersion         *ClusterVersionProgressApplyConfiguration    `json:"clusterVersion,omitempty"`
+	Operators              []OperatorUpgradeProgressApplyConfiguration  `json:"operators,omitempty"`
+	ManifestWorks          []ManifestWorkProgressApplyConfiguration     `json:"manifestWorks,omitempty"`
+	ImageBasedUpgrade      *ImageBasedUpgradeProgressApplyConfiguration `json:"imageBasedUpgrade,omitempty"`
 }
 
 // ClusterRemediationProgressApplyConfiguration constructs an declarative configuration of the ClusterRemediationProgress type for use with
@@ -60,6 +66,14 @@ func (b *ClusterRemediationProgressApplyConfiguration) WithPolicyIndex(value int
 	return b
 }
 
+// WithImageBasedUpgradeIndex sets the ImageBasedUpgradeIndex field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImageBasedUpgradeIndex field is set to the value of the last call.
+func (b *ClusterRemediationProgressApplyConfiguration) WithImageBasedUpgradeIndex(value int) *ClusterRemediationProgressApplyConfiguration {
+	b.ImageBasedUpgradeIndex = &value
+	return b
+}
+
 // WithFirstCompliantAt sets the FirstCompliantAt field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the FirstCompliantAt field is set to the value of the last call.
@@ -67,3 +81,53 @@ func (b *ClusterRemediationProgressApplyConfiguration) WithFirstCompliantAt(valu
 	b.FirstCompliantAt = &value
 	return b
 }
+
+// WithPolicyStartedAt sets the PolicyStartedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PolicyStartedAt field is set to the value of the last call.
+func (b *ClusterRemediationProgressApplyConfiguration) WithPolicyStartedAt(value v1.Time) *ClusterRemediationProgressApplyConfiguration {
+	b.PolicyStartedAt = &value
+	return b
+}
+
+// WithClusterVersion sets the ClusterVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterVersion field is set to the value of the last call.
+func (b *ClusterRemediationProgressApplyConfiguration) WithClusterVersion(value *ClusterVersionProgressApplyConfiguration) *ClusterRemediationProgressApplyConfiguration {
+	b.ClusterVersion = value
+	return b
+}
+
+// WithOperators adds the given value to the Operators field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Operators field.
+func (b *ClusterRemediationProgressApplyConfiguration) WithOperators(values ...*OperatorUpgradeProgressApplyConfiguration) *ClusterRemediationProgressApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithOperators")
+		}
+		b.Operators = append(b.Operators, *values[i])
+	}
+	return b
+}
+
+// WithManifestWorks adds the given value to the ManifestWorks field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ManifestWorks field.
+func (b *ClusterRemediationProgressApplyConfiguration) WithManifestWorks(values ...*ManifestWorkProgressApplyConfiguration) *ClusterRemediationProgressApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithManifestWorks")
+		}
+		b.ManifestWorks = append(b.ManifestWorks, *values[i])
+	}
+	return b
+}
+
+// WithImageBasedUpgrade sets the ImageBasedUpgrade field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImageBasedUpgrade field is set to the value of the last call.
+func (b *ClusterRemediationProgressApplyConfiguration) WithImageBasedUpgrade(value *ImageBasedUpgradeProgressApplyConfiguration) *ClusterRemediationProgressApplyConfiguration {
+	b.ImageBasedUpgrade = value
+	return b
+}
@@ -17,6 +17,10 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // ClusterStateApplyConfiguration represents an declarative configuration of the ClusterState type for use
 // with apply.
 type ClusterStateApplyConfiguration struct {
@@ -24,6 +28,9 @@ type ClusterStateApplyConfiguration struct {
 	State               *string                               `json:"state,omitempty"`
 	CurrentPolicy       *PolicyStatusApplyConfiguration       `json:"currentPolicy,omitempty"`
 	CurrentManifestWork *ManifestWorkStatusApplyConfiguration `json:"currentManifestWork,omitempty"`
+	Message             *string                               `json:"message,omitempty"`
+	StartedAt           *v1.Time                              `json:"startedAt,omitempty"`
+	CompletedAt         *v1.Time                              `json:"completedAt,omitempty"`
 }
 
 // ClusterStateApplyConfiguration constructs an declarative configuration of the ClusterState type for use with
@@ -63,3 +70,27 @@ func (b *ClusterStateApplyConfiguration) WithCurrentManifestWork(value *Manifest
 	b.CurrentManifestWork = value
 	return b
 }
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ClusterStateApplyConfiguration) WithMessage(value string) *ClusterStateApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithStartedAt sets the StartedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartedAt field is set to the value of the last call.
+func (b *ClusterStateApplyConfiguration) WithStartedAt(value v1.Time) *ClusterStateApplyConfiguration {
+	b.StartedAt = &value
+	return b
+}
+
+// WithCompletedAt sets the CompletedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletedAt field is set to the value of the last call.
+func (b *ClusterStateApplyConfiguration) WithCompletedAt(value v1.Time) *ClusterStateApplyConfiguration {
+	b.CompletedAt = &value
+	return b
+}
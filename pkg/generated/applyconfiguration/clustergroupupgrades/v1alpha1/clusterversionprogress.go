@@ -0,0 +1,76 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ClusterVersionProgressApplyConfiguration represents an declarative configuration of the ClusterVersionProgress type for use
+// with apply.
+type ClusterVersionProgressApplyConfiguration struct {
+	CurrentVersion      *string  `json:"currentVersion,omitempty"`
+	DesiredVersion      *string  `json:"desiredVersion,omitempty"`
+	Message             *string  `json:"message,omitempty"`
+	CompletedPercentage *int     `json:"completedPercentage,omitempty"`
+	Failures            []string `json:"failures,omitempty"`
+}
+
+// ClusterVersionProgressApplyConfiguration constructs an declarative configuration of the ClusterVersionProgress type for use with
+// apply.
+func ClusterVersionProgress() *ClusterVersionProgressApplyConfiguration {
+	return &ClusterVersionProgressApplyConfiguration{}
+}
+
+// WithCurrentVersion sets the CurrentVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CurrentVersion field is set to the value of the last call.
+func (b *ClusterVersionProgressApplyConfiguration) WithCurrentVersion(value string) *ClusterVersionProgressApplyConfiguration {
+	b.CurrentVersion = &value
+	return b
+}
+
+// WithDesiredVersion sets the DesiredVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DesiredVersion field is set to the value of the last call.
+func (b *ClusterVersionProgressApplyConfiguration) WithDesiredVersion(value string) *ClusterVersionProgressApplyConfiguration {
+	b.DesiredVersion = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ClusterVersionProgressApplyConfiguration) WithMessage(value string) *ClusterVersionProgressApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithCompletedPercentage sets the CompletedPercentage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletedPercentage field is set to the value of the last call.
+func (b *ClusterVersionProgressApplyConfiguration) WithCompletedPercentage(value int) *ClusterVersionProgressApplyConfiguration {
+	b.CompletedPercentage = &value
+	return b
+}
+
+// WithFailures adds the given value to the Failures field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Failures field.
+func (b *ClusterVersionProgressApplyConfiguration) WithFailures(values ...string) *ClusterVersionProgressApplyConfiguration {
+	for i := range values {
+		b.Failures = append(b.Failures, values[i])
+	}
+	return b
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+)
+
+// ImageBasedUpgradeProgressApplyConfiguration represents an declarative configuration of the ImageBasedUpgradeProgress type for use
+// with apply.
+type ImageBasedUpgradeProgressApplyConfiguration struct {
+	Stage          *v1alpha1.ImageBasedUpgradeStage `json:"stage,omitempty"`
+	Completed      *bool                            `json:"completed,omitempty"`
+	Message        *string                          `json:"message,omitempty"`
+	RollbackReason *string                          `json:"rollbackReason,omitempty"`
+}
+
+// ImageBasedUpgradeProgressApplyConfiguration constructs an declarative configuration of the ImageBasedUpgradeProgress type for use with
+// apply.
+func ImageBasedUpgradeProgress() *ImageBasedUpgradeProgressApplyConfiguration {
+	return &ImageBasedUpgradeProgressApplyConfiguration{}
+}
+
+// WithStage sets the Stage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Stage field is set to the value of the last call.
+func (b *ImageBasedUpgradeProgressApplyConfiguration) WithStage(value v1alpha1.ImageBasedUpgradeStage) *ImageBasedUpgradeProgressApplyConfiguration {
+	b.Stage = &value
+	return b
+}
+
+// WithCompleted sets the Completed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Completed field is set to the value of the last call.
+func (b *ImageBasedUpgradeProgressApplyConfiguration) WithCompleted(value bool) *ImageBasedUpgradeProgressApplyConfiguration {
+	b.Completed = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *ImageBasedUpgradeProgressApplyConfiguration) WithMessage(value string) *ImageBasedUpgradeProgressApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithRollbackReason sets the RollbackReason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RollbackReason field is set to the value of the last call.
+func (b *ImageBasedUpgradeProgressApplyConfiguration) WithRollbackReason(value string) *ImageBasedUpgradeProgressApplyConfiguration {
+	b.RollbackReason = &value
+	return b
+}
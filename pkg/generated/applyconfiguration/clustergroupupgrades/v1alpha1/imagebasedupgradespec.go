@@ -0,0 +1,53 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	clustergroupupgradesv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+)
+
+// ImageBasedUpgradeSpecApplyConfiguration represents an declarative configuration of the ImageBasedUpgradeSpec type for use
+// with apply.
+type ImageBasedUpgradeSpecApplyConfiguration struct {
+	SeedImageRef *SeedImageRefApplyConfiguration                       `json:"seedImageRef,omitempty"`
+	Stages       []clustergroupupgradesv1alpha1.ImageBasedUpgradeStage `json:"stages,omitempty"`
+}
+
+// ImageBasedUpgradeSpecApplyConfiguration constructs an declarative configuration of the ImageBasedUpgradeSpec type for use with
+// apply.
+func ImageBasedUpgradeSpec() *ImageBasedUpgradeSpecApplyConfiguration {
+	return &ImageBasedUpgradeSpecApplyConfiguration{}
+}
+
+// WithSeedImageRef sets the SeedImageRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SeedImageRef field is set to the value of the last call.
+func (b *ImageBasedUpgradeSpecApplyConfiguration) WithSeedImageRef(value *SeedImageRefApplyConfiguration) *ImageBasedUpgradeSpecApplyConfiguration {
+	b.SeedImageRef = value
+	return b
+}
+
+// WithStages adds the given value to the Stages field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Stages field.
+func (b *ImageBasedUpgradeSpecApplyConfiguration) WithStages(values ...clustergroupupgradesv1alpha1.ImageBasedUpgradeStage) *ImageBasedUpgradeSpecApplyConfiguration {
+	for i := range values {
+		b.Stages = append(b.Stages, values[i])
+	}
+	return b
+}
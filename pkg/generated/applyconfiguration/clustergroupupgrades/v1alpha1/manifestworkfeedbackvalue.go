@@ -0,0 +1,74 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ManifestWorkFeedbackValueApplyConfiguration represents an declarative configuration of the ManifestWorkFeedbackValue type for use
+// with apply.
+type ManifestWorkFeedbackValueApplyConfiguration struct {
+	ManifestIndex *int32  `json:"manifestIndex,omitempty"`
+	Name          *string `json:"name,omitempty"`
+	Value         *string `json:"value,omitempty"`
+	Expected      *string `json:"expected,omitempty"`
+	Matched       *bool   `json:"matched,omitempty"`
+}
+
+// ManifestWorkFeedbackValueApplyConfiguration constructs an declarative configuration of the ManifestWorkFeedbackValue type for use with
+// apply.
+func ManifestWorkFeedbackValue() *ManifestWorkFeedbackValueApplyConfiguration {
+	return &ManifestWorkFeedbackValueApplyConfiguration{}
+}
+
+// WithManifestIndex sets the ManifestIndex field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ManifestIndex field is set to the value of the last call.
+func (b *ManifestWorkFeedbackValueApplyConfiguration) WithManifestIndex(value int32) *ManifestWorkFeedbackValueApplyConfiguration {
+	b.ManifestIndex = &value
+	return b
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ManifestWorkFeedbackValueApplyConfiguration) WithName(value string) *ManifestWorkFeedbackValueApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithValue sets the Value field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Value field is set to the value of the last call.
+func (b *ManifestWorkFeedbackValueApplyConfiguration) WithValue(value string) *ManifestWorkFeedbackValueApplyConfiguration {
+	b.Value = &value
+	return b
+}
+
+// WithExpected sets the Expected field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Expected field is set to the value of the last call.
+func (b *ManifestWorkFeedbackValueApplyConfiguration) WithExpected(value string) *ManifestWorkFeedbackValueApplyConfiguration {
+	b.Expected = &value
+	return b
+}
+
+// WithMatched sets the Matched field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Matched field is set to the value of the last call.
+func (b *ManifestWorkFeedbackValueApplyConfiguration) WithMatched(value bool) *ManifestWorkFeedbackValueApplyConfiguration {
+	b.Matched = &value
+	return b
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ManifestWorkProgressApplyConfiguration represents an declarative configuration of the ManifestWorkProgress type for use
+// with apply.
+type ManifestWorkProgressApplyConfiguration struct {
+	Name           *string                                       `json:"name,omitempty"`
+	Applied        *v1.ConditionStatus                           `json:"applied,omitempty"`
+	Available      *v1.ConditionStatus                           `json:"available,omitempty"`
+	Completed      *bool                                         `json:"completed,omitempty"`
+	FeedbackValues []ManifestWorkFeedbackValueApplyConfiguration `json:"feedbackValues,omitempty"`
+}
+
+// ManifestWorkProgressApplyConfiguration constructs an declarative configuration of the ManifestWorkProgress type for use with
+// apply.
+func ManifestWorkProgress() *ManifestWorkProgressApplyConfiguration {
+	return &ManifestWorkProgressApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ManifestWorkProgressApplyConfiguration) WithName(value string) *ManifestWorkProgressApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithApplied sets the Applied field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Applied field is set to the value of the last call.
+func (b *ManifestWorkProgressApplyConfiguration) WithApplied(value v1.ConditionStatus) *ManifestWorkProgressApplyConfiguration {
+	b.Applied = &value
+	return b
+}
+
+// WithAvailable sets the Available field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Available field is set to the value of the last call.
+func (b *ManifestWorkProgressApplyConfiguration) WithAvailable(value v1.ConditionStatus) *ManifestWorkProgressApplyConfiguration {
+	b.Available = &value
+	return b
+}
+
+// WithCompleted sets the Completed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Completed field is set to the value of the last call.
+func (b *ManifestWorkProgressApplyConfiguration) WithCompleted(value bool) *ManifestWorkProgressApplyConfiguration {
+	b.Completed = &value
+	return b
+}
+
+// WithFeedbackValues adds the given value to the FeedbackValues field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FeedbackValues field.
+func (b *ManifestWorkProgressApplyConfiguration) WithFeedbackValues(values ...*ManifestWorkFeedbackValueApplyConfiguration) *ManifestWorkProgressApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFeedbackValues")
+		}
+		b.FeedbackValues = append(b.FeedbackValues, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,49 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ManifestWorkStageApplyConfiguration represents an declarative configuration of the ManifestWorkStage type for use
+// with apply.
+type ManifestWorkStageApplyConfiguration struct {
+	Templates   []string `json:"templates,omitempty"`
+	KeepInPlace *bool    `json:"keepInPlace,omitempty"`
+}
+
+// ManifestWorkStageApplyConfiguration constructs an declarative configuration of the ManifestWorkStage type for use with
+// apply.
+func ManifestWorkStage() *ManifestWorkStageApplyConfiguration {
+	return &ManifestWorkStageApplyConfiguration{}
+}
+
+// WithTemplates adds the given value to the Templates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Templates field.
+func (b *ManifestWorkStageApplyConfiguration) WithTemplates(values ...string) *ManifestWorkStageApplyConfiguration {
+	for i := range values {
+		b.Templates = append(b.Templates, values[i])
+	}
+	return b
+}
+
+// WithKeepInPlace sets the KeepInPlace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KeepInPlace field is set to the value of the last call.
+func (b *ManifestWorkStageApplyConfiguration) WithKeepInPlace(value bool) *ManifestWorkStageApplyConfiguration {
+	b.KeepInPlace = &value
+	return b
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OperatorUpgradeProgressApplyConfiguration represents an declarative configuration of the OperatorUpgradeProgress type for use
+// with apply.
+type OperatorUpgradeProgressApplyConfiguration struct {
+	Subscription         *string  `json:"subscription,omitempty"`
+	Namespace            *string  `json:"namespace,omitempty"`
+	InstalledCSV         *string  `json:"installedCSV,omitempty"`
+	TargetCSV            *string  `json:"targetCSV,omitempty"`
+	CSVPhase             *string  `json:"csvPhase,omitempty"`
+	InstallPlan          *string  `json:"installPlan,omitempty"`
+	CSVFailedSince       *v1.Time `json:"csvFailedSince,omitempty"`
+	InstallPlanPhase     *string  `json:"installPlanPhase,omitempty"`
+	Message              *string  `json:"message,omitempty"`
+	ExpectedCSV          *string  `json:"expectedCSV,omitempty"`
+	InstallPlanRefusal   *string  `json:"installPlanRefusal,omitempty"`
+	ApprovedInstallPlans []string `json:"approvedInstallPlans,omitempty"`
+	Hops                 *int     `json:"hops,omitempty"`
+	CurrentHop           *string  `json:"currentHop,omitempty"`
+}
+
+// OperatorUpgradeProgressApplyConfiguration constructs an declarative configuration of the OperatorUpgradeProgress type for use with
+// apply.
+func OperatorUpgradeProgress() *OperatorUpgradeProgressApplyConfiguration {
+	return &OperatorUpgradeProgressApplyConfiguration{}
+}
+
+// WithSubscription sets the Subscription field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Subscription field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithSubscription(value string) *OperatorUpgradeProgressApplyConfiguration {
+	b.Subscription = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithNamespace(value string) *OperatorUpgradeProgressApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithInstalledCSV sets the InstalledCSV field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InstalledCSV field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithInstalledCSV(value string) *OperatorUpgradeProgressApplyConfiguration {
+	b.InstalledCSV = &value
+	return b
+}
+
+// WithTargetCSV sets the TargetCSV field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TargetCSV field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithTargetCSV(value string) *OperatorUpgradeProgressApplyConfiguration {
+	b.TargetCSV = &value
+	return b
+}
+
+// WithCSVPhase sets the CSVPhase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CSVPhase field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithCSVPhase(value string) *OperatorUpgradeProgressApplyConfiguration {
+	b.CSVPhase = &value
+	return b
+}
+
+// WithInstallPlan sets the InstallPlan field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InstallPlan field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithInstallPlan(value string) *OperatorUpgradeProgressApplyConfiguration {
+	b.InstallPlan = &value
+	return b
+}
+
+// WithCSVFailedSince sets the CSVFailedSince field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CSVFailedSince field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithCSVFailedSince(value v1.Time) *OperatorUpgradeProgressApplyConfiguration {
+	b.CSVFailedSince = &value
+	return b
+}
+
+// WithInstallPlanPhase sets the InstallPlanPhase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InstallPlanPhase field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithInstallPlanPhase(value string) *OperatorUpgradeProgressApplyConfiguration {
+	b.InstallPlanPhase = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithMessage(value string) *OperatorUpgradeProgressApplyConfiguration {
+	b.Message = &value
+	return b
+}
+
+// WithExpectedCSV sets the ExpectedCSV field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExpectedCSV field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithExpectedCSV(value string) *OperatorUpgradeProgressApplyConfiguration {
+	b.ExpectedCSV = &value
+	return b
+}
+
+// WithInstallPlanRefusal sets the InstallPlanRefusal field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InstallPlanRefusal field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithInstallPlanRefusal(value string) *OperatorUpgradeProgressApplyConfiguration {
+	b.InstallPlanRefusal = &value
+	return b
+}
+
+// WithApprovedInstallPlans adds the given value to the ApprovedInstallPlans field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ApprovedInstallPlans field.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithApprovedInstallPlans(values ...string) *OperatorUpgradeProgressApplyConfiguration {
+	for i := range values {
+		b.ApprovedInstallPlans = append(b.ApprovedInstallPlans, values[i])
+	}
+	return b
+}
+
+// WithHops sets the Hops field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Hops field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithHops(value int) *OperatorUpgradeProgressApplyConfiguration {
+	b.Hops = &value
+	return b
+}
+
+// WithCurrentHop sets the CurrentHop field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CurrentHop field is set to the value of the last call.
+func (b *OperatorUpgradeProgressApplyConfiguration) WithCurrentHop(value string) *OperatorUpgradeProgressApplyConfiguration {
+	b.CurrentHop = &value
+	return b
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PrecachingAttemptsApplyConfiguration represents an declarative configuration of the PrecachingAttempts type for use
+// with apply.
+type PrecachingAttemptsApplyConfiguration struct {
+	Count         *int     `json:"count,omitempty"`
+	LastFailure   *string  `json:"lastFailure,omitempty"`
+	LastFailedAt  *v1.Time `json:"lastFailedAt,omitempty"`
+	NextAttemptAt *v1.Time `json:"nextAttemptAt,omitempty"`
+}
+
+// PrecachingAttemptsApplyConfiguration constructs an declarative configuration of the PrecachingAttempts type for use with
+// apply.
+func PrecachingAttempts() *PrecachingAttemptsApplyConfiguration {
+	return &PrecachingAttemptsApplyConfiguration{}
+}
+
+// WithCount sets the Count field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Count field is set to the value of the last call.
+func (b *PrecachingAttemptsApplyConfiguration) WithCount(value int) *PrecachingAttemptsApplyConfiguration {
+	b.Count = &value
+	return b
+}
+
+// WithLastFailure sets the LastFailure field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastFailure field is set to the value of the last call.
+func (b *PrecachingAttemptsApplyConfiguration) WithLastFailure(value string) *PrecachingAttemptsApplyConfiguration {
+	b.LastFailure = &value
+	return b
+}
+
+// WithLastFailedAt sets the LastFailedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastFailedAt field is set to the value of the last call.
+func (b *PrecachingAttemptsApplyConfiguration) WithLastFailedAt(value v1.Time) *PrecachingAttemptsApplyConfiguration {
+	b.LastFailedAt = &value
+	return b
+}
+
+// WithNextAttemptAt sets the NextAttemptAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the NextAttemptAt field is set to the value of the last call.
+func (b *PrecachingAttemptsApplyConfiguration) WithNextAttemptAt(value v1.Time) *PrecachingAttemptsApplyConfiguration {
+	b.NextAttemptAt = &value
+	return b
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// PrecachingProgressApplyConfiguration represents an declarative configuration of the PrecachingProgress type for use
+// with apply.
+type PrecachingProgressApplyConfiguration struct {
+	Phase        *string  `json:"phase,omitempty"`
+	ImagesTotal  *int     `json:"imagesTotal,omitempty"`
+	ImagesPulled *int     `json:"imagesPulled,omitempty"`
+	ImagesFailed *int     `json:"imagesFailed,omitempty"`
+	BytesPulled  *int64   `json:"bytesPulled,omitempty"`
+	FailedImages []string `json:"failedImages,omitempty"`
+	Message      *string  `json:"message,omitempty"`
+}
+
+// PrecachingProgressApplyConfiguration constructs an declarative configuration of the PrecachingProgress type for use with
+// apply.
+func PrecachingProgress() *PrecachingProgressApplyConfiguration {
+	return &PrecachingProgressApplyConfiguration{}
+}
+
+// WithPhase sets the Phase field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Phase field is set to the value of the last call.
+func (b *PrecachingProgressApplyConfiguration) WithPhase(value string) *PrecachingProgressApplyConfiguration {
+	b.Phase = &value
+	return b
+}
+
+// WithImagesTotal sets the ImagesTotal field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImagesTotal field is set to the value of the last call.
+func (b *PrecachingProgressApplyConfiguration) WithImagesTotal(value int) *PrecachingProgressApplyConfiguration {
+	b.ImagesTotal = &value
+	return b
+}
+
+// WithImagesPulled sets the ImagesPulled field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImagesPulled field is set to the value of the last call.
+func (b *PrecachingProgressApplyConfiguration) WithImagesPulled(value int) *PrecachingProgressApplyConfiguration {
+	b.ImagesPulled = &value
+	return b
+}
+
+// WithImagesFailed sets the ImagesFailed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImagesFailed field is set to the value of the last call.
+func (b *PrecachingProgressApplyConfiguration) WithImagesFailed(value int) *PrecachingProgressApplyConfiguration {
+	b.ImagesFailed = &value
+	return b
+}
+
+// WithBytesPulled sets the BytesPulled field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BytesPulled field is set to the value of the last call.
+func (b *PrecachingProgressApplyConfiguration) WithBytesPulled(value int64) *PrecachingProgressApplyConfiguration {
+	b.BytesPulled = &value
+	return b
+}
+
+// WithFailedImages adds the given value to the FailedImages field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the FailedImages field.
+func (b *PrecachingProgressApplyConfiguration) WithFailedImages(values ...string) *PrecachingProgressApplyConfiguration {
+	for i := range values {
+		b.FailedImages = append(b.FailedImages, values[i])
+	}
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *PrecachingProgressApplyConfiguration) WithMessage(value string) *PrecachingProgressApplyConfiguration {
+	b.Message = &value
+	return b
+}
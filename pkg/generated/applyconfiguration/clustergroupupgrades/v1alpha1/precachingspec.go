@@ -20,12 +20,15 @@ package v1alpha1
 // PrecachingSpecApplyConfiguration represents an declarative configuration of the PrecachingSpec type for use
 // with apply.
 type PrecachingSpecApplyConfiguration struct {
-	PlatformImage                *string  `json:"platformImage,omitempty"`
-	OperatorsIndexes             []string `json:"operatorsIndexes,omitempty"`
-	OperatorsPackagesAndChannels []string `json:"operatorsPackagesAndChannels,omitempty"`
-	ExcludePrecachePatterns      []string `json:"excludePrecachePatterns,omitempty"`
-	SpaceRequired                *string  `json:"spaceRequired,omitempty"`
-	AdditionalImages             []string `json:"additionalImages,omitempty"`
+	PlatformImage                *string                            `json:"platformImage,omitempty"`
+	OperatorsIndexes             []string                           `json:"operatorsIndexes,omitempty"`
+	OperatorsPackagesAndChannels []string                           `json:"operatorsPackagesAndChannels,omitempty"`
+	ExcludePrecachePatterns      []string                           `json:"excludePrecachePatterns,omitempty"`
+	SpaceRequired                *string                            `json:"spaceRequired,omitempty"`
+	AdditionalImages             []string                           `json:"additionalImages,omitempty"`
+	Retries                      *int                               `json:"retries,omitempty"`
+	RetryBackoffSeconds          *int                               `json:"retryBackoffSeconds,omitempty"`
+	RegistryMirrors              []RegistryMirrorApplyConfiguration `json:"registryMirrors,omitempty"`
 }
 
 // PrecachingSpecApplyConfiguration constructs an declarative configuration of the PrecachingSpec type for use with
@@ -89,3 +92,32 @@ func (b *PrecachingSpecApplyConfiguration) WithAdditionalImages(values ...string
 	}
 	return b
 }
+
+// WithRetries sets the Retries field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Retries field is set to the value of the last call.
+func (b *PrecachingSpecApplyConfiguration) WithRetries(value int) *PrecachingSpecApplyConfiguration {
+	b.Retries = &value
+	return b
+}
+
+// WithRetryBackoffSeconds sets the RetryBackoffSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetryBackoffSeconds field is set to the value of the last call.
+func (b *PrecachingSpecApplyConfiguration) WithRetryBackoffSeconds(value int) *PrecachingSpecApplyConfiguration {
+	b.RetryBackoffSeconds = &value
+	return b
+}
+
+// WithRegistryMirrors adds the given value to the RegistryMirrors field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RegistryMirrors field.
+func (b *PrecachingSpecApplyConfiguration) WithRegistryMirrors(values ...*RegistryMirrorApplyConfiguration) *PrecachingSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRegistryMirrors")
+		}
+		b.RegistryMirrors = append(b.RegistryMirrors, *values[i])
+	}
+	return b
+}
@@ -20,9 +20,11 @@ package v1alpha1
 // PrecachingStatusApplyConfiguration represents an declarative configuration of the PrecachingStatus type for use
 // with apply.
 type PrecachingStatusApplyConfiguration struct {
-	Spec     *PrecachingSpecApplyConfiguration `json:"spec,omitempty"`
-	Status   map[string]string                 `json:"status,omitempty"`
-	Clusters []string                          `json:"clusters,omitempty"`
+	Spec     *PrecachingSpecApplyConfiguration               `json:"spec,omitempty"`
+	Status   map[string]string                               `json:"status,omitempty"`
+	Attempts map[string]PrecachingAttemptsApplyConfiguration `json:"attempts,omitempty"`
+	Progress map[string]PrecachingProgressApplyConfiguration `json:"progress,omitempty"`
+	Clusters []string                                        `json:"clusters,omitempty"`
 }
 
 // PrecachingStatusApplyConfiguration constructs an declarative configuration of the PrecachingStatus type for use with
@@ -53,6 +55,34 @@ func (b *PrecachingStatusApplyConfiguration) WithStatus(entries map[string]strin
 	return b
 }
 
+// WithAttempts puts the entries into the Attempts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Attempts field,
+// overwriting an existing map entries in Attempts field with the same key.
+func (b *PrecachingStatusApplyConfiguration) WithAttempts(entries map[string]PrecachingAttemptsApplyConfiguration) *PrecachingStatusApplyConfiguration {
+	if b.Attempts == nil && len(entries) > 0 {
+		b.Attempts = make(map[string]PrecachingAttemptsApplyConfiguration, len(entries))
+	}
+	for k, v := range entries {
+		b.Attempts[k] = v
+	}
+	return b
+}
+
+// WithProgress puts the entries into the Progress field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Progress field,
+// overwriting an existing map entries in Progress field with the same key.
+func (b *PrecachingStatusApplyConfiguration) WithProgress(entries map[string]PrecachingProgressApplyConfiguration) *PrecachingStatusApplyConfiguration {
+	if b.Progress == nil && len(entries) > 0 {
+		b.Progress = make(map[string]PrecachingProgressApplyConfiguration, len(entries))
+	}
+	for k, v := range entries {
+		b.Progress[k] = v
+	}
+	return b
+}
+
 // WithClusters adds the given value to the Clusters field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, values provided by each call will be appended to the Clusters field.
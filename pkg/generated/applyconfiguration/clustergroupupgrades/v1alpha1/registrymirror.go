@@ -0,0 +1,49 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RegistryMirrorApplyConfiguration represents an declarative configuration of the RegistryMirror type for use
+// with apply.
+type RegistryMirrorApplyConfiguration struct {
+	Source  *string  `json:"source,omitempty"`
+	Mirrors []string `json:"mirrors,omitempty"`
+}
+
+// RegistryMirrorApplyConfiguration constructs an declarative configuration of the RegistryMirror type for use with
+// apply.
+func RegistryMirror() *RegistryMirrorApplyConfiguration {
+	return &RegistryMirrorApplyConfiguration{}
+}
+
+// WithSource sets the Source field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Source field is set to the value of the last call.
+func (b *RegistryMirrorApplyConfiguration) WithSource(value string) *RegistryMirrorApplyConfiguration {
+	b.Source = &value
+	return b
+}
+
+// WithMirrors adds the given value to the Mirrors field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Mirrors field.
+func (b *RegistryMirrorApplyConfiguration) WithMirrors(values ...string) *RegistryMirrorApplyConfiguration {
+	for i := range values {
+		b.Mirrors = append(b.Mirrors, values[i])
+	}
+	return b
+}
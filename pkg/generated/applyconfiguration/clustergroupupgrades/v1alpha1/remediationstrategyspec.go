@@ -20,9 +20,10 @@ package v1alpha1
 // RemediationStrategySpecApplyConfiguration represents an declarative configuration of the RemediationStrategySpec type for use
 // with apply.
 type RemediationStrategySpecApplyConfiguration struct {
-	Canaries       []string `json:"canaries,omitempty"`
-	MaxConcurrency *int     `json:"maxConcurrency,omitempty"`
-	Timeout        *int     `json:"timeout,omitempty"`
+	Canaries         []string `json:"canaries,omitempty"`
+	MaxConcurrency   *int     `json:"maxConcurrency,omitempty"`
+	Timeout          *int     `json:"timeout,omitempty"`
+	BatchSoakSeconds *int     `json:"batchSoakSeconds,omitempty"`
 }
 
 // RemediationStrategySpecApplyConfiguration constructs an declarative configuration of the RemediationStrategySpec type for use with
@@ -56,3 +57,11 @@ func (b *RemediationStrategySpecApplyConfiguration) WithTimeout(value int) *Reme
 	b.Timeout = &value
 	return b
 }
+
+// WithBatchSoakSeconds sets the BatchSoakSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BatchSoakSeconds field is set to the value of the last call.
+func (b *RemediationStrategySpecApplyConfiguration) WithBatchSoakSeconds(value int) *RemediationStrategySpecApplyConfiguration {
+	b.BatchSoakSeconds = &value
+	return b
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// SeedImageRefApplyConfiguration represents an declarative configuration of the SeedImageRef type for use
+// with apply.
+type SeedImageRefApplyConfiguration struct {
+	Image         *string                          `json:"image,omitempty"`
+	Version       *string                          `json:"version,omitempty"`
+	PullSecretRef *PullSecretRefApplyConfiguration `json:"pullSecretRef,omitempty"`
+}
+
+// SeedImageRefApplyConfiguration constructs an declarative configuration of the SeedImageRef type for use with
+// apply.
+func SeedImageRef() *SeedImageRefApplyConfiguration {
+	return &SeedImageRefApplyConfiguration{}
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *SeedImageRefApplyConfiguration) WithImage(value string) *SeedImageRefApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithVersion sets the Version field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Version field is set to the value of the last call.
+func (b *SeedImageRefApplyConfiguration) WithVersion(value string) *SeedImageRefApplyConfiguration {
+	b.Version = &value
+	return b
+}
+
+// WithPullSecretRef sets the PullSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullSecretRef field is set to the value of the last call.
+func (b *SeedImageRefApplyConfiguration) WithPullSecretRef(value *PullSecretRefApplyConfiguration) *SeedImageRefApplyConfiguration {
+	b.PullSecretRef = value
+	return b
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpgradeReportSpecApplyConfiguration represents an declarative configuration of the UpgradeReportSpec type for use
+// with apply.
+type UpgradeReportSpecApplyConfiguration struct {
+	ClusterGroupUpgrade                   *ClusterGroupUpgradeReferenceApplyConfiguration `json:"clusterGroupUpgrade,omitempty"`
+	ClusterGroupUpgradeSpec               *ClusterGroupUpgradeSpecApplyConfiguration      `json:"clusterGroupUpgradeSpec,omitempty"`
+	StartedAt                             *v1.Time                                        `json:"startedAt,omitempty"`
+	CompletedAt                           *v1.Time                                        `json:"completedAt,omitempty"`
+	RemediationPlan                       [][]string                                      `json:"remediationPlan,omitempty"`
+	Clusters                              []ClusterStateApplyConfiguration                `json:"clusters,omitempty"`
+	ManagedPolicies                       []ManagedPolicyForUpgradeApplyConfiguration     `json:"managedPolicies,omitempty"`
+	ManagedPoliciesCompliantBeforeUpgrade []string                                        `json:"managedPoliciesCompliantBeforeUpgrade,omitempty"`
+	ManifestWorkTemplates                 []string                                        `json:"manifestWorkTemplates,omitempty"`
+	Precaching                            *PrecachingStatusApplyConfiguration             `json:"precaching,omitempty"`
+	Backup                                *BackupStatusApplyConfiguration                 `json:"backup,omitempty"`
+	Conditions                            []v1.Condition                                  `json:"conditions,omitempty"`
+}
+
+// UpgradeReportSpecApplyConfiguration constructs an declarative configuration of the UpgradeReportSpec type for use with
+// apply.
+func UpgradeReportSpec() *UpgradeReportSpecApplyConfiguration {
+	return &UpgradeReportSpecApplyConfiguration{}
+}
+
+// WithClusterGroupUpgrade sets the ClusterGroupUpgrade field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterGroupUpgrade field is set to the value of the last call.
+func (b *UpgradeReportSpecApplyConfiguration) WithClusterGroupUpgrade(value *ClusterGroupUpgradeReferenceApplyConfiguration) *UpgradeReportSpecApplyConfiguration {
+	b.ClusterGroupUpgrade = value
+	return b
+}
+
+// WithClusterGroupUpgradeSpec sets the ClusterGroupUpgradeSpec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterGroupUpgradeSpec field is set to the value of the last call.
+func (b *UpgradeReportSpecApplyConfiguration) WithClusterGroupUpgradeSpec(value *ClusterGroupUpgradeSpecApplyConfiguration) *UpgradeReportSpecApplyConfiguration {
+	b.ClusterGroupUpgradeSpec = value
+	return b
+}
+
+// WithStartedAt sets the StartedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StartedAt field is set to the value of the last call.
+func (b *UpgradeReportSpecApplyConfiguration) WithStartedAt(value v1.Time) *UpgradeReportSpecApplyConfiguration {
+	b.StartedAt = &value
+	return b
+}
+
+// WithCompletedAt sets the CompletedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CompletedAt field is set to the value of the last call.
+func (b *UpgradeReportSpecApplyConfiguration) WithCompletedAt(value v1.Time) *UpgradeReportSpecApplyConfiguration {
+	b.CompletedAt = &value
+	return b
+}
+
+// WithRemediationPlan adds the given value to the RemediationPlan field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RemediationPlan field.
+func (b *UpgradeReportSpecApplyConfiguration) WithRemediationPlan(values ...[]string) *UpgradeReportSpecApplyConfiguration {
+	for i := range values {
+		b.RemediationPlan = append(b.RemediationPlan, values[i])
+	}
+	return b
+}
+
+// WithClusters adds the given value to the Clusters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Clusters field.
+func (b *UpgradeReportSpecApplyConfiguration) WithClusters(values ...*ClusterStateApplyConfiguration) *UpgradeReportSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithClusters")
+		}
+		b.Clusters = append(b.Clusters, *values[i])
+	}
+	return b
+}
+
+// WithManagedPolicies adds the given value to the ManagedPolicies field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ManagedPolicies field.
+func (b *UpgradeReportSpecApplyConfiguration) WithManagedPolicies(values ...*ManagedPolicyForUpgradeApplyConfiguration) *UpgradeReportSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithManagedPolicies")
+		}
+		b.ManagedPolicies = append(b.ManagedPolicies, *values[i])
+	}
+	return b
+}
+
+// WithManagedPoliciesCompliantBeforeUpgrade adds the given value to the ManagedPoliciesCompliantBeforeUpgrade field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ManagedPoliciesCompliantBeforeUpgrade field.
+func (b *UpgradeReportSpecApplyConfiguration) WithManagedPoliciesCompliantBeforeUpgrade(values ...string) *UpgradeReportSpecApplyConfiguration {
+	for i := range values {
+		b.ManagedPoliciesCompliantBeforeUpgrade = append(b.ManagedPoliciesCompliantBeforeUpgrade, values[i])
+	}
+	return b
+}
+
+// WithManifestWorkTemplates adds the given value to the ManifestWorkTemplates field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ManifestWorkTemplates field.
+func (b *UpgradeReportSpecApplyConfiguration) WithManifestWorkTemplates(values ...string) *UpgradeReportSpecApplyConfiguration {
+	for i := range values {
+		b.ManifestWorkTemplates = append(b.ManifestWorkTemplates, values[i])
+	}
+	return b
+}
+
+// WithPrecaching sets the Precaching field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Precaching field is set to the value of the last call.
+func (b *UpgradeReportSpecApplyConfiguration) WithPrecaching(value *PrecachingStatusApplyConfiguration) *UpgradeReportSpecApplyConfiguration {
+	b.Precaching = value
+	return b
+}
+
+// WithBackup sets the Backup field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Backup field is set to the value of the last call.
+func (b *UpgradeReportSpecApplyConfiguration) WithBackup(value *BackupStatusApplyConfiguration) *UpgradeReportSpecApplyConfiguration {
+	b.Backup = value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *UpgradeReportSpecApplyConfiguration) WithConditions(values ...v1.Condition) *UpgradeReportSpecApplyConfiguration {
+	for i := range values {
+		b.Conditions = append(b.Conditions, values[i])
+	}
+	return b
+}
@@ -29,6 +29,7 @@ type UpgradeStatusApplyConfiguration struct {
 	CompletedAt                     *v1.Time                                        `json:"completedAt,omitempty"`
 	CurrentBatch                    *int                                            `json:"currentBatch,omitempty"`
 	CurrentBatchStartedAt           *v1.Time                                        `json:"currentBatchStartedAt,omitempty"`
+	CurrentBatchCompletedAt         *v1.Time                                        `json:"currentBatchCompletedAt,omitempty"`
 	CurrentBatchRemediationProgress map[string]*v1alpha1.ClusterRemediationProgress `json:"currentBatchRemediationProgress,omitempty"`
 }
 
@@ -70,6 +71,14 @@ func (b *UpgradeStatusApplyConfiguration) WithCurrentBatchStartedAt(value v1.Tim
 	return b
 }
 
+// WithCurrentBatchCompletedAt sets the CurrentBatchCompletedAt field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CurrentBatchCompletedAt field is set to the value of the last call.
+func (b *UpgradeStatusApplyConfiguration) WithCurrentBatchCompletedAt(value v1.Time) *UpgradeStatusApplyConfiguration {
+	b.CurrentBatchCompletedAt = &value
+	return b
+}
+
 // WithCurrentBatchRemediationProgress puts the entries into the CurrentBatchRemediationProgress field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the CurrentBatchRemediationProgress field,
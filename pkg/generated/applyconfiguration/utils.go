@@ -40,6 +40,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &clustergroupupgradesv1alpha1.BlockingCRApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ClusterGroupUpgrade"):
 		return &clustergroupupgradesv1alpha1.ClusterGroupUpgradeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterGroupUpgradeReference"):
+		return &clustergroupupgradesv1alpha1.ClusterGroupUpgradeReferenceApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ClusterGroupUpgradeSpec"):
 		return &clustergroupupgradesv1alpha1.ClusterGroupUpgradeSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ClusterGroupUpgradeStatus"):
@@ -48,20 +50,48 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &clustergroupupgradesv1alpha1.ClusterRemediationProgressApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ClusterState"):
 		return &clustergroupupgradesv1alpha1.ClusterStateApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterVersionProgress"):
+		return &clustergroupupgradesv1alpha1.ClusterVersionProgressApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ImageBasedUpgradeProgress"):
+		return &clustergroupupgradesv1alpha1.ImageBasedUpgradeProgressApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ImageBasedUpgradeSpec"):
+		return &clustergroupupgradesv1alpha1.ImageBasedUpgradeSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ManagedPolicyForUpgrade"):
 		return &clustergroupupgradesv1alpha1.ManagedPolicyForUpgradeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ManifestWorkFeedbackValue"):
+		return &clustergroupupgradesv1alpha1.ManifestWorkFeedbackValueApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ManifestWorkProgress"):
+		return &clustergroupupgradesv1alpha1.ManifestWorkProgressApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ManifestWorkStage"):
+		return &clustergroupupgradesv1alpha1.ManifestWorkStageApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ManifestWorkStatus"):
 		return &clustergroupupgradesv1alpha1.ManifestWorkStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OperatorUpgradeProgress"):
+		return &clustergroupupgradesv1alpha1.OperatorUpgradeProgressApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PolicyStatus"):
 		return &clustergroupupgradesv1alpha1.PolicyStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PrecachingAttempts"):
+		return &clustergroupupgradesv1alpha1.PrecachingAttemptsApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PreCachingConfigCR"):
 		return &clustergroupupgradesv1alpha1.PreCachingConfigCRApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PrecachingProgress"):
+		return &clustergroupupgradesv1alpha1.PrecachingProgressApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PrecachingSpec"):
 		return &clustergroupupgradesv1alpha1.PrecachingSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("PrecachingStatus"):
 		return &clustergroupupgradesv1alpha1.PrecachingStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PullSecretRef"):
+		return &clustergroupupgradesv1alpha1.PullSecretRefApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RegistryMirror"):
+		return &clustergroupupgradesv1alpha1.RegistryMirrorApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("RemediationStrategySpec"):
 		return &clustergroupupgradesv1alpha1.RemediationStrategySpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("SeedImageRef"):
+		return &clustergroupupgradesv1alpha1.SeedImageRefApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UpgradeReport"):
+		return &clustergroupupgradesv1alpha1.UpgradeReportApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UpgradeReportSpec"):
+		return &clustergroupupgradesv1alpha1.UpgradeReportSpecApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("UpgradeStatus"):
 		return &clustergroupupgradesv1alpha1.UpgradeStatusApplyConfiguration{}
 
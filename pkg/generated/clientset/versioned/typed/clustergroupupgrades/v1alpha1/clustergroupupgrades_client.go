@@ -28,6 +28,7 @@ import (
 type RanV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClusterGroupUpgradesGetter
+	UpgradeReportsGetter
 }
 
 // RanV1alpha1Client is used to interact with features provided by the ran.openshift.io group.
@@ -39,6 +40,10 @@ func (c *RanV1alpha1Client) ClusterGroupUpgrades(namespace string) ClusterGroupU
 	return newClusterGroupUpgrades(c, namespace)
 }
 
+func (c *RanV1alpha1Client) UpgradeReports(namespace string) UpgradeReportInterface {
+	return newUpgradeReports(c, namespace)
+}
+
 // NewForConfig creates a new RanV1alpha1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
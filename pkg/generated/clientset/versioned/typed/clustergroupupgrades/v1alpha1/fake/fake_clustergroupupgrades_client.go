@@ -31,6 +31,10 @@ func (c *FakeRanV1alpha1) ClusterGroupUpgrades(namespace string) v1alpha1.Cluste
 	return &FakeClusterGroupUpgrades{c, namespace}
 }
 
+func (c *FakeRanV1alpha1) UpgradeReports(namespace string) v1alpha1.UpgradeReportInterface {
+	return &FakeUpgradeReports{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeRanV1alpha1) RESTClient() rest.Interface {
@@ -0,0 +1,153 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+
+	v1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	clustergroupupgradesv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/generated/applyconfiguration/clustergroupupgrades/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeUpgradeReports implements UpgradeReportInterface
+type FakeUpgradeReports struct {
+	Fake *FakeRanV1alpha1
+	ns   string
+}
+
+var upgradereportsResource = v1alpha1.SchemeGroupVersion.WithResource("upgradereports")
+
+var upgradereportsKind = v1alpha1.SchemeGroupVersion.WithKind("UpgradeReport")
+
+// Get takes name of the upgradeReport, and returns the corresponding upgradeReport object, and an error if there is any.
+func (c *FakeUpgradeReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.UpgradeReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(upgradereportsResource, c.ns, name), &v1alpha1.UpgradeReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.UpgradeReport), err
+}
+
+// List takes label and field selectors, and returns the list of UpgradeReports that match those selectors.
+func (c *FakeUpgradeReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.UpgradeReportList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(upgradereportsResource, upgradereportsKind, c.ns, opts), &v1alpha1.UpgradeReportList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.UpgradeReportList{ListMeta: obj.(*v1alpha1.UpgradeReportList).ListMeta}
+	for _, item := range obj.(*v1alpha1.UpgradeReportList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested upgradeReports.
+func (c *FakeUpgradeReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(upgradereportsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a upgradeReport and creates it.  Returns the server's representation of the upgradeReport, and an error, if there is any.
+func (c *FakeUpgradeReports) Create(ctx context.Context, upgradeReport *v1alpha1.UpgradeReport, opts v1.CreateOptions) (result *v1alpha1.UpgradeReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(upgradereportsResource, c.ns, upgradeReport), &v1alpha1.UpgradeReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.UpgradeReport), err
+}
+
+// Update takes the representation of a upgradeReport and updates it. Returns the server's representation of the upgradeReport, and an error, if there is any.
+func (c *FakeUpgradeReports) Update(ctx context.Context, upgradeReport *v1alpha1.UpgradeReport, opts v1.UpdateOptions) (result *v1alpha1.UpgradeReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(upgradereportsResource, c.ns, upgradeReport), &v1alpha1.UpgradeReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.UpgradeReport), err
+}
+
+// Delete takes name of the upgradeReport and deletes it. Returns an error if one occurs.
+func (c *FakeUpgradeReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(upgradereportsResource, c.ns, name, opts), &v1alpha1.UpgradeReport{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeUpgradeReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(upgradereportsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.UpgradeReportList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched upgradeReport.
+func (c *FakeUpgradeReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.UpgradeReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(upgradereportsResource, c.ns, name, pt, data, subresources...), &v1alpha1.UpgradeReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.UpgradeReport), err
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied upgradeReport.
+func (c *FakeUpgradeReports) Apply(ctx context.Context, upgradeReport *clustergroupupgradesv1alpha1.UpgradeReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.UpgradeReport, err error) {
+	if upgradeReport == nil {
+		return nil, fmt.Errorf("upgradeReport provided to Apply must not be nil")
+	}
+	data, err := json.Marshal(upgradeReport)
+	if err != nil {
+		return nil, err
+	}
+	name := upgradeReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("upgradeReport.Name must be provided to Apply")
+	}
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(upgradereportsResource, c.ns, *name, types.ApplyPatchType, data), &v1alpha1.UpgradeReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.UpgradeReport), err
+}
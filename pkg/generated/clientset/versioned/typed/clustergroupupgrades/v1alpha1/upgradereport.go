@@ -0,0 +1,207 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	json "encoding/json"
+	"fmt"
+	"time"
+
+	v1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	clustergroupupgradesv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/generated/applyconfiguration/clustergroupupgrades/v1alpha1"
+	scheme "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/generated/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// UpgradeReportsGetter has a method to return a UpgradeReportInterface.
+// A group's client should implement this interface.
+type UpgradeReportsGetter interface {
+	UpgradeReports(namespace string) UpgradeReportInterface
+}
+
+// UpgradeReportInterface has methods to work with UpgradeReport resources.
+type UpgradeReportInterface interface {
+	Create(ctx context.Context, upgradeReport *v1alpha1.UpgradeReport, opts v1.CreateOptions) (*v1alpha1.UpgradeReport, error)
+	Update(ctx context.Context, upgradeReport *v1alpha1.UpgradeReport, opts v1.UpdateOptions) (*v1alpha1.UpgradeReport, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.UpgradeReport, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.UpgradeReportList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.UpgradeReport, err error)
+	Apply(ctx context.Context, upgradeReport *clustergroupupgradesv1alpha1.UpgradeReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.UpgradeReport, err error)
+	UpgradeReportExpansion
+}
+
+// upgradeReports implements UpgradeReportInterface
+type upgradeReports struct {
+	client rest.Interface
+	ns     string
+}
+
+// newUpgradeReports returns a UpgradeReports
+func newUpgradeReports(c *RanV1alpha1Client, namespace string) *upgradeReports {
+	return &upgradeReports{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the upgradeReport, and returns the corresponding upgradeReport object, and an error if there is any.
+func (c *upgradeReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.UpgradeReport, err error) {
+	result = &v1alpha1.UpgradeReport{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("upgradereports").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of UpgradeReports that match those selectors.
+func (c *upgradeReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.UpgradeReportList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.UpgradeReportList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("upgradereports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested upgradeReports.
+func (c *upgradeReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("upgradereports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a upgradeReport and creates it.  Returns the server's representation of the upgradeReport, and an error, if there is any.
+func (c *upgradeReports) Create(ctx context.Context, upgradeReport *v1alpha1.UpgradeReport, opts v1.CreateOptions) (result *v1alpha1.UpgradeReport, err error) {
+	result = &v1alpha1.UpgradeReport{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("upgradereports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(upgradeReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a upgradeReport and updates it. Returns the server's representation of the upgradeReport, and an error, if there is any.
+func (c *upgradeReports) Update(ctx context.Context, upgradeReport *v1alpha1.UpgradeReport, opts v1.UpdateOptions) (result *v1alpha1.UpgradeReport, err error) {
+	result = &v1alpha1.UpgradeReport{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("upgradereports").
+		Name(upgradeReport.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(upgradeReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the upgradeReport and deletes it. Returns an error if one occurs.
+func (c *upgradeReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("upgradereports").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *upgradeReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("upgradereports").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched upgradeReport.
+func (c *upgradeReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.UpgradeReport, err error) {
+	result = &v1alpha1.UpgradeReport{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("upgradereports").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Apply takes the given apply declarative configuration, applies it and returns the applied upgradeReport.
+func (c *upgradeReports) Apply(ctx context.Context, upgradeReport *clustergroupupgradesv1alpha1.UpgradeReportApplyConfiguration, opts v1.ApplyOptions) (result *v1alpha1.UpgradeReport, err error) {
+	if upgradeReport == nil {
+		return nil, fmt.Errorf("upgradeReport provided to Apply must not be nil")
+	}
+	patchOpts := opts.ToPatchOptions()
+	data, err := json.Marshal(upgradeReport)
+	if err != nil {
+		return nil, err
+	}
+	name := upgradeReport.Name
+	if name == nil {
+		return nil, fmt.Errorf("upgradeReport.Name must be provided to Apply")
+	}
+	result = &v1alpha1.UpgradeReport{}
+	err = c.client.Patch(types.ApplyPatchType).
+		Namespace(c.ns).
+		Resource("upgradereports").
+		Name(*name).
+		VersionedParams(&patchOpts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -25,6 +25,8 @@ import (
 type Interface interface {
 	// ClusterGroupUpgrades returns a ClusterGroupUpgradeInformer.
 	ClusterGroupUpgrades() ClusterGroupUpgradeInformer
+	// UpgradeReports returns a UpgradeReportInformer.
+	UpgradeReports() UpgradeReportInformer
 }
 
 type version struct {
@@ -42,3 +44,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) ClusterGroupUpgrades() ClusterGroupUpgradeInformer {
 	return &clusterGroupUpgradeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// UpgradeReports returns a UpgradeReportInformer.
+func (v *version) UpgradeReports() UpgradeReportInformer {
+	return &upgradeReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	clustergroupupgradesv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	versioned "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/generated/clientset/versioned"
+	internalinterfaces "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/generated/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/generated/listers/clustergroupupgrades/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// UpgradeReportInformer provides access to a shared informer and lister for
+// UpgradeReports.
+type UpgradeReportInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.UpgradeReportLister
+}
+
+type upgradeReportInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewUpgradeReportInformer constructs a new informer for UpgradeReport type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewUpgradeReportInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredUpgradeReportInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredUpgradeReportInformer constructs a new informer for UpgradeReport type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredUpgradeReportInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.RanV1alpha1().UpgradeReports(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.RanV1alpha1().UpgradeReports(namespace).Watch(context.TODO(), options)
+			},
+		},
+		&clustergroupupgradesv1alpha1.UpgradeReport{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *upgradeReportInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredUpgradeReportInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *upgradeReportInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&clustergroupupgradesv1alpha1.UpgradeReport{}, f.defaultInformer)
+}
+
+func (f *upgradeReportInformer) Lister() v1alpha1.UpgradeReportLister {
+	return v1alpha1.NewUpgradeReportLister(f.Informer().GetIndexer())
+}
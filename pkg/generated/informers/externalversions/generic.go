@@ -54,6 +54,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=ran.openshift.io, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("clustergroupupgrades"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ran().V1alpha1().ClusterGroupUpgrades().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("upgradereports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ran().V1alpha1().UpgradeReports().Informer()}, nil
 
 	}
 
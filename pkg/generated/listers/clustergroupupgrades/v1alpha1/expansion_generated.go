@@ -24,3 +24,11 @@ type ClusterGroupUpgradeListerExpansion interface{}
 // ClusterGroupUpgradeNamespaceListerExpansion allows custom methods to be added to
 // ClusterGroupUpgradeNamespaceLister.
 type ClusterGroupUpgradeNamespaceListerExpansion interface{}
+
+// UpgradeReportListerExpansion allows custom methods to be added to
+// UpgradeReportLister.
+type UpgradeReportListerExpansion interface{}
+
+// UpgradeReportNamespaceListerExpansion allows custom methods to be added to
+// UpgradeReportNamespaceLister.
+type UpgradeReportNamespaceListerExpansion interface{}
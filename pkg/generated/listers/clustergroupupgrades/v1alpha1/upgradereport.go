@@ -0,0 +1,98 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/api/clustergroupupgrades/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// UpgradeReportLister helps list UpgradeReports.
+// All objects returned here must be treated as read-only.
+type UpgradeReportLister interface {
+	// List lists all UpgradeReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.UpgradeReport, err error)
+	// UpgradeReports returns an object that can list and get UpgradeReports.
+	UpgradeReports(namespace string) UpgradeReportNamespaceLister
+	UpgradeReportListerExpansion
+}
+
+// upgradeReportLister implements the UpgradeReportLister interface.
+type upgradeReportLister struct {
+	indexer cache.Indexer
+}
+
+// NewUpgradeReportLister returns a new UpgradeReportLister.
+func NewUpgradeReportLister(indexer cache.Indexer) UpgradeReportLister {
+	return &upgradeReportLister{indexer: indexer}
+}
+
+// List lists all UpgradeReports in the indexer.
+func (s *upgradeReportLister) List(selector labels.Selector) (ret []*v1alpha1.UpgradeReport, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.UpgradeReport))
+	})
+	return ret, err
+}
+
+// UpgradeReports returns an object that can list and get UpgradeReports.
+func (s *upgradeReportLister) UpgradeReports(namespace string) UpgradeReportNamespaceLister {
+	return upgradeReportNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// UpgradeReportNamespaceLister helps list and get UpgradeReports.
+// All objects returned here must be treated as read-only.
+type UpgradeReportNamespaceLister interface {
+	// List lists all UpgradeReports in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.UpgradeReport, err error)
+	// Get retrieves the UpgradeReport from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.UpgradeReport, error)
+	UpgradeReportNamespaceListerExpansion
+}
+
+// upgradeReportNamespaceLister implements the UpgradeReportNamespaceLister
+// interface.
+type upgradeReportNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all UpgradeReports in the indexer for a given namespace.
+func (s upgradeReportNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.UpgradeReport, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.UpgradeReport))
+	})
+	return ret, err
+}
+
+// Get retrieves the UpgradeReport from the indexer for a given namespace and name.
+func (s upgradeReportNamespaceLister) Get(name string) (*v1alpha1.UpgradeReport, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("upgradereport"), name)
+	}
+	return obj.(*v1alpha1.UpgradeReport), nil
+}
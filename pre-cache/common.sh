@@ -5,6 +5,12 @@ PULL_SECRET_PATH="${pull_secret_path:-/var/lib/kubelet/config.json}"
 export PULL_SPEC_FILE="${pull_spec_file:-/tmp/images.txt}"
 CONFIG_VOLUME_PATH="${CONFIG_VOLUME_PATH:-/tmp/precache/config}"
 export ADDITIONAL_IMAGES_SPEC_FILE="${additional_images_spec_file:-${CONFIG_VOLUME_PATH}/additionalImages}"
+REGISTRY_MIRRORS_FILE="${registry_mirrors_file:-${CONFIG_VOLUME_PATH}/registryMirrors}"
+PROGRESS_DIR="${progress_dir:-/tmp/precache/progress}"
+SERVICE_ACCOUNT_DIR="${service_account_dir:-/var/run/secrets/kubernetes.io/serviceaccount}"
+JQ_TOOL="${jq_tool:-jq}"
+PROGRESS_CONFIGMAP="pre-cache-progress"
+PROGRESS_NAMESPACE="openshift-talo-pre-cache"
 
 if ! [[ $TEST_ENV ]]; then
 # This fixes process substitution issues in chroot
@@ -33,12 +39,62 @@ log_debug() {
     _log 2 "[DEBUG]: $*"
 }
 
+#
+# mirror_image:
+# Rewrites a digest reference to the first mirror of the most specific source repository of
+# the registry mirrors, or leaves it unchanged if none of the sources match. As on the
+# cluster, tag references are never mirrored
+#
+mirror_image(){
+    local img=$1
+    local source
+    local mirror
+    local matched_source=""
+    local matched_mirror=""
+
+    if [[ $img == *@sha256:* && -f $REGISTRY_MIRRORS_FILE ]]; then
+        while read -r source mirror _; do
+            [[ -n $source && -n $mirror ]] || continue
+            # The source matches whole repository path components only
+            case $img in
+                "$source"@*|"$source"/*) ;;
+                *) continue ;;
+            esac
+            if [[ ${#source} -gt ${#matched_source} ]]; then
+                matched_source=$source
+                matched_mirror=$mirror
+            fi
+        done < $REGISTRY_MIRRORS_FILE
+    fi
+    if [[ -n $matched_source ]]; then
+        echo "${matched_mirror}${img#"$matched_source"}"
+        return 0
+    fi
+    echo $img
+}
+
+#
+# tag_source_image:
+# Names an image pulled from a mirror after its source repository, so that CRI-O finds it
+# in the cache when looking up the source digest reference. A digest can't be a tag, the
+# image is tagged sha256-<digest> in the source repository instead
+#
+tag_source_image(){
+    local source=$1
+    local img=$2
+
+    [[ $source != "$img" ]] || return 0
+    $CONTAINER_TOOL tag $img "${source%@sha256:*}:sha256-${source##*@sha256:}"
+}
+
 pull_index(){
-    local index_pull_spec=$1
+    local index_pull_spec
+    index_pull_spec=$(mirror_image $1)
     local PULL_SECRET_PATH=$2
     # Pull the image into the cache directory and attain the image ID
     release_index_id=$($CONTAINER_TOOL pull --quiet  $index_pull_spec --authfile=$PULL_SECRET_PATH)
     [[ $? -eq 0 ]] || return 1
+    tag_source_image $1 $index_pull_spec > /dev/null || return 1
     echo $release_index_id
     return 0
 }
@@ -91,3 +147,62 @@ function with_retries {
 
     return ${rc}
 }
+
+#
+# Pre-caching progress:
+# Each value is kept in a file of PROGRESS_DIR named after its key. The pre-caching job
+# container, which holds the service account token, publishes them in the
+# pre-cache-progress ConfigMap, read by the hub through a ManagedClusterView
+#
+progress_set(){
+    local key=$1
+    local value=$2
+    mkdir -p "$PROGRESS_DIR"
+    echo -n "$value" > "$PROGRESS_DIR/$key"
+}
+
+progress_get(){
+    local key=$1
+    cat "$PROGRESS_DIR/$key" 2>/dev/null || true
+}
+
+progress_add(){
+    local key=$1
+    local count=$2
+    local value
+    value=$(progress_get $key)
+    progress_set $key $(( ${value:-0} + count ))
+}
+
+progress_append(){
+    local key=$1
+    local value=$2
+    mkdir -p "$PROGRESS_DIR"
+    echo "$value" >> "$PROGRESS_DIR/$key"
+}
+
+progress_fail(){
+    progress_set message "$*"
+}
+
+publish_progress(){
+    local token_file="$SERVICE_ACCOUNT_DIR/token"
+    local api="https://${KUBERNETES_SERVICE_HOST}:${KUBERNETES_SERVICE_PORT}/api/v1/namespaces/${PROGRESS_NAMESPACE}/configmaps"
+    local data body
+
+    # The progress is only published from the pre-caching job container
+    [[ -f $token_file ]] || return 0
+
+    data=$(for f in "$PROGRESS_DIR"/*; do
+        [[ -f $f ]] && $JQ_TOOL -n --arg key "$(basename "$f")" --arg value "$(cat "$f")" '{($key): $value}'
+    done | $JQ_TOOL -s 'add // {}')
+    body=$($JQ_TOOL -n --arg name "$PROGRESS_CONFIGMAP" --arg namespace "$PROGRESS_NAMESPACE" --argjson data "$data" \
+        '{apiVersion: "v1", kind: "ConfigMap", metadata: {name: $name, namespace: $namespace}, data: $data}')
+
+    local curl_args=(-sf -o /dev/null --cacert "$SERVICE_ACCOUNT_DIR/ca.crt"
+        -H "Authorization: Bearer $(cat $token_file)" -H "Content-Type: application/json" -d "$body")
+    if ! curl "${curl_args[@]}" -X PUT "$api/$PROGRESS_CONFIGMAP" && ! curl "${curl_args[@]}" -X POST "$api"; then
+        log_debug "Failed to publish the pre-caching progress"
+    fi
+    return 0
+}
@@ -53,6 +53,7 @@ olm_main(){
         log_debug "Operators index is not specified. Operators won't be pre-cached"
         return 0
     fi
+    progress_set phase olm
     # There could be several indexes, hence the loop
     while IFS= read -r index; do
         image_id=$(pull_index $index $PULL_SECRET_PATH)
@@ -93,5 +94,7 @@ olm_main(){
 
 if [[ "${BASH_SOURCE[0]}" = "${0}" ]]; then
     olm_main
-    exit $?
+    rv=$?
+    [[ $rv -eq 0 ]] || progress_fail "Failed to extract the operator images from the OLM indexes"
+    exit $rv
 fi
@@ -16,6 +16,21 @@ rm -f /host/tmp/images.txt
 cp -a /opt/precache /host/tmp/
 cp -rf /etc/config /host/tmp/precache/config
 
+# Publish the progress the scripts running on the host write, the service account token stays in the container
+progress_dir=/host/tmp/precache/progress
+jq_tool="chroot /host jq"
+# shellcheck source=pre-cache/common.sh
+. /opt/precache/common.sh
+publish_progress_periodically(){
+    while true; do
+        sleep 30
+        publish_progress
+    done
+}
+publish_progress_periodically &
+publisher_pid=$!
+trap 'kill $publisher_pid 2>/dev/null; publish_progress' EXIT
+
 # Check the available space for the OCP upgrade case or for pre-caching additional images
 { [ -n "$(cat /etc/config/platform.image)" ] || [ -n "$(cat /etc/config/additionalImages)" ]; } && check_disk_space=1 || check_disk_space=0
 if [[ $check_disk_space == 1 ]]; then
@@ -13,7 +13,18 @@ wait_image(){
     if [[ $? != 0 ]]; then
         log_error "Pull failed for image: ${img}! Will retry later... "
         failed_pulls+=("${img}") # Failed, then add the image to be retrieved later
+        return
     fi
+    record_pulled_image $img
+}
+
+record_pulled_image(){
+    local img=$1
+    local size
+
+    progress_add imagesPulled 1
+    size=$($CONTAINER_TOOL image inspect --format '{{.Size}}' $img 2>/dev/null)
+    [[ $size =~ ^[0-9]+$ ]] && progress_add bytesPulled $size
 }
 
 mirror_images() {
@@ -32,21 +43,26 @@ mirror_images() {
     local total_pulls
     total_pulls=$(sort -u $pull_file | wc -l)  # Required to keep track of the pull task vs total
     local current_pull=1
+    progress_add imagesTotal $total_pulls
 
     # for line in $(sort -u $pull_file); do
     while IFS= read -r line; do
         # Strip double quotes
         img="${line%\"}"
         img="${img#\"}"
+        source_img=$img
+        img=$(mirror_image $img)
         log_debug "Pulling ${img} [${current_pull}/${total_pulls}]"
         # If image is on disk, then skip. This improves the global performance
         $CONTAINER_TOOL image exists $img
         if [[ $? == 0 ]]; then
             log_debug "Skipping existing image $img"
+            tag_source_image $source_img $img > /dev/null
+            progress_add imagesPulled 1
             current_pull=$((current_pull + 1))
             continue
         fi
-        $CONTAINER_TOOL pull $img --authfile=$PULL_SECRET_PATH -q > /dev/null &
+        { $CONTAINER_TOOL pull $img --authfile=$PULL_SECRET_PATH -q > /dev/null && tag_source_image $source_img $img > /dev/null; } &
         #$CONTAINER_TOOL copy docker://${img} --authfile=/var/lib/kubelet/config.json containers-storage:${img} -q & # SKOPEO 
         pids[${img}]=$! # Keeping track of the PID and container image in case the pull fails
         max_bg=$((max_bg - 1)) # Batch size adapted 
@@ -81,11 +97,14 @@ retry_images() {
             $CONTAINER_TOOL pull $failed_pull --authfile=$PULL_SECRET_PATH
             if [[ $? == 0 ]]; then
                 success=1
+                record_pulled_image $failed_pull
             fi
             iterations=$((iterations - 1))
         done
         if [[ $success == 0 ]]; then
             log_error "Limit number of retries reached. The image  ${failed_pull} could not be pulled."
+            progress_add imagesFailed 1
+            progress_append failedImages $failed_pull
             rv=1
         fi
     done
@@ -97,6 +116,7 @@ pre_cache_images(){
     local pull_type=$2
 
     log_info "Image pre-caching starting for ${pull_type}"
+    progress_set phase ${pull_type%-images}
 
     failed_pulls=() # Clear the failed_pull array
     mirror_images $pull_file $pull_type
@@ -105,6 +125,7 @@ pre_cache_images(){
     retry_images $pull_type # Return 1 if max.retries reached
     if [[ $? -ne 0 ]]; then
         log_error "One or more images were not pre-cached successfully for ${pull_type}"
+        progress_fail "$(progress_get imagesFailed) images could not be pulled for ${pull_type}"
         return 1
     fi
 
@@ -127,5 +148,6 @@ if [[ "${BASH_SOURCE[0]}" = "${0}" ]]; then
                 [[ $? -eq 0 ]] || exit 1
         fi
     done
+    progress_set phase completed
     exit 0
 fi
@@ -25,6 +25,7 @@ release_main(){
         log_debug "Release index is not specified. Release images will not be pre-cached"
         return 0
     fi
+    progress_set phase release
     release_index_id=$(pull_index $rel_img $PULL_SECRET_PATH)
     [[ $? -eq 0 ]] || return 1
     rel_img_mount=$(mount_index $release_index_id)
@@ -38,5 +39,7 @@ release_main(){
 
 if [[ "${BASH_SOURCE[0]}" = "${0}" ]]; then
     release_main
-    exit $?
+    rv=$?
+    [[ $rv -eq 0 ]] || progress_fail "Failed to extract the release images from $(cat $CONFIG_VOLUME_PATH/platform.image)"
+    exit $rv
 fi
@@ -75,6 +75,23 @@ result=$(unmount_index test)
 [[ $result == "image unmount test" ]]  || fatal "Index image unmount failure"
 echo " Index image unmount pass"
 
+# Test registry mirrors
+echo "Testing registry mirrors:"
+REGISTRY_MIRRORS_FILE=/tmp/registryMirrors
+echo -e "  quay.io/openshift-release-dev/ocp-v4.0-art-dev mirror.example.com/ocp/release mirror2.example.com/ocp \n  quay.io mirror.example.com/quay \n" > $REGISTRY_MIRRORS_FILE
+[[ $(mirror_image quay.io/openshift-release-dev/ocp-v4.0-art-dev@sha256:1234) == "mirror.example.com/ocp/release@sha256:1234" ]] || fatal "most specific mirror failure"
+[[ $(mirror_image quay.io/1@sha256:1234) == "mirror.example.com/quay/1@sha256:1234" ]] || fatal "registry mirror failure"
+[[ $(mirror_image quay.io/1:tag) == "quay.io/1:tag" ]] || fatal "tag reference mirror failure"
+[[ $(mirror_image quay.iox/1) == "quay.iox/1" ]] || fatal "partial source match failure"
+[[ $(mirror_image registry.example.com/1) == "registry.example.com/1" ]] || fatal "unmirrored image failure"
+result=$(pull_index "quay.io/index:v4.14" $PULL_SECRET_PATH)
+[[ $result == "pull --quiet quay.io/index:v4.14 --authfile=$PULL_SECRET_PATH" ]] || fatal "tag index pull failure"
+result=$(pull_index "quay.io/index@sha256:1234" $PULL_SECRET_PATH)
+[[ $result == "pull --quiet mirror.example.com/quay/index@sha256:1234 --authfile=$PULL_SECRET_PATH" ]] || fatal "mirrored index pull failure"
+[[ $(tag_source_image quay.io/index@sha256:1234 mirror.example.com/quay/index@sha256:1234) == "tag mirror.example.com/quay/index@sha256:1234 quay.io/index:sha256-1234" ]] || fatal "source image tag failure"
+[[ -z $(tag_source_image quay.io/index:v4.14 quay.io/index:v4.14) ]] || fatal "unmirrored image tag failure"
+echo " registry mirrors pass"
+
 # Test olm
 echo "Testing olm unit:"
 result=$(extract_packages)
@@ -88,5 +105,33 @@ result=$(extract_pull_spec "/tmp")
 [[ $(cat $PULL_SPEC_FILE) == "\"quay.io/1\"" ]] || fatal "release pull spec extract failure"
 echo " release extract_pull_spec pass"
 
+# Test progress
+echo "Testing progress unit:"
+PROGRESS_DIR=/tmp/precache-progress
+progress_add imagesPulled 2
+progress_add imagesPulled 3
+[[ $(progress_get imagesPulled) == "5" ]] || fatal "progress counter failure"
+progress_append failedImages quay.io/1
+progress_append failedImages quay.io/2
+[[ $(progress_get failedImages) == $'quay.io/1\nquay.io/2' ]] || fatal "progress list failure"
+publish_progress || fatal "publish_progress without service account failure"
+SERVICE_ACCOUNT_DIR=/tmp/precache-serviceaccount
+mkdir -p $SERVICE_ACCOUNT_DIR
+echo -n token > $SERVICE_ACCOUNT_DIR/token
+curl(){
+    local arg
+    while [[ $# -gt 0 ]]; do
+        arg=$1
+        shift
+        [[ $arg == "-d" ]] && echo "$1" > /tmp/precache-progress-body
+    done
+    return 0
+}
+publish_progress || fatal "publish_progress with service account failure"
+unset -f curl
+[[ $(jq -r .data.imagesPulled /tmp/precache-progress-body) == "5" ]] || fatal "publish_progress counter failure"
+[[ $(jq -r .data.failedImages /tmp/precache-progress-body) == $'quay.io/1\nquay.io/2' ]] || fatal "publish_progress list failure"
+echo " progress pass"
+
 # Clean
-rm -rf /tmp/operators.indexes /tmp/release-manifests $PULL_SPEC_FILE /tmp/operators.packagesAndChannels
+rm -rf /tmp/operators.indexes /tmp/release-manifests $PULL_SPEC_FILE /tmp/operators.packagesAndChannels $PROGRESS_DIR $SERVICE_ACCOUNT_DIR /tmp/precache-progress-body $REGISTRY_MIRRORS_FILE